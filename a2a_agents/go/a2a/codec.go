@@ -0,0 +1,61 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Codec serializes and deserializes JSON. Encoding dominates CPU for large
+// surfaces, so deployments can swap in a faster implementation (e.g. a
+// code-generated or SIMD-backed encoder) without forking the server and
+// executor plumbing. Implementations must produce and accept standard JSON;
+// they must honor json.Marshaler so pass-through parts keep their verbatim
+// bytes.
+type Codec interface {
+	// Marshal returns the JSON encoding of v.
+	Marshal(v any) ([]byte, error)
+	// Unmarshal parses JSON data into v.
+	Unmarshal(data []byte, v any) error
+	// Encode writes the JSON encoding of v to w, followed by a newline,
+	// matching json.Encoder. Streaming sites such as SSE framing and
+	// payload hashing use it to avoid an intermediate copy.
+	Encode(w io.Writer, v any) error
+}
+
+// stdlibCodec is the default Codec backed by encoding/json.
+type stdlibCodec struct{}
+
+func (stdlibCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (stdlibCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (stdlibCodec) Encode(w io.Writer, v any) error    { return json.NewEncoder(w).Encode(v) }
+
+var activeCodec Codec = stdlibCodec{}
+
+// SetCodec installs the Codec used for all A2A serialization. It must be
+// called before the server starts handling requests; it is not safe to
+// swap codecs while requests are in flight.
+func SetCodec(c Codec) {
+	if c == nil {
+		c = stdlibCodec{}
+	}
+	activeCodec = c
+}
+
+// ActiveCodec returns the Codec currently in use.
+func ActiveCodec() Codec {
+	return activeCodec
+}
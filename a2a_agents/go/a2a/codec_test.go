@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+// countingCodec wraps the default codec and counts calls, to verify the
+// plumbing routes through the installed codec.
+type countingCodec struct {
+	Codec
+	marshals, unmarshals, encodes int
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	c.marshals++
+	return c.Codec.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.unmarshals++
+	return c.Codec.Unmarshal(data, v)
+}
+
+func (c *countingCodec) Encode(w io.Writer, v any) error {
+	c.encodes++
+	return c.Codec.Encode(w, v)
+}
+
+func TestSetCodec(t *testing.T) {
+	counting := &countingCodec{Codec: ActiveCodec()}
+	SetCodec(counting)
+	defer SetCodec(nil)
+
+	task := &Task{ID: "t1", ContextID: "c1"}
+	if _, err := cloneTask(task); err != nil {
+		t.Fatalf("cloneTask: %v", err)
+	}
+	if counting.marshals == 0 || counting.unmarshals == 0 {
+		t.Errorf("installed codec not used: %d marshals, %d unmarshals",
+			counting.marshals, counting.unmarshals)
+	}
+
+	// SetCodec(nil) restores the default.
+	SetCodec(nil)
+	if _, ok := ActiveCodec().(stdlibCodec); !ok {
+		t.Errorf("SetCodec(nil) left %T active", ActiveCodec())
+	}
+}
+
+// benchmarkEvent builds an artifact event the size of a large dashboard
+// surface. The benchmarks run against ActiveCodec, so a plugged codec can
+// be measured against the stdlib baseline with the same workload.
+func benchmarkEvent(components int) *ArtifactUpdateEvent {
+	data := map[string]any{
+		"surfaceUpdate": map[string]any{
+			"surfaceId":  "main",
+			"components": make([]any, 0, components),
+		},
+	}
+	list := data["surfaceUpdate"].(map[string]any)
+	for i := 0; i < components; i++ {
+		list["components"] = append(list["components"].([]any), map[string]any{
+			"id": fmt.Sprintf("text%d", i),
+			"component": map[string]any{
+				"Text": map[string]any{
+					"text": map[string]any{"literalString": "The quick brown fox jumps over the lazy dog."},
+				},
+			},
+		})
+	}
+	return &ArtifactUpdateEvent{
+		TaskID:    "bench",
+		ContextID: "bench",
+		Artifact: Artifact{
+			ArtifactID: "a1",
+			Name:       "a2ui",
+			Parts:      []Part{NewDataPart(data, nil)},
+		},
+	}
+}
+
+func BenchmarkCodecMarshal(b *testing.B) {
+	event := benchmarkEvent(200)
+	raw, err := ActiveCodec().Marshal(event)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(raw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ActiveCodec().Marshal(event); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCodecEncode(b *testing.B) {
+	event := benchmarkEvent(200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := ActiveCodec().Encode(io.Discard, event); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCodecUnmarshal(b *testing.B) {
+	raw, err := ActiveCodec().Marshal(benchmarkEvent(200))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(raw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var event ArtifactUpdateEvent
+		if err := ActiveCodec().Unmarshal(raw, &event); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
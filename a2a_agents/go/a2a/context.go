@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import "context"
+
+// RequestContext carries the incoming message and task identity for one
+// execution, plus the extension negotiation state.
+type RequestContext struct {
+	// TaskID identifies the task this execution works on.
+	TaskID string
+	// ContextID identifies the conversation the task belongs to.
+	ContextID string
+	// Message is the user message that triggered the execution.
+	Message *Message
+
+	requested []string
+	activated []string
+}
+
+// NewRequestContext builds a request context for the given message. The
+// requested extension URIs typically come from the transport (e.g. the
+// X-A2A-Extensions header) in addition to the message's own extensions.
+func NewRequestContext(message *Message, taskID, contextID string, requestedExtensions []string) *RequestContext {
+	return &RequestContext{
+		TaskID:    taskID,
+		ContextID: contextID,
+		Message:   message,
+		requested: requestedExtensions,
+	}
+}
+
+// RequestedExtensions returns the extension URIs the client asked for.
+func (c *RequestContext) RequestedExtensions() []string {
+	return c.requested
+}
+
+// AddActivatedExtension records that the agent activated an extension for
+// this request.
+func (c *RequestContext) AddActivatedExtension(uri string) {
+	for _, existing := range c.activated {
+		if existing == uri {
+			return
+		}
+	}
+	c.activated = append(c.activated, uri)
+}
+
+// ActivatedExtensions returns the extension URIs activated so far.
+func (c *RequestContext) ActivatedExtensions() []string {
+	return c.activated
+}
+
+// AgentExecutor is implemented by agents. Execute processes the request and
+// publishes events on the queue; it must leave the task in a final state.
+type AgentExecutor interface {
+	Execute(ctx context.Context, rc *RequestContext, queue *EventQueue) error
+	Cancel(ctx context.Context, rc *RequestContext, queue *EventQueue) error
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Event is an update an executor publishes while working on a task. It is
+// one of StatusUpdateEvent or ArtifactUpdateEvent.
+type Event interface {
+	isEvent()
+}
+
+// StatusUpdateEvent reports a task state change.
+type StatusUpdateEvent struct {
+	TaskID    string         `json:"taskId"`
+	ContextID string         `json:"contextId"`
+	Status    TaskStatus     `json:"status"`
+	Final     bool           `json:"final"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+func (*StatusUpdateEvent) isEvent() {}
+
+// MarshalJSON adds the A2A "kind" discriminator.
+func (e *StatusUpdateEvent) MarshalJSON() ([]byte, error) {
+	type alias StatusUpdateEvent
+	return json.Marshal(struct {
+		Kind string `json:"kind"`
+		*alias
+	}{"status-update", (*alias)(e)})
+}
+
+// ArtifactUpdateEvent delivers a task artifact, such as a set of A2UI parts.
+type ArtifactUpdateEvent struct {
+	TaskID    string         `json:"taskId"`
+	ContextID string         `json:"contextId"`
+	Artifact  Artifact       `json:"artifact"`
+	Append    bool           `json:"append,omitempty"`
+	LastChunk bool           `json:"lastChunk,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+func (*ArtifactUpdateEvent) isEvent() {}
+
+// MarshalJSON adds the A2A "kind" discriminator.
+func (e *ArtifactUpdateEvent) MarshalJSON() ([]byte, error) {
+	type alias ArtifactUpdateEvent
+	return json.Marshal(struct {
+		Kind string `json:"kind"`
+		*alias
+	}{"artifact-update", (*alias)(e)})
+}
+
+// ErrQueueClosed is returned by EventQueue.Write after the queue is closed.
+var ErrQueueClosed = errors.New("a2a: event queue is closed")
+
+// ErrQueueStalled is returned by EventQueue.Write when the buffer stayed
+// full for the whole write timeout — the consumer has stopped reading the
+// stream. The queue closes itself so the producing task unwinds instead of
+// blocking forever.
+var ErrQueueStalled = errors.New("a2a: event queue stalled; consumer stopped reading")
+
+// defaultQueueSize bounds an event queue when no explicit size is given.
+const defaultQueueSize = 64
+
+// EventQueue carries events from an executor to the transport delivering
+// them to the client.
+type EventQueue struct {
+	ch           chan Event
+	done         chan struct{}
+	closeOnce    sync.Once
+	writeTimeout time.Duration
+}
+
+// NewEventQueue returns a queue buffering up to size events; size <= 0 uses
+// a reasonable default.
+func NewEventQueue(size int) *EventQueue {
+	if size <= 0 {
+		size = defaultQueueSize
+	}
+	return &EventQueue{ch: make(chan Event, size), done: make(chan struct{})}
+}
+
+// SetWriteTimeout bounds how long Write blocks on a full buffer before
+// giving up with ErrQueueStalled. Zero (the default) blocks indefinitely.
+// It must be called before the queue is shared with a producer.
+func (q *EventQueue) SetWriteTimeout(d time.Duration) {
+	q.writeTimeout = d
+}
+
+// Write publishes an event, blocking while the buffer is full. With a
+// write timeout set, a write that cannot complete in time closes the
+// queue and returns ErrQueueStalled.
+func (q *EventQueue) Write(event Event) error {
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+	if q.writeTimeout > 0 {
+		timer := time.NewTimer(q.writeTimeout)
+		defer timer.Stop()
+		select {
+		case q.ch <- event:
+			return nil
+		case <-timer.C:
+			q.Close()
+			return ErrQueueStalled
+		case <-q.done:
+			return ErrQueueClosed
+		}
+	}
+	select {
+	case q.ch <- event:
+		return nil
+	case <-q.done:
+		return ErrQueueClosed
+	}
+}
+
+// Read returns the next event. It returns false once the queue is closed and
+// all buffered events have been consumed.
+func (q *EventQueue) Read() (Event, bool) {
+	select {
+	case event := <-q.ch:
+		return event, true
+	case <-q.done:
+		// Drain anything buffered before reporting closure.
+		select {
+		case event := <-q.ch:
+			return event, true
+		default:
+			return nil, false
+		}
+	}
+}
+
+// Close marks the queue complete. Further writes fail with ErrQueueClosed;
+// buffered events remain readable.
+func (q *EventQueue) Close() {
+	q.closeOnce.Do(func() { close(q.done) })
+}
+
+// Closed reports whether the queue has been closed — by the transport or
+// by a stalled write — so producers can abandon work that has nowhere to
+// go.
+func (q *EventQueue) Closed() bool {
+	select {
+	case <-q.done:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEventQueueWriteTimeout(t *testing.T) {
+	queue := NewEventQueue(1)
+	queue.SetWriteTimeout(10 * time.Millisecond)
+	event := &StatusUpdateEvent{TaskID: "t1"}
+	if err := queue.Write(event); err != nil {
+		t.Fatalf("first write: %v", err)
+	}
+	// Nobody reads; the second write must stall out instead of blocking.
+	err := queue.Write(event)
+	if !errors.Is(err, ErrQueueStalled) {
+		t.Fatalf("Write on full queue = %v, want ErrQueueStalled", err)
+	}
+	if !queue.Closed() {
+		t.Error("queue not closed after a stalled write")
+	}
+	if err := queue.Write(event); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Write after stall = %v, want ErrQueueClosed", err)
+	}
+	// The buffered event stays readable so the transport can drain.
+	if _, ok := queue.Read(); !ok {
+		t.Error("buffered event lost after stall")
+	}
+}
+
+func TestEventQueueWriteUnblocksOnRead(t *testing.T) {
+	queue := NewEventQueue(1)
+	queue.SetWriteTimeout(time.Second)
+	event := &StatusUpdateEvent{TaskID: "t1"}
+	if err := queue.Write(event); err != nil {
+		t.Fatalf("first write: %v", err)
+	}
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		queue.Read()
+	}()
+	if err := queue.Write(event); err != nil {
+		t.Errorf("write with live consumer: %v", err)
+	}
+}
+
+func TestEventQueueClosedReporting(t *testing.T) {
+	queue := NewEventQueue(1)
+	if queue.Closed() {
+		t.Error("new queue reports closed")
+	}
+	queue.Close()
+	if !queue.Closed() {
+		t.Error("closed queue reports open")
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ActivatedExtensionsKey is the task metadata key under which the server
+// lists the extension URIs activated for the request, so clients reading
+// the task result in-band see the outcome of negotiation alongside the
+// response header.
+const ActivatedExtensionsKey = "a2aActivatedExtensions"
+
+// ExtensionHandler activates one requested extension on the request
+// context and reports whether it did; a handler may decline, e.g. when
+// the request lacks a capability the extension needs.
+type ExtensionHandler func(rc *RequestContext) bool
+
+// ExtensionSet maps the extension URIs an agent understands to their
+// activation handlers, so agents composing several extensions activate
+// each one the same way without clobbering each other's call-context
+// handling.
+type ExtensionSet struct {
+	handlers map[string]ExtensionHandler
+}
+
+// NewExtensionSet returns an empty set.
+func NewExtensionSet() *ExtensionSet {
+	return &ExtensionSet{handlers: map[string]ExtensionHandler{}}
+}
+
+// Handle registers the handler for one extension URI. A nil handler
+// plainly records the activation, for extensions without per-request
+// setup.
+func (s *ExtensionSet) Handle(uri string, handler ExtensionHandler) {
+	if handler == nil {
+		handler = func(rc *RequestContext) bool {
+			rc.AddActivatedExtension(uri)
+			return true
+		}
+	}
+	s.handlers[uri] = handler
+}
+
+// Activate walks every extension the request asked for — via the
+// transport and via the message itself — runs the registered handler for
+// each known URI, and returns what was activated alongside the URIs no
+// handler covers, so the agent can log or reject those rather than
+// silently ignore them.
+func (s *ExtensionSet) Activate(rc *RequestContext) (activated, unknown []string) {
+	seen := map[string]bool{}
+	requested := rc.RequestedExtensions()
+	if rc.Message != nil {
+		requested = append(append([]string{}, requested...), rc.Message.Extensions...)
+	}
+	for _, uri := range requested {
+		if seen[uri] {
+			continue
+		}
+		seen[uri] = true
+		handler, ok := s.handlers[uri]
+		if !ok {
+			unknown = append(unknown, uri)
+			continue
+		}
+		if handler(rc) {
+			activated = append(activated, uri)
+		}
+	}
+	return activated, unknown
+}
+
+// ActivatedExtensions parses the extension URIs a server echoed in a
+// response's ExtensionsHeader, so clients can distinguish "the agent
+// ignored the extension" from "the agent activated it but had nothing to
+// send this turn".
+func ActivatedExtensions(header http.Header) []string {
+	var uris []string
+	for _, value := range header.Values(ExtensionsHeader) {
+		for _, uri := range strings.Split(value, ",") {
+			if uri = strings.TrimSpace(uri); uri != "" {
+				uris = append(uris, uri)
+			}
+		}
+	}
+	return uris
+}
+
+// TaskActivatedExtensions returns the extension URIs recorded in a
+// task's metadata under ActivatedExtensionsKey, covering clients that
+// cannot see response headers.
+func TaskActivatedExtensions(task *Task) []string {
+	if task == nil || task.Metadata == nil {
+		return nil
+	}
+	raw, _ := task.Metadata[ActivatedExtensionsKey].([]any)
+	var uris []string
+	for _, entry := range raw {
+		if uri, ok := entry.(string); ok {
+			uris = append(uris, uri)
+		}
+	}
+	// The list may not have round-tripped through JSON yet.
+	if uris == nil {
+		uris, _ = task.Metadata[ActivatedExtensionsKey].([]string)
+	}
+	return uris
+}
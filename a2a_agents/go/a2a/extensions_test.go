@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtensionSetActivate(t *testing.T) {
+	set := NewExtensionSet()
+	set.Handle("https://example.com/ui", nil)
+	set.Handle("https://example.com/declined", func(rc *RequestContext) bool {
+		return false
+	})
+
+	msg := &Message{MessageID: "m1", Extensions: []string{"https://example.com/ui"}}
+	rc := NewRequestContext(msg, "task1", "ctx1", []string{
+		"https://example.com/ui", // requested twice: header and message
+		"https://example.com/declined",
+		"https://example.com/unknown",
+	})
+	activated, unknown := set.Activate(rc)
+	if want := []string{"https://example.com/ui"}; !reflect.DeepEqual(activated, want) {
+		t.Errorf("activated = %v, want %v", activated, want)
+	}
+	if want := []string{"https://example.com/unknown"}; !reflect.DeepEqual(unknown, want) {
+		t.Errorf("unknown = %v, want %v", unknown, want)
+	}
+	// The nil handler recorded the activation on the context.
+	if want := []string{"https://example.com/ui"}; !reflect.DeepEqual(rc.ActivatedExtensions(), want) {
+		t.Errorf("context activated = %v, want %v", rc.ActivatedExtensions(), want)
+	}
+}
+
+func TestExtensionSetMessageOnlyRequest(t *testing.T) {
+	set := NewExtensionSet()
+	handled := false
+	set.Handle("https://example.com/ui", func(rc *RequestContext) bool {
+		handled = true
+		rc.AddActivatedExtension("https://example.com/ui")
+		return true
+	})
+	msg := &Message{MessageID: "m1", Extensions: []string{"https://example.com/ui"}}
+	rc := NewRequestContext(msg, "task1", "ctx1", nil)
+	if activated, _ := set.Activate(rc); len(activated) != 1 || !handled {
+		t.Errorf("message-carried request not activated: %v", activated)
+	}
+}
+
+// activatingExecutor activates its extension and completes the task.
+type activatingExecutor struct {
+	uri string
+}
+
+func (e *activatingExecutor) Execute(ctx context.Context, rc *RequestContext, queue *EventQueue) error {
+	for _, uri := range rc.RequestedExtensions() {
+		if uri == e.uri {
+			rc.AddActivatedExtension(uri)
+		}
+	}
+	return queue.Write(&StatusUpdateEvent{
+		TaskID:    rc.TaskID,
+		ContextID: rc.ContextID,
+		Status:    NewTaskStatus(TaskStateCompleted, nil),
+		Final:     true,
+	})
+}
+
+func (e *activatingExecutor) Cancel(ctx context.Context, rc *RequestContext, queue *EventQueue) error {
+	return queue.Write(&StatusUpdateEvent{
+		TaskID:    rc.TaskID,
+		ContextID: rc.ContextID,
+		Status:    NewTaskStatus(TaskStateCanceled, nil),
+		Final:     true,
+	})
+}
+
+func TestServerEchoesActivatedExtensions(t *testing.T) {
+	const uri = "https://example.com/ui"
+	server := NewServer(AgentCard{Name: "test"}, &activatingExecutor{uri: uri})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(sendBody))
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set(ExtensionsHeader, uri+", https://example.com/other")
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	if got := ActivatedExtensions(rec.Result().Header); !reflect.DeepEqual(got, []string{uri}) {
+		t.Errorf("header activated = %v, want [%s]", got, uri)
+	}
+	var resp rpcResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	raw, _ := json.Marshal(resp.Result)
+	var task Task
+	if err := json.Unmarshal(raw, &task); err != nil {
+		t.Fatalf("result is not a task: %v", err)
+	}
+	if got := TaskActivatedExtensions(&task); !reflect.DeepEqual(got, []string{uri}) {
+		t.Errorf("task activated = %v, want [%s]", got, uri)
+	}
+
+	// Without a request, nothing is echoed.
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(sendBody))
+	req.RemoteAddr = "192.0.2.1:1234"
+	server.ServeHTTP(rec, req)
+	if got := ActivatedExtensions(rec.Result().Header); got != nil {
+		t.Errorf("unrequested activation echoed: %v", got)
+	}
+}
+
+func TestStreamEchoesActivatedExtensions(t *testing.T) {
+	const uri = "https://example.com/ui"
+	server := NewServer(AgentCard{Name: "test"}, &activatingExecutor{uri: uri})
+	body := strings.Replace(sendBody, "message/send", "message/stream", 1)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set(ExtensionsHeader, uri)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	if got := ActivatedExtensions(rec.Result().Header); !reflect.DeepEqual(got, []string{uri}) {
+		t.Errorf("header activated = %v, want [%s]", got, uri)
+	}
+	if !strings.Contains(rec.Body.String(), "status-update") {
+		t.Error("stream carried no events")
+	}
+}
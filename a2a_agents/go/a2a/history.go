@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"context"
+	"log/slog"
+)
+
+// HistoryPolicy caps how much history and artifact data a saved task may
+// carry, so long conversations do not grow task documents without bound.
+// Zero fields leave the corresponding dimension unlimited.
+type HistoryPolicy struct {
+	// MaxTurns keeps at most the newest N history messages.
+	MaxTurns int
+	// MaxArtifacts keeps at most the newest N artifacts; older A2UI
+	// payloads are superseded by later surface updates anyway.
+	MaxArtifacts int
+	// Summarize, when set, receives the history messages about to be
+	// dropped and returns a summary stored as a single agent message in
+	// their place — the hook where an LLM backend can write the summary.
+	// A failure is logged and the turns are dropped without one.
+	Summarize func(ctx context.Context, dropped []Message) (string, error)
+}
+
+// NewTrimmingTaskStore wraps a store so every Save applies the history
+// policy first. Get (and List, when the underlying store supports it)
+// pass through unchanged.
+func NewTrimmingTaskStore(store TaskStore, policy HistoryPolicy) TaskStore {
+	return &trimmingTaskStore{store: store, policy: policy}
+}
+
+type trimmingTaskStore struct {
+	store  TaskStore
+	policy HistoryPolicy
+}
+
+// Save trims the task per the policy and stores the result. The caller's
+// task is not modified.
+func (s *trimmingTaskStore) Save(ctx context.Context, task *Task) error {
+	return s.store.Save(ctx, s.policy.apply(ctx, task))
+}
+
+// Get returns the stored (already trimmed) task.
+func (s *trimmingTaskStore) Get(ctx context.Context, id string) (*Task, error) {
+	return s.store.Get(ctx, id)
+}
+
+// List passes through to the underlying store; it returns
+// ErrListingUnsupported when the store cannot list.
+func (s *trimmingTaskStore) List(ctx context.Context, filter TaskFilter) ([]*Task, error) {
+	if lister, ok := s.store.(TaskLister); ok {
+		return lister.List(ctx, filter)
+	}
+	return nil, ErrListingUnsupported
+}
+
+// apply returns the task with history and artifacts capped. When nothing
+// is over a cap the task is returned as is.
+func (p HistoryPolicy) apply(ctx context.Context, task *Task) *Task {
+	overTurns := p.MaxTurns > 0 && len(task.History) > p.MaxTurns
+	overArtifacts := p.MaxArtifacts > 0 && len(task.Artifacts) > p.MaxArtifacts
+	if !overTurns && !overArtifacts {
+		return task
+	}
+
+	trimmed := *task
+	if overTurns {
+		dropped := task.History[:len(task.History)-p.MaxTurns]
+		kept := task.History[len(task.History)-p.MaxTurns:]
+		if p.Summarize != nil {
+			summary, err := p.Summarize(ctx, dropped)
+			if err != nil {
+				slog.Warn("history summarization failed, dropping turns", "task", task.ID, "dropped", len(dropped), "error", err)
+			} else if summary != "" {
+				kept = append([]Message{{
+					MessageID: NewID(),
+					Role:      RoleAgent,
+					Parts:     []Part{NewTextPart(summary)},
+					TaskID:    task.ID,
+					ContextID: task.ContextID,
+				}}, kept...)
+			}
+		}
+		trimmed.History = kept
+	}
+	if overArtifacts {
+		trimmed.Artifacts = task.Artifacts[len(task.Artifacts)-p.MaxArtifacts:]
+	}
+	return &trimmed
+}
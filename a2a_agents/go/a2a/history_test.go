@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// historyTask builds a task with n history messages and m artifacts.
+func historyTask(n, m int) *Task {
+	task := &Task{ID: "t1", ContextID: "ctx", Status: NewTaskStatus(TaskStateWorking, nil)}
+	for i := 0; i < n; i++ {
+		task.History = append(task.History, Message{
+			MessageID: fmt.Sprintf("m%d", i),
+			Role:      RoleUser,
+			Parts:     []Part{NewTextPart(fmt.Sprintf("turn %d", i))},
+		})
+	}
+	for i := 0; i < m; i++ {
+		task.Artifacts = append(task.Artifacts, Artifact{ArtifactID: fmt.Sprintf("a%d", i)})
+	}
+	return task
+}
+
+func TestTrimmingTaskStoreCapsHistory(t *testing.T) {
+	store := NewTrimmingTaskStore(NewInMemoryTaskStore(), HistoryPolicy{
+		MaxTurns:     2,
+		MaxArtifacts: 1,
+	})
+	original := historyTask(5, 3)
+	if err := store.Save(context.Background(), original); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	// The caller's copy stays intact.
+	if len(original.History) != 5 || len(original.Artifacts) != 3 {
+		t.Errorf("caller's task was trimmed: %d turns, %d artifacts", len(original.History), len(original.Artifacts))
+	}
+
+	saved, err := store.Get(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(saved.History) != 2 || saved.History[0].MessageID != "m3" {
+		t.Errorf("history = %+v, want the newest two turns", saved.History)
+	}
+	if len(saved.Artifacts) != 1 || saved.Artifacts[0].ArtifactID != "a2" {
+		t.Errorf("artifacts = %+v, want the newest one", saved.Artifacts)
+	}
+}
+
+func TestTrimmingTaskStoreSummarizes(t *testing.T) {
+	store := NewTrimmingTaskStore(NewInMemoryTaskStore(), HistoryPolicy{
+		MaxTurns: 1,
+		Summarize: func(ctx context.Context, dropped []Message) (string, error) {
+			return fmt.Sprintf("Summary of %d earlier turns.", len(dropped)), nil
+		},
+	})
+	if err := store.Save(context.Background(), historyTask(4, 0)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	saved, err := store.Get(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(saved.History) != 2 {
+		t.Fatalf("history = %+v, want summary plus one kept turn", saved.History)
+	}
+	summary := saved.History[0]
+	if summary.Role != RoleAgent || !strings.Contains(summary.Parts[0].Text, "3 earlier turns") {
+		t.Errorf("summary message = %+v", summary)
+	}
+	if saved.History[1].MessageID != "m3" {
+		t.Errorf("kept turn = %+v, want the newest", saved.History[1])
+	}
+}
+
+func TestTrimmingTaskStoreSummarizeFailure(t *testing.T) {
+	store := NewTrimmingTaskStore(NewInMemoryTaskStore(), HistoryPolicy{
+		MaxTurns: 1,
+		Summarize: func(ctx context.Context, dropped []Message) (string, error) {
+			return "", errors.New("backend unavailable")
+		},
+	})
+	if err := store.Save(context.Background(), historyTask(3, 0)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	saved, err := store.Get(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	// The turns are dropped without a summary rather than failing the save.
+	if len(saved.History) != 1 || saved.History[0].MessageID != "m2" {
+		t.Errorf("history = %+v, want only the newest turn", saved.History)
+	}
+}
+
+func TestTrimmingTaskStoreUnderCaps(t *testing.T) {
+	store := NewTrimmingTaskStore(NewInMemoryTaskStore(), HistoryPolicy{MaxTurns: 10})
+	if err := store.Save(context.Background(), historyTask(2, 2)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	saved, err := store.Get(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(saved.History) != 2 || len(saved.Artifacts) != 2 {
+		t.Errorf("under-cap task was trimmed: %+v", saved)
+	}
+}
+
+func TestTrimmingTaskStoreListPassThrough(t *testing.T) {
+	store := NewTrimmingTaskStore(NewInMemoryTaskStore(), HistoryPolicy{})
+	if err := store.Save(context.Background(), historyTask(1, 0)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	tasks, err := store.(TaskLister).List(context.Background(), TaskFilter{ContextID: "ctx"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Errorf("List = %v, want the saved task", tasks)
+	}
+}
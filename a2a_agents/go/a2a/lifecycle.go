@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import "fmt"
+
+// Final reports whether the state is terminal: a task in a final state
+// accepts no further status changes or artifacts.
+func (s TaskState) Final() bool {
+	switch s {
+	case TaskStateCompleted, TaskStateCanceled, TaskStateFailed, TaskStateRejected:
+		return true
+	}
+	return false
+}
+
+// validNext maps each non-final state to the states a task may move to
+// from it. Repeating the current state is allowed for working, so
+// executors can publish progress updates.
+var validNext = map[TaskState][]TaskState{
+	TaskStateSubmitted: {TaskStateWorking, TaskStateInputRequired, TaskStateCompleted,
+		TaskStateCanceled, TaskStateFailed, TaskStateRejected},
+	TaskStateWorking: {TaskStateWorking, TaskStateInputRequired, TaskStateCompleted,
+		TaskStateCanceled, TaskStateFailed},
+	TaskStateInputRequired: {TaskStateWorking, TaskStateCanceled, TaskStateFailed},
+}
+
+// ValidTransition reports whether a task may move from one state to
+// another. Final states admit no successors.
+func ValidTransition(from, to TaskState) bool {
+	for _, next := range validNext[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Lifecycle tracks one task's state and rejects events that violate the
+// task lifecycle — an invalid state transition, or an artifact after the
+// final status. Executors and tests can run their event stream through
+// Apply to catch ordering bugs that a client would otherwise surface.
+type Lifecycle struct {
+	state TaskState
+	done  bool
+}
+
+// NewLifecycle returns a Lifecycle for a freshly submitted task.
+func NewLifecycle() *Lifecycle {
+	return &Lifecycle{state: TaskStateSubmitted}
+}
+
+// State returns the task's current state.
+func (l *Lifecycle) State() TaskState {
+	return l.state
+}
+
+// Transition moves the task to the given state, or reports why it cannot.
+func (l *Lifecycle) Transition(to TaskState) error {
+	if l.done {
+		return fmt.Errorf("a2a: task is final in state %q, cannot move to %q", l.state, to)
+	}
+	if !ValidTransition(l.state, to) {
+		return fmt.Errorf("a2a: invalid task transition from %q to %q", l.state, to)
+	}
+	l.state = to
+	if to.Final() {
+		l.done = true
+	}
+	return nil
+}
+
+// Apply validates one published event against the lifecycle: status
+// updates must be legal transitions, a status marked final ends the task,
+// and artifacts may only follow while the task is still live.
+func (l *Lifecycle) Apply(event Event) error {
+	switch e := event.(type) {
+	case *StatusUpdateEvent:
+		if err := l.Transition(e.Status.State); err != nil {
+			return err
+		}
+		if e.Final {
+			// input-required is published final too: it ends the stream
+			// even though the task can resume later.
+			l.done = true
+		}
+	case *ArtifactUpdateEvent:
+		if l.done {
+			return fmt.Errorf("a2a: artifact %q emitted after the final status in state %q",
+				e.Artifact.ArtifactID, l.state)
+		}
+	}
+	return nil
+}
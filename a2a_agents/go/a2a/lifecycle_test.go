@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaskStateFinal(t *testing.T) {
+	finals := []TaskState{TaskStateCompleted, TaskStateCanceled, TaskStateFailed, TaskStateRejected}
+	for _, state := range finals {
+		if !state.Final() {
+			t.Errorf("%s.Final() = false, want true", state)
+		}
+	}
+	live := []TaskState{TaskStateSubmitted, TaskStateWorking, TaskStateInputRequired}
+	for _, state := range live {
+		if state.Final() {
+			t.Errorf("%s.Final() = true, want false", state)
+		}
+	}
+}
+
+func TestValidTransition(t *testing.T) {
+	if !ValidTransition(TaskStateSubmitted, TaskStateWorking) {
+		t.Error("submitted -> working rejected")
+	}
+	if !ValidTransition(TaskStateWorking, TaskStateWorking) {
+		t.Error("working -> working progress updates rejected")
+	}
+	if !ValidTransition(TaskStateInputRequired, TaskStateWorking) {
+		t.Error("input-required -> working resume rejected")
+	}
+	if ValidTransition(TaskStateCompleted, TaskStateWorking) {
+		t.Error("completed -> working allowed")
+	}
+	if ValidTransition(TaskStateFailed, TaskStateCompleted) {
+		t.Error("failed -> completed allowed")
+	}
+}
+
+func statusEvent(state TaskState, final bool) *StatusUpdateEvent {
+	return &StatusUpdateEvent{TaskID: "t1", Status: NewTaskStatus(state, nil), Final: final}
+}
+
+func TestLifecycleHappyPath(t *testing.T) {
+	l := NewLifecycle()
+	events := []Event{
+		statusEvent(TaskStateWorking, false),
+		&ArtifactUpdateEvent{TaskID: "t1", Artifact: Artifact{ArtifactID: "a1"}},
+		statusEvent(TaskStateCompleted, true),
+	}
+	for i, event := range events {
+		if err := l.Apply(event); err != nil {
+			t.Fatalf("event %d rejected: %v", i, err)
+		}
+	}
+	if got := l.State(); got != TaskStateCompleted {
+		t.Errorf("final state = %s, want completed", got)
+	}
+}
+
+func TestLifecycleRejectsArtifactAfterFinal(t *testing.T) {
+	l := NewLifecycle()
+	if err := l.Apply(statusEvent(TaskStateCompleted, true)); err != nil {
+		t.Fatalf("completion rejected: %v", err)
+	}
+	err := l.Apply(&ArtifactUpdateEvent{TaskID: "t1", Artifact: Artifact{ArtifactID: "late"}})
+	if err == nil {
+		t.Fatal("artifact after final status accepted")
+	}
+	if !strings.Contains(err.Error(), "final") {
+		t.Errorf("error does not mention finality: %v", err)
+	}
+}
+
+func TestLifecycleRejectsInvalidTransition(t *testing.T) {
+	l := NewLifecycle()
+	if err := l.Transition(TaskStateFailed); err != nil {
+		t.Fatalf("submitted -> failed rejected: %v", err)
+	}
+	if err := l.Transition(TaskStateWorking); err == nil {
+		t.Fatal("transition out of a final state accepted")
+	}
+}
+
+func TestLifecycleFinalInputRequiredEndsStream(t *testing.T) {
+	// input-required is published with final=true: it ends this stream
+	// even though the task itself can resume in a later request.
+	l := NewLifecycle()
+	if err := l.Apply(statusEvent(TaskStateInputRequired, true)); err != nil {
+		t.Fatalf("input-required rejected: %v", err)
+	}
+	err := l.Apply(&ArtifactUpdateEvent{TaskID: "t1", Artifact: Artifact{ArtifactID: "late"}})
+	if err == nil {
+		t.Error("artifact after a final input-required accepted")
+	}
+}
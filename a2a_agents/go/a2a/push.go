@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNoRoute is returned by PushRouter.Push when a context has no live
+// stream and no fallback is configured.
+var ErrNoRoute = errors.New("a2a: no route to context")
+
+// FallbackFunc delivers an event for a context with no live stream, e.g.
+// by sending a push notification that prompts the client to reconnect.
+type FallbackFunc func(ctx context.Context, contextID string, event Event) error
+
+// PushFilterFunc decides whether a context should receive a pushed
+// event, e.g. because the client still subscribes to the surfaces it
+// touches. Filtered events are dropped silently — no fallback.
+type PushFilterFunc func(contextID string, event Event) bool
+
+// PushRouter routes server-initiated events — alerting rules, scheduled
+// refreshes, webhook handlers — to an existing context outside the
+// request cycle. Streaming transports attach their event queue while a
+// stream is live; Push writes to every attached queue, falling back to
+// the configured FallbackFunc when none is reachable.
+type PushRouter struct {
+	fallback FallbackFunc
+	filter   PushFilterFunc
+
+	mu      sync.Mutex
+	streams map[string]map[*EventQueue]bool
+}
+
+// NewPushRouter returns a router; fallback may be nil, in which case
+// pushes to contexts without a live stream fail with ErrNoRoute.
+func NewPushRouter(fallback FallbackFunc) *PushRouter {
+	return &PushRouter{fallback: fallback, streams: map[string]map[*EventQueue]bool{}}
+}
+
+// Attach registers a live stream's queue for a context and returns the
+// detach function the transport must call when the stream ends.
+func (r *PushRouter) Attach(contextID string, queue *EventQueue) (detach func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	attached := r.streams[contextID]
+	if attached == nil {
+		attached = map[*EventQueue]bool{}
+		r.streams[contextID] = attached
+	}
+	attached[queue] = true
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(attached, queue)
+		if len(attached) == 0 {
+			delete(r.streams, contextID)
+		}
+	}
+}
+
+// SetFilter installs the delivery filter. It must be called before the
+// router is shared.
+func (r *PushRouter) SetFilter(filter PushFilterFunc) {
+	r.filter = filter
+}
+
+// Push delivers an event to the context's live streams. When every write
+// fails or no stream is attached, the event goes to the fallback instead.
+// Events the filter rejects are dropped without error.
+func (r *PushRouter) Push(ctx context.Context, contextID string, event Event) error {
+	if r.filter != nil && !r.filter(contextID, event) {
+		return nil
+	}
+	return r.push(ctx, contextID, event)
+}
+
+func (r *PushRouter) push(ctx context.Context, contextID string, event Event) error {
+	r.mu.Lock()
+	queues := make([]*EventQueue, 0, len(r.streams[contextID]))
+	for queue := range r.streams[contextID] {
+		queues = append(queues, queue)
+	}
+	r.mu.Unlock()
+
+	delivered := false
+	for _, queue := range queues {
+		if err := queue.Write(event); err == nil {
+			delivered = true
+		}
+	}
+	if delivered {
+		return nil
+	}
+	if r.fallback != nil {
+		return r.fallback(ctx, contextID, event)
+	}
+	return fmt.Errorf("%w %s", ErrNoRoute, contextID)
+}
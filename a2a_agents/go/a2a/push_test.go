@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func pushEvent(contextID string) Event {
+	return &StatusUpdateEvent{TaskID: "task1", ContextID: contextID,
+		Status: NewTaskStatus(TaskStateWorking, nil)}
+}
+
+func TestPushRouterDeliversToLiveStreams(t *testing.T) {
+	router := NewPushRouter(nil)
+	first := NewEventQueue(4)
+	second := NewEventQueue(4)
+	detachFirst := router.Attach("ctx1", first)
+	defer router.Attach("ctx1", second)()
+
+	if err := router.Push(context.Background(), "ctx1", pushEvent("ctx1")); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	for _, queue := range []*EventQueue{first, second} {
+		if _, ok := queue.Read(); !ok {
+			t.Error("an attached stream did not receive the event")
+		}
+	}
+
+	// After the first stream detaches, pushes reach only the second.
+	detachFirst()
+	if err := router.Push(context.Background(), "ctx1", pushEvent("ctx1")); err != nil {
+		t.Fatalf("Push after detach: %v", err)
+	}
+	if _, ok := second.Read(); !ok {
+		t.Error("remaining stream did not receive the event")
+	}
+	first.Close()
+	if event, ok := first.Read(); ok {
+		t.Errorf("detached stream received %+v", event)
+	}
+}
+
+func TestPushRouterFallback(t *testing.T) {
+	var fellBack []string
+	router := NewPushRouter(func(ctx context.Context, contextID string, event Event) error {
+		fellBack = append(fellBack, contextID)
+		return nil
+	})
+
+	// No stream attached: the fallback carries the event.
+	if err := router.Push(context.Background(), "ctx1", pushEvent("ctx1")); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	// A closed stream counts as unreachable.
+	queue := NewEventQueue(4)
+	queue.Close()
+	defer router.Attach("ctx2", queue)()
+	if err := router.Push(context.Background(), "ctx2", pushEvent("ctx2")); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if len(fellBack) != 2 || fellBack[0] != "ctx1" || fellBack[1] != "ctx2" {
+		t.Errorf("fallback contexts = %v, want [ctx1 ctx2]", fellBack)
+	}
+}
+
+func TestPushRouterNoRoute(t *testing.T) {
+	router := NewPushRouter(nil)
+	err := router.Push(context.Background(), "ctx1", pushEvent("ctx1"))
+	if !errors.Is(err, ErrNoRoute) {
+		t.Errorf("err = %v, want ErrNoRoute", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimit configures request rate limiting for a Server.
+type RateLimit struct {
+	// PerClientPerSecond is the sustained request rate allowed per client;
+	// 0 disables the per-client limit.
+	PerClientPerSecond float64
+	// PerClientBurst is the instantaneous burst allowed per client. Defaults
+	// to PerClientPerSecond when 0.
+	PerClientBurst int
+	// GlobalPerSecond is the sustained request rate allowed across all
+	// clients; 0 disables the global limit.
+	GlobalPerSecond float64
+	// GlobalBurst is the instantaneous burst allowed globally. Defaults to
+	// GlobalPerSecond when 0.
+	GlobalBurst int
+}
+
+// maxClientBuckets caps the per-client bucket map; the least recently used
+// bucket is evicted beyond this, so abusive clients cannot grow memory
+// unboundedly by rotating identities.
+const maxClientBuckets = 4096
+
+// tokenBucket is a standard token-bucket limiter.
+type tokenBucket struct {
+	tokens   float64
+	rate     float64
+	burst    float64
+	last     time.Time
+	lastUsed time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	b := float64(burst)
+	if b <= 0 {
+		b = rate
+	}
+	return &tokenBucket{tokens: b, rate: rate, burst: b, last: now, lastUsed: now}
+}
+
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.lastUsed = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimiter enforces per-client and global token buckets.
+type rateLimiter struct {
+	cfg    RateLimit
+	mu     sync.Mutex
+	global *tokenBucket
+	client map[string]*tokenBucket
+	now    func() time.Time
+}
+
+func newRateLimiter(cfg RateLimit) *rateLimiter {
+	l := &rateLimiter{cfg: cfg, client: map[string]*tokenBucket{}, now: time.Now}
+	if cfg.GlobalPerSecond > 0 {
+		l.global = newTokenBucket(cfg.GlobalPerSecond, cfg.GlobalBurst, l.now())
+	}
+	return l
+}
+
+// allow reports whether a request from the client may proceed.
+func (l *rateLimiter) allow(clientID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if l.cfg.PerClientPerSecond > 0 {
+		bucket, ok := l.client[clientID]
+		if !ok {
+			bucket = newTokenBucket(l.cfg.PerClientPerSecond, l.cfg.PerClientBurst, now)
+			l.client[clientID] = bucket
+			l.evictLocked()
+		}
+		if !bucket.allow(now) {
+			return false
+		}
+	}
+	if l.global != nil && !l.global.allow(now) {
+		return false
+	}
+	return true
+}
+
+// evictLocked drops the least recently used client bucket once the map
+// exceeds its cap.
+func (l *rateLimiter) evictLocked() {
+	if len(l.client) <= maxClientBuckets {
+		return
+	}
+	var oldestID string
+	var oldest time.Time
+	for id, bucket := range l.client {
+		if oldestID == "" || bucket.lastUsed.Before(oldest) {
+			oldestID, oldest = id, bucket.lastUsed
+		}
+	}
+	delete(l.client, oldestID)
+}
@@ -0,0 +1,498 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AgentCardPath is the well-known path serving the agent card.
+const AgentCardPath = "/.well-known/agent-card.json"
+
+// ExtensionsHeader is the HTTP header clients use to request extension
+// activation.
+const ExtensionsHeader = "X-A2A-Extensions"
+
+// JSON-RPC error codes used by the server. The -32001..-32006 range follows
+// the A2A specification; the -3203x codes are implementation-defined server
+// errors.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeTaskNotFound   = -32001
+	CodeRateLimited    = -32030
+	CodeSaturated      = -32031
+)
+
+// rpcRequest is an incoming JSON-RPC request.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+// rpcError is a JSON-RPC error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return fmt.Sprintf("%s (code %d)", e.Message, e.Code) }
+
+// rpcResponse is an outgoing JSON-RPC response.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// messageParams are the params of message/send and message/stream.
+type messageParams struct {
+	Message Message `json:"message"`
+}
+
+// taskParams are the params of tasks/get and tasks/cancel.
+type taskParams struct {
+	ID string `json:"id"`
+}
+
+// subscribeParams are the params of surfaces/subscribe and
+// surfaces/unsubscribe.
+type subscribeParams struct {
+	ContextID  string   `json:"contextId"`
+	SurfaceIDs []string `json:"surfaceIds"`
+}
+
+// Server exposes an AgentExecutor over the A2A JSON-RPC HTTP transport. It
+// serves the agent card, message/send, message/stream (SSE), tasks/get and
+// tasks/cancel.
+type Server struct {
+	card              AgentCard
+	executor          AgentExecutor
+	store             TaskStore
+	limiter           *rateLimiter
+	sem               chan struct{}
+	eventWriteTimeout time.Duration
+	push              *PushRouter
+	subs              *SurfaceSubscriptions
+}
+
+// ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// WithTaskStore sets the task store; the default is an in-memory store.
+func WithTaskStore(store TaskStore) ServerOption {
+	return func(s *Server) { s.store = store }
+}
+
+// WithRateLimit enables per-client and/or global request rate limiting.
+// Saturated requests fail with a CodeRateLimited JSON-RPC error, so a single
+// chatty client cannot exhaust a shared agent's LLM quota.
+func WithRateLimit(cfg RateLimit) ServerOption {
+	return func(s *Server) { s.limiter = newRateLimiter(cfg) }
+}
+
+// WithMaxConcurrentExecutions caps the number of Execute calls in flight.
+// Requests beyond the cap fail immediately with a CodeSaturated JSON-RPC
+// error.
+func WithMaxConcurrentExecutions(n int) ServerOption {
+	return func(s *Server) {
+		if n > 0 {
+			s.sem = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithEventWriteTimeout bounds how long an executor blocks writing an
+// event when the client stops reading the stream; a stalled queue closes
+// and the task fails instead of pinning its goroutine forever. Zero (the
+// default) blocks indefinitely.
+func WithEventWriteTimeout(d time.Duration) ServerOption {
+	return func(s *Server) { s.eventWriteTimeout = d }
+}
+
+// WithPushRouter attaches live streams to the router for their context's
+// lifetime, so server-initiated events (see PushRouter.Push) reach
+// clients over the stream they already hold open.
+func WithPushRouter(router *PushRouter) ServerOption {
+	return func(s *Server) { s.push = router }
+}
+
+// WithSurfaceSubscriptions enables the surfaces/subscribe and
+// surfaces/unsubscribe methods against the given registry. Callers that
+// also push server-initiated events typically install the registry's
+// Filter on their PushRouter, so only surfaces the client still displays
+// receive them.
+func WithSurfaceSubscriptions(subs *SurfaceSubscriptions) ServerOption {
+	return func(s *Server) { s.subs = subs }
+}
+
+// NewServer builds a server for the given card and executor.
+func NewServer(card AgentCard, executor AgentExecutor, opts ...ServerOption) *Server {
+	s := &Server{card: card, executor: executor, store: NewInMemoryTaskStore()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == AgentCardPath {
+		writeJSON(w, http.StatusOK, s.card)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeRPCError(w, nil, CodeParseError, "reading request body failed")
+		return
+	}
+	var req rpcRequest
+	if err := ActiveCodec().Unmarshal(body, &req); err != nil {
+		writeRPCError(w, nil, CodeParseError, "invalid JSON")
+		return
+	}
+	if req.JSONRPC != "2.0" {
+		writeRPCError(w, req.ID, CodeInvalidRequest, "jsonrpc must be \"2.0\"")
+		return
+	}
+
+	if s.limiter != nil && !s.limiter.allow(clientID(r)) {
+		writeRPCError(w, req.ID, CodeRateLimited, "rate limit exceeded, retry later")
+		return
+	}
+
+	switch req.Method {
+	case "message/send":
+		s.handleMessageSend(w, r, &req)
+	case "message/stream":
+		s.handleMessageStream(w, r, &req)
+	case "tasks/get":
+		s.handleTaskGet(w, r, &req)
+	case "tasks/cancel":
+		s.handleTaskCancel(w, r, &req)
+	case "surfaces/subscribe", "surfaces/unsubscribe":
+		s.handleSubscribe(w, &req)
+	default:
+		writeRPCError(w, req.ID, CodeMethodNotFound, fmt.Sprintf("unknown method %q", req.Method))
+	}
+}
+
+// acquire reserves an execution slot, or fails fast when the server is at
+// its concurrency cap.
+func (s *Server) acquire() (release func(), ok bool) {
+	if s.sem == nil {
+		return func() {}, true
+	}
+	select {
+	case s.sem <- struct{}{}:
+		return func() { <-s.sem }, true
+	default:
+		return nil, false
+	}
+}
+
+// prepare builds the request context and task for an incoming message.
+func (s *Server) prepare(r *http.Request, msg *Message) (*RequestContext, *Task) {
+	taskID := msg.TaskID
+	if taskID == "" {
+		taskID = NewID()
+	}
+	contextID := msg.ContextID
+	if contextID == "" {
+		contextID = NewID()
+	}
+	rc := NewRequestContext(msg, taskID, contextID, requestedExtensions(r))
+	task := &Task{
+		ID:        taskID,
+		ContextID: contextID,
+		Status:    NewTaskStatus(TaskStateSubmitted, nil),
+		History:   []Message{*msg},
+	}
+	return rc, task
+}
+
+func (s *Server) handleMessageSend(w http.ResponseWriter, r *http.Request, req *rpcRequest) {
+	var params messageParams
+	if err := ActiveCodec().Unmarshal(req.Params, &params); err != nil {
+		writeRPCError(w, req.ID, CodeInvalidParams, "params must contain a message")
+		return
+	}
+	release, ok := s.acquire()
+	if !ok {
+		writeRPCError(w, req.ID, CodeSaturated, "agent is at capacity, retry later")
+		return
+	}
+	defer release()
+
+	rc, task := s.prepare(r, &params.Message)
+	queue := s.newQueue()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer queue.Close()
+		if err := s.executor.Execute(r.Context(), rc, queue); err != nil {
+			slog.Error("executor failed", "task", task.ID, "error", err)
+		}
+	}()
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		applyEvent(task, event)
+	}
+	<-done
+	echoActivatedExtensions(w, rc, task)
+	if err := s.store.Save(r.Context(), task); err != nil {
+		slog.Error("saving task failed", "task", task.ID, "error", err)
+	}
+	writeJSON(w, http.StatusOK, rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: task})
+}
+
+func (s *Server) handleMessageStream(w http.ResponseWriter, r *http.Request, req *rpcRequest) {
+	var params messageParams
+	if err := ActiveCodec().Unmarshal(req.Params, &params); err != nil {
+		writeRPCError(w, req.ID, CodeInvalidParams, "params must contain a message")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeRPCError(w, req.ID, CodeInternalError, "streaming unsupported by transport")
+		return
+	}
+	release, ok := s.acquire()
+	if !ok {
+		writeRPCError(w, req.ID, CodeSaturated, "agent is at capacity, retry later")
+		return
+	}
+	defer release()
+
+	rc, task := s.prepare(r, &params.Message)
+	queue := s.newQueue()
+	if s.push != nil {
+		detach := s.push.Attach(rc.ContextID, queue)
+		defer detach()
+	}
+	go func() {
+		defer queue.Close()
+		if err := s.executor.Execute(r.Context(), rc, queue); err != nil {
+			slog.Error("executor failed", "task", task.ID, "error", err)
+		}
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// The status line waits for the first event, so extensions the
+	// executor activates while starting up still make the header echo.
+	wroteHeader := false
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		if !wroteHeader {
+			echoActivatedExtensions(w, rc, task)
+			w.WriteHeader(http.StatusOK)
+			wroteHeader = true
+		}
+		applyEvent(task, event)
+		frame, err := ActiveCodec().Marshal(rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: event})
+		if err != nil {
+			slog.Error("encoding event failed", "task", task.ID, "error", err)
+			continue
+		}
+		fmt.Fprintf(w, "data: %s\n\n", frame)
+		flusher.Flush()
+	}
+	if !wroteHeader {
+		echoActivatedExtensions(w, rc, task)
+		w.WriteHeader(http.StatusOK)
+	}
+	if err := s.store.Save(r.Context(), task); err != nil {
+		slog.Error("saving task failed", "task", task.ID, "error", err)
+	}
+}
+
+func (s *Server) handleTaskGet(w http.ResponseWriter, r *http.Request, req *rpcRequest) {
+	var params taskParams
+	if err := ActiveCodec().Unmarshal(req.Params, &params); err != nil || params.ID == "" {
+		writeRPCError(w, req.ID, CodeInvalidParams, "params must contain a task id")
+		return
+	}
+	task, err := s.store.Get(r.Context(), params.ID)
+	if err != nil {
+		writeRPCError(w, req.ID, CodeTaskNotFound, fmt.Sprintf("task %q not found", params.ID))
+		return
+	}
+	writeJSON(w, http.StatusOK, rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: task})
+}
+
+func (s *Server) handleTaskCancel(w http.ResponseWriter, r *http.Request, req *rpcRequest) {
+	var params taskParams
+	if err := ActiveCodec().Unmarshal(req.Params, &params); err != nil || params.ID == "" {
+		writeRPCError(w, req.ID, CodeInvalidParams, "params must contain a task id")
+		return
+	}
+	task, err := s.store.Get(r.Context(), params.ID)
+	if err != nil {
+		writeRPCError(w, req.ID, CodeTaskNotFound, fmt.Sprintf("task %q not found", params.ID))
+		return
+	}
+	rc := NewRequestContext(nil, task.ID, task.ContextID, nil)
+	queue := s.newQueue()
+	go func() {
+		defer queue.Close()
+		if err := s.executor.Cancel(r.Context(), rc, queue); err != nil {
+			slog.Error("cancel failed", "task", task.ID, "error", err)
+		}
+	}()
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		applyEvent(task, event)
+	}
+	if err := s.store.Save(r.Context(), task); err != nil {
+		slog.Error("saving task failed", "task", task.ID, "error", err)
+	}
+	writeJSON(w, http.StatusOK, rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: task})
+}
+
+// handleSubscribe serves surfaces/subscribe and surfaces/unsubscribe.
+// Both return the context's remaining active subscriptions, so clients
+// can verify their view of the set after a reconnect.
+func (s *Server) handleSubscribe(w http.ResponseWriter, req *rpcRequest) {
+	if s.subs == nil {
+		writeRPCError(w, req.ID, CodeMethodNotFound, "surface subscriptions are not enabled")
+		return
+	}
+	var params subscribeParams
+	if err := ActiveCodec().Unmarshal(req.Params, &params); err != nil || params.ContextID == "" || len(params.SurfaceIDs) == 0 {
+		writeRPCError(w, req.ID, CodeInvalidParams, "params must contain a contextId and surfaceIds")
+		return
+	}
+	if req.Method == "surfaces/subscribe" {
+		s.subs.Subscribe(params.ContextID, params.SurfaceIDs...)
+	} else {
+		s.subs.Unsubscribe(params.ContextID, params.SurfaceIDs...)
+	}
+	writeJSON(w, http.StatusOK, rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: map[string]any{
+		"contextId":  params.ContextID,
+		"surfaceIds": s.subs.Active(params.ContextID),
+	}})
+}
+
+// applyEvent folds an executor event into the task.
+func applyEvent(task *Task, event Event) {
+	switch e := event.(type) {
+	case *StatusUpdateEvent:
+		task.Status = e.Status
+	case *ArtifactUpdateEvent:
+		if e.Append {
+			for i := range task.Artifacts {
+				if task.Artifacts[i].ArtifactID == e.Artifact.ArtifactID {
+					task.Artifacts[i].Parts = append(task.Artifacts[i].Parts, e.Artifact.Parts...)
+					return
+				}
+			}
+		}
+		task.Artifacts = append(task.Artifacts, e.Artifact)
+	}
+}
+
+// echoActivatedExtensions signals which extensions the executor
+// activated: the response header for HTTP clients, the task metadata for
+// readers of the task itself. Requests that activated nothing leave
+// both untouched.
+func echoActivatedExtensions(w http.ResponseWriter, rc *RequestContext, task *Task) {
+	activated := rc.ActivatedExtensions()
+	if len(activated) == 0 {
+		return
+	}
+	w.Header().Set(ExtensionsHeader, strings.Join(activated, ", "))
+	if task.Metadata == nil {
+		task.Metadata = map[string]any{}
+	}
+	task.Metadata[ActivatedExtensionsKey] = activated
+}
+
+// requestedExtensions parses the X-A2A-Extensions header.
+func requestedExtensions(r *http.Request) []string {
+	var uris []string
+	for _, header := range r.Header.Values(ExtensionsHeader) {
+		for _, uri := range strings.Split(header, ",") {
+			if uri = strings.TrimSpace(uri); uri != "" {
+				uris = append(uris, uri)
+			}
+		}
+	}
+	return uris
+}
+
+// clientID identifies the calling client for rate limiting: the first
+// X-Forwarded-For hop if present, otherwise the remote IP.
+func clientID(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		return strings.TrimSpace(strings.Split(fwd, ",")[0])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := ActiveCodec().Encode(w, v); err != nil {
+		slog.Error("encoding response failed", "error", err)
+	}
+}
+
+func writeRPCError(w http.ResponseWriter, id json.RawMessage, code int, message string) {
+	writeJSON(w, http.StatusOK, rpcResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   &rpcError{Code: code, Message: message},
+	})
+}
+
+// newQueue builds an event queue for one request, applying the configured
+// write timeout.
+func (s *Server) newQueue() *EventQueue {
+	queue := NewEventQueue(0)
+	queue.SetWriteTimeout(s.eventWriteTimeout)
+	return queue
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// echoExecutor completes every task with a fixed text message. If block is
+// non-nil, Execute signals started and then waits on block before finishing.
+type echoExecutor struct {
+	block   chan struct{}
+	started chan struct{}
+}
+
+func (e *echoExecutor) Execute(ctx context.Context, rc *RequestContext, queue *EventQueue) error {
+	if e.started != nil {
+		e.started <- struct{}{}
+	}
+	if e.block != nil {
+		<-e.block
+	}
+	return queue.Write(&StatusUpdateEvent{
+		TaskID:    rc.TaskID,
+		ContextID: rc.ContextID,
+		Status: NewTaskStatus(TaskStateCompleted, &Message{
+			MessageID: NewID(),
+			Role:      RoleAgent,
+			Parts:     []Part{NewTextPart("done")},
+		}),
+		Final: true,
+	})
+}
+
+func (e *echoExecutor) Cancel(ctx context.Context, rc *RequestContext, queue *EventQueue) error {
+	return queue.Write(&StatusUpdateEvent{
+		TaskID:    rc.TaskID,
+		ContextID: rc.ContextID,
+		Status:    NewTaskStatus(TaskStateCanceled, nil),
+		Final:     true,
+	})
+}
+
+func rpcCall(t *testing.T, server *Server, body string) rpcResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.RemoteAddr = "192.0.2.1:1234"
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	var resp rpcResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v (body %q)", err, rec.Body.String())
+	}
+	return resp
+}
+
+const sendBody = `{"jsonrpc": "2.0", "id": 1, "method": "message/send",
+  "params": {"message": {"messageId": "m1", "role": "user",
+    "parts": [{"kind": "text", "text": "hi"}]}}}`
+
+func TestServerMessageSend(t *testing.T) {
+	server := NewServer(AgentCard{Name: "test"}, &echoExecutor{})
+	resp := rpcCall(t, server, sendBody)
+	if resp.Error != nil {
+		t.Fatalf("message/send error: %v", resp.Error)
+	}
+	raw, _ := json.Marshal(resp.Result)
+	var task Task
+	if err := json.Unmarshal(raw, &task); err != nil {
+		t.Fatalf("result is not a task: %v", err)
+	}
+	if task.Status.State != TaskStateCompleted {
+		t.Errorf("task state = %q, want completed", task.Status.State)
+	}
+
+	// The task is retrievable afterwards.
+	getBody := `{"jsonrpc": "2.0", "id": 2, "method": "tasks/get", "params": {"id": "` + task.ID + `"}}`
+	resp = rpcCall(t, server, getBody)
+	if resp.Error != nil {
+		t.Fatalf("tasks/get error: %v", resp.Error)
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	server := NewServer(AgentCard{Name: "test"}, &echoExecutor{},
+		WithRateLimit(RateLimit{PerClientPerSecond: 1, PerClientBurst: 2}))
+	var rateLimited bool
+	for i := 0; i < 4; i++ {
+		resp := rpcCall(t, server, sendBody)
+		if resp.Error != nil && resp.Error.Code == CodeRateLimited {
+			rateLimited = true
+		}
+	}
+	if !rateLimited {
+		t.Error("burst of requests was never rate limited")
+	}
+}
+
+func TestServerConcurrencyCap(t *testing.T) {
+	block := make(chan struct{})
+	started := make(chan struct{}, 1)
+	server := NewServer(AgentCard{Name: "test"}, &echoExecutor{block: block, started: started},
+		WithMaxConcurrentExecutions(1))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rpcCall(t, server, sendBody)
+	}()
+	// Wait until the first request holds the only execution slot.
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("first request never started executing")
+	}
+	resp := rpcCall(t, server, sendBody)
+	if resp.Error == nil || resp.Error.Code != CodeSaturated {
+		t.Errorf("saturated server returned %v, want CodeSaturated", resp.Error)
+	}
+	close(block)
+	wg.Wait()
+}
+
+func TestServerUnknownMethod(t *testing.T) {
+	server := NewServer(AgentCard{Name: "test"}, &echoExecutor{})
+	resp := rpcCall(t, server, `{"jsonrpc": "2.0", "id": 1, "method": "bogus"}`)
+	if resp.Error == nil || resp.Error.Code != CodeMethodNotFound {
+		t.Fatalf("error = %v, want method-not-found", resp.Error)
+	}
+}
+
+func TestServerAgentCard(t *testing.T) {
+	server := NewServer(AgentCard{Name: "test-agent"}, &echoExecutor{})
+	req := httptest.NewRequest(http.MethodGet, AgentCardPath, nil)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	var card AgentCard
+	if err := json.NewDecoder(rec.Body).Decode(&card); err != nil {
+		t.Fatalf("decoding card: %v", err)
+	}
+	if card.Name != "test-agent" {
+		t.Errorf("card name = %q", card.Name)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// SurfaceExtractor names the surfaces an event touches, so subscription
+// filtering can decide whether the client still displays any of them.
+// A nil or empty result marks the event as not surface-scoped — status
+// updates, say — and such events always deliver.
+type SurfaceExtractor func(event Event) []string
+
+// LapseFunc is notified when a subscription lapses without an explicit
+// unsubscribe, so per-surface server work (scheduled refreshes, open
+// watches) can be torn down alongside it.
+type LapseFunc func(contextID, surfaceID string)
+
+// SurfaceSubscriptions tracks which surfaces each context's client still
+// displays. Clients subscribe via the surfaces/subscribe RPC when a
+// surface appears and unsubscribe when it is dismissed; with a TTL set,
+// a subscription that is not renewed lapses and is cleaned up server
+// side, covering clients that vanish without unsubscribing.
+type SurfaceSubscriptions struct {
+	ttl     time.Duration
+	onLapse LapseFunc
+
+	mu       sync.Mutex
+	contexts map[string]map[string]time.Time // surfaceID → expiry
+	now      func() time.Time
+}
+
+// NewSurfaceSubscriptions returns a registry whose subscriptions lapse
+// after ttl unless renewed by another Subscribe; ttl <= 0 means they
+// last until explicitly unsubscribed.
+func NewSurfaceSubscriptions(ttl time.Duration) *SurfaceSubscriptions {
+	return &SurfaceSubscriptions{ttl: ttl, contexts: map[string]map[string]time.Time{}, now: time.Now}
+}
+
+// SetOnLapse installs the lapse callback. It must be called before the
+// registry is shared; the callback runs without the registry lock held.
+func (s *SurfaceSubscriptions) SetOnLapse(fn LapseFunc) {
+	s.onLapse = fn
+}
+
+// Subscribe records (or renews) the context's interest in the surfaces.
+func (s *SurfaceSubscriptions) Subscribe(contextID string, surfaceIDs ...string) {
+	s.mu.Lock()
+	now := s.now()
+	surfaces := s.contexts[contextID]
+	if surfaces == nil {
+		surfaces = map[string]time.Time{}
+		s.contexts[contextID] = surfaces
+	}
+	var expiry time.Time
+	if s.ttl > 0 {
+		expiry = now.Add(s.ttl)
+	}
+	for _, id := range surfaceIDs {
+		surfaces[id] = expiry
+	}
+	// Subscribes are the natural heartbeat, so sweep the whole registry
+	// here: contexts whose client vanished are cleaned up without a
+	// background timer.
+	lapsed := s.sweepLocked(now)
+	s.mu.Unlock()
+	s.notifyLapsed(lapsed)
+}
+
+// Unsubscribe drops the context's interest in the surfaces. Unknown
+// surfaces are ignored; no lapse callback fires for explicit drops.
+func (s *SurfaceSubscriptions) Unsubscribe(contextID string, surfaceIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	surfaces := s.contexts[contextID]
+	for _, id := range surfaceIDs {
+		delete(surfaces, id)
+	}
+	if len(surfaces) == 0 {
+		delete(s.contexts, contextID)
+	}
+}
+
+// Active returns the context's live subscriptions in sorted order,
+// pruning any that lapsed.
+func (s *SurfaceSubscriptions) Active(contextID string) []string {
+	s.mu.Lock()
+	lapsed := s.pruneContextLocked(contextID, s.now())
+	ids := make([]string, 0, len(s.contexts[contextID]))
+	for id := range s.contexts[contextID] {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+	s.notifyLapsed(lapsed)
+	sort.Strings(ids)
+	return ids
+}
+
+// Filter adapts the registry to a PushRouter filter: events touching
+// only surfaces the context no longer subscribes to are dropped, while
+// events the extractor cannot scope to a surface pass through.
+func (s *SurfaceSubscriptions) Filter(extract SurfaceExtractor) PushFilterFunc {
+	return func(contextID string, event Event) bool {
+		surfaceIDs := extract(event)
+		if len(surfaceIDs) == 0 {
+			return true
+		}
+		s.mu.Lock()
+		lapsed := s.pruneContextLocked(contextID, s.now())
+		surfaces := s.contexts[contextID]
+		subscribed := false
+		for _, id := range surfaceIDs {
+			if _, ok := surfaces[id]; ok {
+				subscribed = true
+				break
+			}
+		}
+		s.mu.Unlock()
+		s.notifyLapsed(lapsed)
+		return subscribed
+	}
+}
+
+// lapsedSurface names one expired subscription for the lapse callback.
+type lapsedSurface struct {
+	contextID string
+	surfaceID string
+}
+
+// sweepLocked removes every expired subscription and returns what lapsed.
+func (s *SurfaceSubscriptions) sweepLocked(now time.Time) []lapsedSurface {
+	var lapsed []lapsedSurface
+	for contextID := range s.contexts {
+		lapsed = append(lapsed, s.pruneContextLocked(contextID, now)...)
+	}
+	return lapsed
+}
+
+// pruneContextLocked removes the context's expired subscriptions and
+// returns what lapsed, deleting the context once it holds none.
+func (s *SurfaceSubscriptions) pruneContextLocked(contextID string, now time.Time) []lapsedSurface {
+	surfaces := s.contexts[contextID]
+	var lapsed []lapsedSurface
+	for id, expiry := range surfaces {
+		if !expiry.IsZero() && expiry.Before(now) {
+			delete(surfaces, id)
+			lapsed = append(lapsed, lapsedSurface{contextID: contextID, surfaceID: id})
+		}
+	}
+	if surfaces != nil && len(surfaces) == 0 {
+		delete(s.contexts, contextID)
+	}
+	return lapsed
+}
+
+func (s *SurfaceSubscriptions) notifyLapsed(lapsed []lapsedSurface) {
+	if s.onLapse == nil {
+		return
+	}
+	for _, l := range lapsed {
+		s.onLapse(l.contextID, l.surfaceID)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// surfaceScopedEvent builds an artifact update tagged with the surface
+// it targets, matching what an extractor would read in production.
+func surfaceScopedEvent(contextID, surfaceID string) Event {
+	return &ArtifactUpdateEvent{
+		TaskID:    "task1",
+		ContextID: contextID,
+		Artifact:  Artifact{ArtifactID: "a1"},
+		Metadata:  map[string]any{"surfaceId": surfaceID},
+	}
+}
+
+func metadataSurfaceExtractor(event Event) []string {
+	e, ok := event.(*ArtifactUpdateEvent)
+	if !ok {
+		return nil
+	}
+	if id, ok := e.Metadata["surfaceId"].(string); ok {
+		return []string{id}
+	}
+	return nil
+}
+
+func TestSurfaceSubscriptionsFilter(t *testing.T) {
+	subs := NewSurfaceSubscriptions(0)
+	subs.Subscribe("ctx1", "chart", "table")
+	subs.Unsubscribe("ctx1", "table")
+
+	filter := subs.Filter(metadataSurfaceExtractor)
+	if !filter("ctx1", surfaceScopedEvent("ctx1", "chart")) {
+		t.Error("subscribed surface was filtered out")
+	}
+	if filter("ctx1", surfaceScopedEvent("ctx1", "table")) {
+		t.Error("unsubscribed surface was delivered")
+	}
+	// Events without a surface scope always pass.
+	if !filter("ctx1", pushEvent("ctx1")) {
+		t.Error("non-surface event was filtered out")
+	}
+	if got, want := subs.Active("ctx1"), []string{"chart"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Active = %v, want %v", got, want)
+	}
+}
+
+func TestSurfaceSubscriptionsLapse(t *testing.T) {
+	subs := NewSurfaceSubscriptions(time.Minute)
+	now := time.Now()
+	subs.now = func() time.Time { return now }
+	var lapsed []string
+	subs.SetOnLapse(func(contextID, surfaceID string) {
+		lapsed = append(lapsed, contextID+"/"+surfaceID)
+	})
+
+	subs.Subscribe("ctx1", "chart")
+	subs.Subscribe("ctx2", "table")
+	now = now.Add(30 * time.Second)
+	// Renewal extends the subscription past the original expiry.
+	subs.Subscribe("ctx1", "chart")
+	now = now.Add(45 * time.Second)
+
+	if got := subs.Active("ctx1"); len(got) != 1 {
+		t.Errorf("renewed subscription lapsed early: Active = %v", got)
+	}
+	// ctx2 was never renewed; the next subscribe sweeps it out.
+	subs.Subscribe("ctx3", "map")
+	if got, want := lapsed, []string{"ctx2/table"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("lapsed = %v, want %v", got, want)
+	}
+	if got := subs.Active("ctx2"); len(got) != 0 {
+		t.Errorf("lapsed context still active: %v", got)
+	}
+}
+
+func TestSurfaceSubscriptionsPushIntegration(t *testing.T) {
+	subs := NewSurfaceSubscriptions(0)
+	router := NewPushRouter(nil)
+	router.SetFilter(subs.Filter(metadataSurfaceExtractor))
+	queue := NewEventQueue(4)
+	defer router.Attach("ctx1", queue)()
+
+	subs.Subscribe("ctx1", "chart")
+	if err := router.Push(context.Background(), "ctx1", surfaceScopedEvent("ctx1", "chart")); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if _, ok := queue.Read(); !ok {
+		t.Fatal("subscribed surface event did not arrive")
+	}
+
+	// After unsubscribing, the push is dropped without error or fallback.
+	subs.Unsubscribe("ctx1", "chart")
+	if err := router.Push(context.Background(), "ctx1", surfaceScopedEvent("ctx1", "chart")); err != nil {
+		t.Fatalf("Push after unsubscribe: %v", err)
+	}
+	queue.Close()
+	if event, ok := queue.Read(); ok {
+		t.Errorf("unsubscribed surface received %+v", event)
+	}
+}
+
+func TestServerSubscribeRPC(t *testing.T) {
+	subs := NewSurfaceSubscriptions(0)
+	server := NewServer(AgentCard{Name: "test"}, &echoExecutor{},
+		WithSurfaceSubscriptions(subs))
+
+	resp := rpcCall(t, server, `{"jsonrpc": "2.0", "id": 1, "method": "surfaces/subscribe",
+	  "params": {"contextId": "ctx1", "surfaceIds": ["chart", "table"]}}`)
+	if resp.Error != nil {
+		t.Fatalf("surfaces/subscribe error: %v", resp.Error)
+	}
+	if got, want := subs.Active("ctx1"), []string{"chart", "table"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Active = %v, want %v", got, want)
+	}
+
+	resp = rpcCall(t, server, `{"jsonrpc": "2.0", "id": 2, "method": "surfaces/unsubscribe",
+	  "params": {"contextId": "ctx1", "surfaceIds": ["table"]}}`)
+	if resp.Error != nil {
+		t.Fatalf("surfaces/unsubscribe error: %v", resp.Error)
+	}
+	result, _ := resp.Result.(map[string]any)
+	if got, want := result["surfaceIds"], []any{"chart"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("surfaceIds = %v, want %v", got, want)
+	}
+
+	resp = rpcCall(t, server, `{"jsonrpc": "2.0", "id": 3, "method": "surfaces/subscribe", "params": {}}`)
+	if resp.Error == nil || resp.Error.Code != CodeInvalidParams {
+		t.Errorf("missing params error = %v, want CodeInvalidParams", resp.Error)
+	}
+}
+
+func TestServerSubscribeDisabled(t *testing.T) {
+	server := NewServer(AgentCard{Name: "test"}, &echoExecutor{})
+	resp := rpcCall(t, server, `{"jsonrpc": "2.0", "id": 1, "method": "surfaces/subscribe",
+	  "params": {"contextId": "ctx1", "surfaceIds": ["chart"]}}`)
+	if resp.Error == nil || resp.Error.Code != CodeMethodNotFound {
+		t.Errorf("error = %v, want method-not-found", resp.Error)
+	}
+}
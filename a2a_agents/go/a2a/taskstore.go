@@ -0,0 +1,207 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrTaskNotFound is returned by TaskStore.Get for unknown task IDs.
+var ErrTaskNotFound = errors.New("a2a: task not found")
+
+// ErrListingUnsupported is returned by List wrappers whose underlying
+// store does not implement TaskLister.
+var ErrListingUnsupported = errors.New("a2a: task store does not support listing")
+
+// TaskStore persists tasks across requests.
+type TaskStore interface {
+	// Save stores or replaces a task.
+	Save(ctx context.Context, task *Task) error
+	// Get returns the task with the given ID, or ErrTaskNotFound.
+	Get(ctx context.Context, id string) (*Task, error)
+}
+
+// TaskFilter selects tasks for TaskLister.List. Zero fields match
+// everything.
+type TaskFilter struct {
+	// ContextID restricts the listing to one conversation.
+	ContextID string
+	// States restricts the listing to tasks currently in one of the given
+	// states.
+	States []TaskState
+	// UpdatedAfter and UpdatedBefore bound the task's status timestamp.
+	// Zero values leave the corresponding side unbounded; tasks without a
+	// timestamp never match a bounded filter.
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+}
+
+// matches reports whether the task passes every set filter field.
+func (f TaskFilter) matches(task *Task) bool {
+	if f.ContextID != "" && task.ContextID != f.ContextID {
+		return false
+	}
+	if len(f.States) > 0 {
+		found := false
+		for _, state := range f.States {
+			if task.Status.State == state {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if !f.UpdatedAfter.IsZero() || !f.UpdatedBefore.IsZero() {
+		updated := statusTime(task)
+		if updated.IsZero() {
+			return false
+		}
+		if !f.UpdatedAfter.IsZero() && !updated.After(f.UpdatedAfter) {
+			return false
+		}
+		if !f.UpdatedBefore.IsZero() && !updated.Before(f.UpdatedBefore) {
+			return false
+		}
+	}
+	return true
+}
+
+// TaskLister is the optional TaskStore extension for listing tasks, which
+// UIs showing "my conversations with this agent" need. Persistent
+// implementations should index contextId, state, and the status timestamp
+// so List does not scan the whole store.
+type TaskLister interface {
+	// List returns the tasks matching the filter, most recently updated
+	// first.
+	List(ctx context.Context, filter TaskFilter) ([]*Task, error)
+}
+
+// InMemoryTaskStore is a TaskStore keeping tasks in process memory. It is
+// suitable for samples and tests.
+type InMemoryTaskStore struct {
+	mu        sync.RWMutex
+	tasks     map[string]*Task
+	byContext map[string]map[string]bool
+}
+
+// NewInMemoryTaskStore returns an empty in-memory store.
+func NewInMemoryTaskStore() *InMemoryTaskStore {
+	return &InMemoryTaskStore{
+		tasks:     map[string]*Task{},
+		byContext: map[string]map[string]bool{},
+	}
+}
+
+// Save stores a deep copy of the task.
+func (s *InMemoryTaskStore) Save(_ context.Context, task *Task) error {
+	copied, err := cloneTask(task)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.tasks[task.ID]; ok && existing.ContextID != copied.ContextID {
+		delete(s.byContext[existing.ContextID], task.ID)
+	}
+	s.tasks[task.ID] = copied
+	ids := s.byContext[copied.ContextID]
+	if ids == nil {
+		ids = map[string]bool{}
+		s.byContext[copied.ContextID] = ids
+	}
+	ids[task.ID] = true
+	return nil
+}
+
+// Get returns a deep copy of the stored task.
+func (s *InMemoryTaskStore) Get(_ context.Context, id string) (*Task, error) {
+	s.mu.RLock()
+	task, ok := s.tasks[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	return cloneTask(task)
+}
+
+// List returns deep copies of the tasks matching the filter, most
+// recently updated first. The context index narrows the scan when the
+// filter names a context.
+func (s *InMemoryTaskStore) List(_ context.Context, filter TaskFilter) ([]*Task, error) {
+	s.mu.RLock()
+	var candidates []*Task
+	if filter.ContextID != "" {
+		for id := range s.byContext[filter.ContextID] {
+			candidates = append(candidates, s.tasks[id])
+		}
+	} else {
+		for _, task := range s.tasks {
+			candidates = append(candidates, task)
+		}
+	}
+	var matched []*Task
+	for _, task := range candidates {
+		if filter.matches(task) {
+			matched = append(matched, task)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		ti, tj := statusTime(matched[i]), statusTime(matched[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return matched[i].ID < matched[j].ID
+	})
+	out := make([]*Task, len(matched))
+	for i, task := range matched {
+		copied, err := cloneTask(task)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = copied
+	}
+	return out, nil
+}
+
+// statusTime parses a task's status timestamp, zero when absent or
+// malformed.
+func statusTime(task *Task) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, task.Status.Timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// cloneTask deep-copies a task so callers cannot mutate stored state.
+func cloneTask(task *Task) (*Task, error) {
+	raw, err := ActiveCodec().Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	var copied Task
+	if err := ActiveCodec().Unmarshal(raw, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
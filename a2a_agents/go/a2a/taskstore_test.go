@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// storedTask saves a task with the given identity, state, and timestamp.
+func storedTask(t *testing.T, store *InMemoryTaskStore, id, contextID string, state TaskState, at time.Time) {
+	t.Helper()
+	task := &Task{
+		ID:        id,
+		ContextID: contextID,
+		Status: TaskStatus{
+			State:     state,
+			Timestamp: at.UTC().Format(time.RFC3339Nano),
+		},
+	}
+	if err := store.Save(context.Background(), task); err != nil {
+		t.Fatalf("Save(%s): %v", id, err)
+	}
+}
+
+func TestInMemoryTaskStoreList(t *testing.T) {
+	store := NewInMemoryTaskStore()
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	storedTask(t, store, "t1", "ctx-a", TaskStateCompleted, base)
+	storedTask(t, store, "t2", "ctx-a", TaskStateWorking, base.Add(time.Minute))
+	storedTask(t, store, "t3", "ctx-b", TaskStateCompleted, base.Add(2*time.Minute))
+
+	// The store satisfies the optional lister interface.
+	var _ TaskLister = store
+
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   []string
+	}{
+		{"all, newest first", TaskFilter{}, []string{"t3", "t2", "t1"}},
+		{"by context", TaskFilter{ContextID: "ctx-a"}, []string{"t2", "t1"}},
+		{"by state", TaskFilter{States: []TaskState{TaskStateCompleted}}, []string{"t3", "t1"}},
+		{"context and state", TaskFilter{ContextID: "ctx-a", States: []TaskState{TaskStateCompleted}}, []string{"t1"}},
+		{"after", TaskFilter{UpdatedAfter: base}, []string{"t3", "t2"}},
+		{"before", TaskFilter{UpdatedBefore: base.Add(time.Minute)}, []string{"t1"}},
+		{"window", TaskFilter{UpdatedAfter: base, UpdatedBefore: base.Add(2 * time.Minute)}, []string{"t2"}},
+		{"no match", TaskFilter{ContextID: "ctx-c"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := store.List(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			var ids []string
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("List = %v, want %v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("List = %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestInMemoryTaskStoreListCopies(t *testing.T) {
+	store := NewInMemoryTaskStore()
+	storedTask(t, store, "t1", "ctx-a", TaskStateWorking, time.Now())
+
+	tasks, err := store.List(context.Background(), TaskFilter{ContextID: "ctx-a"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	tasks[0].ContextID = "mutated"
+
+	again, err := store.Get(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if again.ContextID != "ctx-a" {
+		t.Errorf("stored task mutated through List result: %q", again.ContextID)
+	}
+}
+
+func TestInMemoryTaskStoreReindexesContext(t *testing.T) {
+	store := NewInMemoryTaskStore()
+	at := time.Now()
+	storedTask(t, store, "t1", "ctx-a", TaskStateWorking, at)
+	// Re-saving under a different context must move the index entry.
+	storedTask(t, store, "t1", "ctx-b", TaskStateWorking, at)
+
+	old, err := store.List(context.Background(), TaskFilter{ContextID: "ctx-a"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(old) != 0 {
+		t.Errorf("stale index entry: %v", old)
+	}
+	moved, err := store.List(context.Background(), TaskFilter{ContextID: "ctx-b"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(moved) != 1 {
+		t.Errorf("task not listed under its new context: %v", moved)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package a2a implements the subset of the A2A (Agent-to-Agent) protocol the
+// A2UI agents need: the wire types, agent execution plumbing, and reusable
+// server helpers.
+package a2a
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// Role identifies the sender of a message.
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAgent Role = "agent"
+)
+
+// TaskState is the lifecycle state of a task.
+type TaskState string
+
+const (
+	TaskStateSubmitted     TaskState = "submitted"
+	TaskStateWorking       TaskState = "working"
+	TaskStateInputRequired TaskState = "input-required"
+	TaskStateCompleted     TaskState = "completed"
+	TaskStateCanceled      TaskState = "canceled"
+	TaskStateFailed        TaskState = "failed"
+	TaskStateRejected      TaskState = "rejected"
+)
+
+// Part is one piece of a message or artifact. Kind selects which of the
+// value fields is meaningful.
+type Part struct {
+	// Kind is "text", "data" or "file".
+	Kind string `json:"kind"`
+	// Text holds the content of a text part.
+	Text string `json:"text,omitempty"`
+	// Data holds the structured content of a data part.
+	Data map[string]any `json:"data,omitempty"`
+	// RawData holds the verbatim JSON of a data part, for producers that
+	// pass already-validated content through byte-for-byte instead of
+	// re-marshaling it. When set it takes precedence over Data on the
+	// wire.
+	RawData json.RawMessage `json:"-"`
+	// File holds the content of a file part.
+	File *FileContent `json:"file,omitempty"`
+	// Metadata carries part-level metadata such as the A2UI MIME type.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// FileContent is the payload of a file part, carried inline as base64.
+type FileContent struct {
+	Name     string `json:"name,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	Bytes    string `json:"bytes"`
+}
+
+// NewTextPart returns a text part.
+func NewTextPart(text string) Part {
+	return Part{Kind: "text", Text: text}
+}
+
+// MarshalJSON serializes RawData verbatim under "data" when it is set,
+// so pass-through parts keep the producer's number formatting and key
+// order.
+func (p Part) MarshalJSON() ([]byte, error) {
+	type plain Part
+	if len(p.RawData) == 0 {
+		return json.Marshal(plain(p))
+	}
+	return json.Marshal(struct {
+		plain
+		Data json.RawMessage `json:"data"`
+	}{plain: plain(p), Data: p.RawData})
+}
+
+// NewRawDataPart returns a data part carrying verbatim JSON.
+func NewRawDataPart(raw json.RawMessage, metadata map[string]any) Part {
+	return Part{Kind: "data", RawData: raw, Metadata: metadata}
+}
+
+// NewFilePart returns a file part with the content carried inline.
+func NewFilePart(name, mimeType string, data []byte) Part {
+	return Part{Kind: "file", File: &FileContent{
+		Name:     name,
+		MimeType: mimeType,
+		Bytes:    base64.StdEncoding.EncodeToString(data),
+	}}
+}
+
+// NewDataPart returns a data part with the given metadata.
+func NewDataPart(data, metadata map[string]any) Part {
+	return Part{Kind: "data", Data: data, Metadata: metadata}
+}
+
+// Message is a single conversational turn.
+type Message struct {
+	MessageID string `json:"messageId"`
+	Role      Role   `json:"role"`
+	Parts     []Part `json:"parts"`
+	ContextID string `json:"contextId,omitempty"`
+	TaskID    string `json:"taskId,omitempty"`
+	// Extensions lists extension URIs the sender wants activated.
+	Extensions []string       `json:"extensions,omitempty"`
+	Metadata   map[string]any `json:"metadata,omitempty"`
+}
+
+// TaskStatus is the current state of a task, optionally with a message for
+// the client.
+type TaskStatus struct {
+	State     TaskState `json:"state"`
+	Message   *Message  `json:"message,omitempty"`
+	Timestamp string    `json:"timestamp,omitempty"`
+}
+
+// NewTaskStatus returns a status in the given state stamped with the current
+// time.
+func NewTaskStatus(state TaskState, message *Message) TaskStatus {
+	return TaskStatus{
+		State:     state,
+		Message:   message,
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// Artifact is an output of a task, such as a batch of A2UI messages.
+type Artifact struct {
+	ArtifactID  string         `json:"artifactId"`
+	Name        string         `json:"name,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Parts       []Part         `json:"parts"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+// Task is a unit of work tracked by the server.
+type Task struct {
+	ID        string         `json:"id"`
+	ContextID string         `json:"contextId"`
+	Status    TaskStatus     `json:"status"`
+	Artifacts []Artifact     `json:"artifacts,omitempty"`
+	History   []Message      `json:"history,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// AgentExtension declares an A2A extension on the agent card.
+type AgentExtension struct {
+	URI         string         `json:"uri"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Params      map[string]any `json:"params,omitempty"`
+}
+
+// AgentCapabilities advertises optional features of an agent.
+type AgentCapabilities struct {
+	Streaming  bool             `json:"streaming,omitempty"`
+	Extensions []AgentExtension `json:"extensions,omitempty"`
+}
+
+// AgentCard is the agent's self-description served to clients.
+type AgentCard struct {
+	Name         string            `json:"name"`
+	Description  string            `json:"description,omitempty"`
+	URL          string            `json:"url,omitempty"`
+	Version      string            `json:"version,omitempty"`
+	Capabilities AgentCapabilities `json:"capabilities"`
+}
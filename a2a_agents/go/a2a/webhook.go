@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+// WebhookEvent is the payload an external system posts to the webhook
+// endpoint: an event type naming what happened (e.g. "order_placed") and
+// its free-form payload.
+type WebhookEvent struct {
+	Type    string         `json:"type"`
+	Payload map[string]any `json:"payload"`
+}
+
+// WebhookHandler translates one external event into the A2A events to
+// deliver — typically artifact updates carrying dataModelUpdate or
+// surface messages — keyed by the context ID of each affected session.
+type WebhookHandler func(ctx context.Context, event WebhookEvent) (map[string][]Event, error)
+
+// WebhookDispatcher is an http.Handler ingesting events from external
+// systems and fanning the registered handlers' output through a
+// PushRouter to the affected sessions.
+type WebhookDispatcher struct {
+	router *PushRouter
+
+	mu       sync.RWMutex
+	handlers map[string][]WebhookHandler
+}
+
+// NewWebhookDispatcher returns a dispatcher delivering through router.
+func NewWebhookDispatcher(router *PushRouter) *WebhookDispatcher {
+	return &WebhookDispatcher{router: router, handlers: map[string][]WebhookHandler{}}
+}
+
+// Handle registers a handler for one event type. Multiple handlers per
+// type run in registration order.
+func (d *WebhookDispatcher) Handle(eventType string, handler WebhookHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[eventType] = append(d.handlers[eventType], handler)
+}
+
+// ServeHTTP accepts POSTed webhook events. It responds 202 with delivery
+// counts once every handler ran; translation failures are a 500 so the
+// external system retries.
+func (d *WebhookDispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "reading body", http.StatusBadRequest)
+		return
+	}
+	var event WebhookEvent
+	if err := ActiveCodec().Unmarshal(body, &event); err != nil || event.Type == "" {
+		http.Error(w, "body must be a webhook event with a type", http.StatusBadRequest)
+		return
+	}
+	d.mu.RLock()
+	handlers := d.handlers[event.Type]
+	d.mu.RUnlock()
+	if len(handlers) == 0 {
+		http.Error(w, fmt.Sprintf("no handler for event type %q", event.Type), http.StatusNotFound)
+		return
+	}
+
+	delivered, unrouted := 0, 0
+	for _, handler := range handlers {
+		routed, err := handler(r.Context(), event)
+		if err != nil {
+			slog.Error("webhook handler failed", "type", event.Type, "error", err)
+			http.Error(w, "handler failed", http.StatusInternalServerError)
+			return
+		}
+		for contextID, events := range routed {
+			for _, e := range events {
+				switch err := d.router.Push(r.Context(), contextID, e); {
+				case err == nil:
+					delivered++
+				case errors.Is(err, ErrNoRoute):
+					// The session is gone or offline; that is the normal
+					// fate of broadcasts, not a webhook failure.
+					unrouted++
+				default:
+					slog.Warn("webhook delivery failed", "type", event.Type,
+						"context", contextID, "error", err)
+					unrouted++
+				}
+			}
+		}
+	}
+	writeJSON(w, http.StatusAccepted, map[string]any{
+		"delivered": delivered,
+		"unrouted":  unrouted,
+	})
+}
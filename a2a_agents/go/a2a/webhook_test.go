@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2a
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookDispatch(t *testing.T) {
+	router := NewPushRouter(nil)
+	queue := NewEventQueue(4)
+	defer router.Attach("ctx1", queue)()
+
+	dispatcher := NewWebhookDispatcher(router)
+	dispatcher.Handle("order_placed", func(ctx context.Context, event WebhookEvent) (map[string][]Event, error) {
+		if event.Payload["order"] != "o-42" {
+			t.Errorf("payload = %v", event.Payload)
+		}
+		return map[string][]Event{
+			"ctx1":    {pushEvent("ctx1")},
+			"ctx-off": {pushEvent("ctx-off")},
+		}, nil
+	})
+
+	resp := postWebhook(t, dispatcher, `{"type":"order_placed","payload":{"order":"o-42"}}`)
+	if resp.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, body %s", resp.Code, resp.Body)
+	}
+	body := resp.Body.String()
+	if !strings.Contains(body, `"delivered":1`) || !strings.Contains(body, `"unrouted":1`) {
+		t.Errorf("body = %s", body)
+	}
+	if _, ok := queue.Read(); !ok {
+		t.Error("attached session did not receive the update")
+	}
+}
+
+func TestWebhookRejectsBadRequests(t *testing.T) {
+	dispatcher := NewWebhookDispatcher(NewPushRouter(nil))
+	dispatcher.Handle("known", func(ctx context.Context, event WebhookEvent) (map[string][]Event, error) {
+		return nil, nil
+	})
+
+	if resp := postWebhook(t, dispatcher, `{"payload":{}}`); resp.Code != http.StatusBadRequest {
+		t.Errorf("missing type: status = %d", resp.Code)
+	}
+	if resp := postWebhook(t, dispatcher, `not json`); resp.Code != http.StatusBadRequest {
+		t.Errorf("bad JSON: status = %d", resp.Code)
+	}
+	if resp := postWebhook(t, dispatcher, `{"type":"unknown"}`); resp.Code != http.StatusNotFound {
+		t.Errorf("unknown type: status = %d", resp.Code)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/webhook", nil)
+	resp := httptest.NewRecorder()
+	dispatcher.ServeHTTP(resp, req)
+	if resp.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: status = %d", resp.Code)
+	}
+}
+
+func TestWebhookHandlerError(t *testing.T) {
+	dispatcher := NewWebhookDispatcher(NewPushRouter(nil))
+	dispatcher.Handle("order_placed", func(ctx context.Context, event WebhookEvent) (map[string][]Event, error) {
+		return nil, errors.New("backend down")
+	})
+	if resp := postWebhook(t, dispatcher, `{"type":"order_placed"}`); resp.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", resp.Code)
+	}
+}
+
+func postWebhook(t *testing.T, dispatcher *WebhookDispatcher, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	resp := httptest.NewRecorder()
+	dispatcher.ServeHTTP(resp, req)
+	return resp
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+// Alias rewrites a retired component type name to its current form, so
+// minor catalog renames don't break existing agents or model prompts.
+type Alias struct {
+	// Target is the component type name to emit instead.
+	Target string
+	// Props are merged into the component's properties, overriding any
+	// the payload set — e.g. {"type": "line"} for a LineChart → Chart
+	// alias.
+	Props map[string]any
+}
+
+// AliasMap maps retired component type names to their aliases.
+type AliasMap map[string]Alias
+
+// ApplyAliases rewrites aliased component types in surfaceUpdate
+// messages. Types without an alias pass through unchanged; the input is
+// not modified.
+func ApplyAliases(messages []map[string]any, aliases AliasMap) ([]map[string]any, error) {
+	copied, err := deepCopyMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range copied {
+		update, _ := msg["surfaceUpdate"].(map[string]any)
+		if update == nil {
+			continue
+		}
+		components, _ := update["components"].([]any)
+		for _, entry := range components {
+			wrapper, _ := entry.(map[string]any)
+			component, _ := wrapper["component"].(map[string]any)
+			if len(component) != 1 {
+				continue
+			}
+			for name, value := range component {
+				alias, ok := aliases[name]
+				if !ok {
+					continue
+				}
+				props, _ := value.(map[string]any)
+				if props == nil {
+					props = map[string]any{}
+				}
+				for key, override := range alias.Props {
+					props[key] = override
+				}
+				delete(component, name)
+				component[alias.Target] = props
+			}
+		}
+	}
+	return copied, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import "testing"
+
+func TestApplyAliases(t *testing.T) {
+	messages := []map[string]any{
+		{"surfaceUpdate": map[string]any{"surfaceId": "s1", "components": []any{
+			map[string]any{"id": "chart", "component": map[string]any{"LineChart": map[string]any{
+				"title": map[string]any{"literalString": "Revenue"},
+				"type":  "spline",
+			}}},
+			map[string]any{"id": "title", "component": map[string]any{"Text": map[string]any{
+				"text": map[string]any{"literalString": "Dashboard"},
+			}}},
+		}}},
+	}
+	aliases := AliasMap{
+		"LineChart": {Target: "Chart", Props: map[string]any{"type": "line"}},
+	}
+
+	rewritten, err := ApplyAliases(messages, aliases)
+	if err != nil {
+		t.Fatalf("ApplyAliases: %v", err)
+	}
+	components := rewritten[0]["surfaceUpdate"].(map[string]any)["components"].([]any)
+	chart, ok := components[0].(map[string]any)["component"].(map[string]any)["Chart"].(map[string]any)
+	if !ok {
+		t.Fatalf("LineChart not aliased: %v", components[0])
+	}
+	if chart["type"] != "line" {
+		t.Errorf("alias props did not override: %v", chart)
+	}
+	if title, _ := chart["title"].(map[string]any); title["literalString"] != "Revenue" {
+		t.Errorf("alias lost existing props: %v", chart)
+	}
+	if _, ok := components[1].(map[string]any)["component"].(map[string]any)["Text"]; !ok {
+		t.Error("non-aliased component was rewritten")
+	}
+
+	// The original payload is untouched.
+	original := messages[0]["surfaceUpdate"].(map[string]any)["components"].([]any)
+	if _, ok := original[0].(map[string]any)["component"].(map[string]any)["LineChart"]; !ok {
+		t.Error("ApplyAliases modified its input")
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// Asset references let surfaces carry binary content — images, audio,
+// attachments — without inlining base64 blobs in the JSON data model.
+// The reference is a flat map of scalars (so it fits a data-model value)
+// naming either a sibling FilePart or an asset URL, always with a SHA-256
+// integrity hash the client verifies after resolving it.
+const (
+	// AssetURIKey holds the URL the client fetches the content from.
+	AssetURIKey = "assetUri"
+	// AssetFileKey holds the name of a FilePart, delivered alongside the
+	// surface, carrying the content.
+	AssetFileKey = "assetFile"
+	// AssetMIMEKey holds the content's MIME type.
+	AssetMIMEKey = "assetMimeType"
+	// AssetHashKey holds the lowercase hex SHA-256 of the content.
+	AssetHashKey = "assetSha256"
+)
+
+// URLAssetReference builds a data-model value pointing at content hosted
+// at uri, hashed so the client can verify what it fetches.
+func URLAssetReference(uri, mimeType string, content []byte) map[string]any {
+	return map[string]any{
+		AssetURIKey:  uri,
+		AssetMIMEKey: mimeType,
+		AssetHashKey: hashAsset(content),
+	}
+}
+
+// FileAssetReference wraps content in a FilePart and builds the
+// data-model value pointing at it, for binary payloads small enough to
+// ride the A2A message but too opaque for the data model itself.
+func FileAssetReference(name, mimeType string, content []byte) (a2a.Part, map[string]any) {
+	part := a2a.NewFilePart(name, mimeType, content)
+	return part, map[string]any{
+		AssetFileKey: name,
+		AssetMIMEKey: mimeType,
+		AssetHashKey: hashAsset(content),
+	}
+}
+
+// AssetReference reports whether a data-model value is an asset
+// reference, returning it as a map when it is.
+func AssetReference(value any) (map[string]any, bool) {
+	ref, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := ref[AssetHashKey].(string); !ok {
+		return nil, false
+	}
+	_, hasURI := ref[AssetURIKey].(string)
+	_, hasFile := ref[AssetFileKey].(string)
+	return ref, hasURI || hasFile
+}
+
+// ResolveAsset returns the content of a FilePart-backed reference,
+// searching the parts delivered with the surface and verifying the
+// integrity hash. URL-backed references cannot be resolved locally: the
+// caller fetches the URI and checks the result with VerifyAsset.
+func ResolveAsset(ref map[string]any, parts []a2a.Part) ([]byte, error) {
+	name, ok := ref[AssetFileKey].(string)
+	if !ok {
+		if uri, ok := ref[AssetURIKey].(string); ok {
+			return nil, fmt.Errorf("a2ui: asset %q is URL-backed; fetch it and verify with VerifyAsset", uri)
+		}
+		return nil, fmt.Errorf("a2ui: value is not an asset reference")
+	}
+	for _, part := range parts {
+		if part.Kind != "file" || part.File == nil || part.File.Name != name {
+			continue
+		}
+		content, err := base64.StdEncoding.DecodeString(part.File.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("a2ui: decoding asset %q: %w", name, err)
+		}
+		if err := VerifyAsset(ref, content); err != nil {
+			return nil, err
+		}
+		return content, nil
+	}
+	return nil, fmt.Errorf("a2ui: no file part named %q carries the asset", name)
+}
+
+// VerifyAsset checks resolved content against the reference's integrity
+// hash, guarding file parts against mixups and fetched URLs against
+// tampering or stale caches.
+func VerifyAsset(ref map[string]any, content []byte) error {
+	want, ok := ref[AssetHashKey].(string)
+	if !ok {
+		return fmt.Errorf("a2ui: asset reference lacks an integrity hash")
+	}
+	if got := hashAsset(content); got != want {
+		return fmt.Errorf("a2ui: asset hash %s does not match the reference's %s", got, want)
+	}
+	return nil
+}
+
+// hashAsset returns the lowercase hex SHA-256 of content.
+func hashAsset(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
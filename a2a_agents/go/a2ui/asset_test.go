@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func TestFileAssetReferenceRoundTrip(t *testing.T) {
+	content := []byte("\x89PNG fake image bytes")
+	part, ref := FileAssetReference("chart.png", "image/png", content)
+
+	if _, ok := AssetReference(ref); !ok {
+		t.Fatal("reference not recognized as an asset")
+	}
+	// The reference fits a data-model value: a flat map of scalars.
+	if _, err := DataModelUpdateMessage("main", "/chart/image", ref); err != nil {
+		t.Errorf("reference does not fit the data model: %v", err)
+	}
+
+	resolved, err := ResolveAsset(ref, []a2a.Part{a2a.NewTextPart("hi"), part})
+	if err != nil {
+		t.Fatalf("ResolveAsset: %v", err)
+	}
+	if !bytes.Equal(resolved, content) {
+		t.Error("resolved content does not match the original")
+	}
+
+	// A part carrying different bytes under the same name fails the hash.
+	badPart := a2a.NewFilePart("chart.png", "image/png", []byte("other"))
+	if _, err := ResolveAsset(ref, []a2a.Part{badPart}); err == nil {
+		t.Error("tampered asset resolved without error")
+	}
+	if _, err := ResolveAsset(ref, nil); err == nil {
+		t.Error("missing file part resolved without error")
+	}
+}
+
+func TestURLAssetReference(t *testing.T) {
+	content := []byte("audio bytes")
+	ref := URLAssetReference("https://example.com/clip.mp3", "audio/mpeg", content)
+	if _, ok := AssetReference(ref); !ok {
+		t.Fatal("reference not recognized as an asset")
+	}
+
+	// Resolution is a fetch plus VerifyAsset on the result.
+	if _, err := ResolveAsset(ref, nil); err == nil {
+		t.Error("URL-backed reference resolved locally")
+	}
+	if err := VerifyAsset(ref, content); err != nil {
+		t.Errorf("VerifyAsset on matching content: %v", err)
+	}
+	if err := VerifyAsset(ref, []byte("tampered")); err == nil {
+		t.Error("VerifyAsset accepted tampered content")
+	}
+}
+
+func TestAssetReferenceRejectsPlainValues(t *testing.T) {
+	for _, value := range []any{
+		"https://example.com/clip.mp3",
+		map[string]any{"filter": "all"},
+		42.0,
+	} {
+		if _, ok := AssetReference(value); ok {
+			t.Errorf("plain value %v recognized as an asset", value)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// Part metadata keys grouping related A2UI messages into an atomic batch.
+// A beginRendering/surfaceUpdate/dataModelUpdate triple stamped with the
+// same batch ID should be applied together, in index order, or not at all.
+const (
+	// BatchIDKey carries the identifier shared by every part of one batch.
+	BatchIDKey = "a2uiBatchId"
+	// BatchIndexKey carries the zero-based position of a part within its
+	// batch.
+	BatchIndexKey = "a2uiBatchIndex"
+	// BatchCountKey carries the total number of parts in the batch, so a
+	// client can tell when it has received them all.
+	BatchCountKey = "a2uiBatchCount"
+)
+
+// NewBatchParts packages related A2UI messages as the parts of one batch:
+// each part carries the same fresh batch ID plus its index and the batch
+// size, so a client can apply the whole group atomically and in order even
+// when the parts arrive interleaved or split across artifact chunks.
+func NewBatchParts(messages []map[string]any) []a2a.Part {
+	id := a2a.NewID()
+	parts := make([]a2a.Part, len(messages))
+	for i, msg := range messages {
+		parts[i] = a2a.NewDataPart(msg, batchMetadata(id, i, len(messages)))
+	}
+	return parts
+}
+
+// NewRawBatchParts is NewBatchParts for verbatim JSON messages, preserving
+// the producer's number formatting and key order like NewRawPart.
+func NewRawBatchParts(segments []json.RawMessage) []a2a.Part {
+	id := a2a.NewID()
+	parts := make([]a2a.Part, len(segments))
+	for i, raw := range segments {
+		parts[i] = a2a.NewRawDataPart(raw, batchMetadata(id, i, len(segments)))
+	}
+	return parts
+}
+
+func batchMetadata(id string, index, count int) map[string]any {
+	return map[string]any{
+		MIMETypeKey:   MIMEType,
+		BatchIDKey:    id,
+		BatchIndexKey: index,
+		BatchCountKey: count,
+	}
+}
+
+// Batch is a group of A2UI messages a client should apply atomically.
+type Batch struct {
+	// ID is the shared batch identifier, empty for an unbatched part.
+	ID string
+	// Messages are the batch's A2UI messages in index order.
+	Messages []map[string]any
+	// Complete reports whether every index up to the declared batch count
+	// arrived. Incomplete batches should be held back, not applied.
+	Complete bool
+}
+
+// CollectBatches groups the A2UI parts of a message or artifact into
+// batches, ordered by first appearance. Parts within a batch are sorted by
+// their declared index regardless of arrival order, and a batch is marked
+// complete once every index up to its declared count is present. Parts
+// without batch metadata become single-message complete batches, so a
+// client can process batched and unbatched content uniformly. Non-A2UI
+// parts are ignored.
+func CollectBatches(parts []a2a.Part) []Batch {
+	var order []string
+	pending := map[string]map[int]map[string]any{}
+	counts := map[string]int{}
+	var batches []Batch
+	for _, part := range parts {
+		data := Data(part)
+		if data == nil {
+			continue
+		}
+		id, ok := part.Metadata[BatchIDKey].(string)
+		if !ok || id == "" {
+			batches = append(batches, Batch{Messages: []map[string]any{data}, Complete: true})
+			continue
+		}
+		if _, seen := pending[id]; !seen {
+			order = append(order, id)
+			pending[id] = map[int]map[string]any{}
+		}
+		index, ok := intMetadata(part.Metadata[BatchIndexKey])
+		if !ok {
+			index = len(pending[id])
+		}
+		pending[id][index] = data
+		if count, ok := intMetadata(part.Metadata[BatchCountKey]); ok && count > counts[id] {
+			counts[id] = count
+		}
+	}
+	for _, id := range order {
+		members := pending[id]
+		count := counts[id]
+		if count < len(members) {
+			count = len(members)
+		}
+		batch := Batch{ID: id, Complete: true}
+		for i := 0; i < count; i++ {
+			msg, ok := members[i]
+			if !ok {
+				batch.Complete = false
+				continue
+			}
+			batch.Messages = append(batch.Messages, msg)
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// intMetadata reads a numeric metadata value, tolerating the float64 and
+// json.Number forms a JSON round trip produces.
+func intMetadata(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, false
+		}
+		return int(n), true
+	}
+	return 0, false
+}
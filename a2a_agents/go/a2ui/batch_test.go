@@ -0,0 +1,155 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// batchTriple is the canonical atomic group: create the surface, bind its
+// data, then begin rendering.
+func batchTriple() []map[string]any {
+	return []map[string]any{
+		{"surfaceUpdate": map[string]any{"surfaceId": "s1", "components": []any{}}},
+		{"dataModelUpdate": map[string]any{"surfaceId": "s1", "contents": []any{}}},
+		{"beginRendering": map[string]any{"surfaceId": "s1", "root": "root"}},
+	}
+}
+
+func TestNewBatchPartsMetadata(t *testing.T) {
+	parts := NewBatchParts(batchTriple())
+	if len(parts) != 3 {
+		t.Fatalf("got %d parts, want 3", len(parts))
+	}
+	id, _ := parts[0].Metadata[BatchIDKey].(string)
+	if id == "" {
+		t.Fatal("batch ID missing")
+	}
+	for i, part := range parts {
+		if !IsA2UIPart(part) {
+			t.Errorf("part %d is not marked as A2UI content", i)
+		}
+		if got := part.Metadata[BatchIDKey]; got != id {
+			t.Errorf("part %d batch ID = %v, want %q", i, got, id)
+		}
+		if got := part.Metadata[BatchIndexKey]; got != i {
+			t.Errorf("part %d index = %v, want %d", i, got, i)
+		}
+		if got := part.Metadata[BatchCountKey]; got != 3 {
+			t.Errorf("part %d count = %v, want 3", i, got)
+		}
+	}
+	other := NewBatchParts(batchTriple())
+	if other[0].Metadata[BatchIDKey] == id {
+		t.Error("separate batches share an ID")
+	}
+}
+
+func TestCollectBatchesReorders(t *testing.T) {
+	parts := NewBatchParts(batchTriple())
+	// Arrival order must not matter; the declared indexes do.
+	shuffled := []a2a.Part{parts[2], parts[0], parts[1]}
+	batches := CollectBatches(shuffled)
+	if len(batches) != 1 {
+		t.Fatalf("got %d batches, want 1", len(batches))
+	}
+	batch := batches[0]
+	if !batch.Complete {
+		t.Error("full batch not marked complete")
+	}
+	want := []string{"surfaceUpdate", "dataModelUpdate", "beginRendering"}
+	if len(batch.Messages) != len(want) {
+		t.Fatalf("got %d messages, want %d", len(batch.Messages), len(want))
+	}
+	for i, kind := range want {
+		if _, ok := batch.Messages[i][kind]; !ok {
+			t.Errorf("message %d is not a %s", i, kind)
+		}
+	}
+}
+
+func TestCollectBatchesIncomplete(t *testing.T) {
+	parts := NewBatchParts(batchTriple())
+	batches := CollectBatches(parts[:2])
+	if len(batches) != 1 {
+		t.Fatalf("got %d batches, want 1", len(batches))
+	}
+	if batches[0].Complete {
+		t.Error("partial batch marked complete")
+	}
+	if len(batches[0].Messages) != 2 {
+		t.Errorf("got %d messages, want the 2 that arrived", len(batches[0].Messages))
+	}
+}
+
+func TestCollectBatchesUnbatchedAndForeignParts(t *testing.T) {
+	parts := []a2a.Part{
+		a2a.NewTextPart("progress note"),
+		NewPart(map[string]any{"deleteSurface": map[string]any{"surfaceId": "s0"}}),
+	}
+	parts = append(parts, NewBatchParts(batchTriple())...)
+	batches := CollectBatches(parts)
+	if len(batches) != 2 {
+		t.Fatalf("got %d batches, want 2", len(batches))
+	}
+	if batches[0].ID != "" || !batches[0].Complete || len(batches[0].Messages) != 1 {
+		t.Errorf("unbatched part collected as %+v, want a complete singleton", batches[0])
+	}
+	if batches[1].ID == "" || len(batches[1].Messages) != 3 {
+		t.Errorf("batched triple collected as %+v", batches[1])
+	}
+}
+
+func TestCollectBatchesSurvivesJSONRoundTrip(t *testing.T) {
+	// On the wire the index and count arrive as float64, not int.
+	raw, err := json.Marshal(NewBatchParts(batchTriple()))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var parts []a2a.Part
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	batches := CollectBatches(parts)
+	if len(batches) != 1 || !batches[0].Complete || len(batches[0].Messages) != 3 {
+		t.Errorf("round-tripped batch collected as %+v", batches)
+	}
+}
+
+func TestNewRawBatchParts(t *testing.T) {
+	segments := []json.RawMessage{
+		json.RawMessage(`{"surfaceUpdate": {"surfaceId": "s1", "components": []}}`),
+		json.RawMessage(`{"beginRendering": {"surfaceId": "s1", "root": "root", "weight": 1.50}}`),
+	}
+	parts := NewRawBatchParts(segments)
+	if len(parts) != 2 {
+		t.Fatalf("got %d parts, want 2", len(parts))
+	}
+	out, err := json.Marshal(parts[1])
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(out), "1.50") {
+		t.Errorf("raw part perturbed the producer's JSON:\n%s", out)
+	}
+	batches := CollectBatches(parts)
+	if len(batches) != 1 || !batches[0].Complete {
+		t.Errorf("raw batch collected as %+v", batches)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// CanonicalJSON marshals a value to a canonical JSON form: object keys
+// sorted, numbers normalized ("1.0" and "1e2" encode as 1 and 100), and
+// no insignificant whitespace. Two payloads that decode to the same
+// value always canonicalize to the same bytes, which makes the output
+// safe to hash, diff against golden files, or store in audit records —
+// unlike a plain marshal of structs or raw messages, whose key order
+// follows the source.
+func CanonicalJSON(value any) ([]byte, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("a2ui: canonicalizing: %w", err)
+	}
+	// Round-trip through a decoder that keeps numbers textual, so the
+	// normalization below sees the exact value rather than a float64
+	// approximation chosen by the first marshal.
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("a2ui: canonicalizing: %w", err)
+	}
+	var b bytes.Buffer
+	if err := writeCanonical(&b, decoded); err != nil {
+		return nil, fmt.Errorf("a2ui: canonicalizing: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// writeCanonical encodes a decoded JSON value with sorted keys and
+// normalized numbers.
+func writeCanonical(b *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		b.WriteString("null")
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			encoded, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			b.Write(encoded)
+			b.WriteByte(':')
+			if err := writeCanonical(b, v[key]); err != nil {
+				return err
+			}
+		}
+		b.WriteByte('}')
+	case []any:
+		b.WriteByte('[')
+		for i, entry := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if err := writeCanonical(b, entry); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+	case json.Number:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		if err != nil {
+			return fmt.Errorf("number %q: %w", v, err)
+		}
+		// encoding/json's float formatting is already minimal; reusing
+		// it collapses the spellings "1", "1.0" and "1e0".
+		encoded, err := json.Marshal(f)
+		if err != nil {
+			return err
+		}
+		b.Write(encoded)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		b.Write(encoded)
+	}
+	return nil
+}
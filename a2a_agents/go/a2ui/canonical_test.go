@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCanonicalJSONSortsKeys(t *testing.T) {
+	got, err := CanonicalJSON(map[string]any{
+		"b": 2,
+		"a": map[string]any{"z": true, "y": nil},
+	})
+	if err != nil {
+		t.Fatalf("CanonicalJSON: %v", err)
+	}
+	want := `{"a":{"y":null,"z":true},"b":2}`
+	if string(got) != want {
+		t.Errorf("CanonicalJSON = %s, want %s", got, want)
+	}
+}
+
+func TestCanonicalJSONNormalizesNumbers(t *testing.T) {
+	for raw, want := range map[string]string{
+		`{"n":1.0}`:   `{"n":1}`,
+		`{"n":1e2}`:   `{"n":100}`,
+		`{"n":0.5}`:   `{"n":0.5}`,
+		`{"n":-0.25}`: `{"n":-0.25}`,
+	} {
+		var decoded map[string]any
+		if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+			t.Fatalf("decoding %s: %v", raw, err)
+		}
+		got, err := CanonicalJSON(decoded)
+		if err != nil {
+			t.Fatalf("CanonicalJSON(%s): %v", raw, err)
+		}
+		if string(got) != want {
+			t.Errorf("CanonicalJSON(%s) = %s, want %s", raw, got, want)
+		}
+	}
+}
+
+func TestCanonicalJSONStructAndMapAgree(t *testing.T) {
+	type payload struct {
+		Zeta  string `json:"zeta"`
+		Alpha int    `json:"alpha"`
+	}
+	fromStruct, err := CanonicalJSON(payload{Zeta: "z", Alpha: 1})
+	if err != nil {
+		t.Fatalf("CanonicalJSON(struct): %v", err)
+	}
+	fromMap, err := CanonicalJSON(map[string]any{"zeta": "z", "alpha": float64(1)})
+	if err != nil {
+		t.Fatalf("CanonicalJSON(map): %v", err)
+	}
+	if string(fromStruct) != string(fromMap) {
+		t.Errorf("struct and map canonical forms differ: %s vs %s", fromStruct, fromMap)
+	}
+	want := `{"alpha":1,"zeta":"z"}`
+	if string(fromStruct) != want {
+		t.Errorf("canonical form = %s, want %s", fromStruct, want)
+	}
+}
+
+func TestCanonicalJSONArraysAndEscapes(t *testing.T) {
+	got, err := CanonicalJSON(map[string]any{
+		"list": []any{float64(1), "two", map[string]any{"b": false, "a": "<&>"}},
+	})
+	if err != nil {
+		t.Fatalf("CanonicalJSON: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("canonical output is not valid JSON: %v\n%s", err, got)
+	}
+	list, _ := decoded["list"].([]any)
+	if len(list) != 3 {
+		t.Errorf("round-tripped list = %v, want 3 entries", list)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+// CompiledSchema is the merged and compiled message schema for one
+// catalog.
+type CompiledSchema struct {
+	// Catalog is the catalog the schema was merged from.
+	Catalog *Catalog
+	// Doc is the merged schema document.
+	Doc map[string]any
+	// Schema is the compiled validator.
+	Schema *jsonschema.Schema
+	// Err is the merge or compile failure, nil on success.
+	Err error
+}
+
+// CompileCatalogs merges and compiles the message schema for every
+// catalog concurrently, so agents registering many catalogs don't
+// serialize the compile time on boot. A canceled context aborts the
+// remaining compiles, recording the context error on their results.
+// Results are in input order, each carrying its own error; the returned
+// error aggregates the failures so callers can report readiness in one
+// check.
+func CompileCatalogs(ctx context.Context, catalogs []*Catalog, workers int) ([]*CompiledSchema, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(catalogs) {
+		workers = len(catalogs)
+	}
+	results := make([]*CompiledSchema, len(catalogs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					results[i] = &CompiledSchema{Catalog: catalogs[i], Err: err}
+					continue
+				}
+				results[i] = compileCatalog(ctx, catalogs[i])
+			}
+		}()
+	}
+	for i := range catalogs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", catalogID(result.Catalog), result.Err))
+		}
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("a2ui: %d of %d catalog schemas failed to compile: %s",
+			len(failed), len(catalogs), strings.Join(failed, "; "))
+	}
+	return results, nil
+}
+
+// compileCatalog merges and compiles one catalog's schema.
+func compileCatalog(ctx context.Context, catalog *Catalog) *CompiledSchema {
+	result := &CompiledSchema{Catalog: catalog}
+	result.Doc, result.Err = LoadA2UISchema(catalog)
+	if result.Err != nil {
+		return result
+	}
+	result.Schema, result.Err = jsonschema.CompileContext(ctx, result.Doc)
+	return result
+}
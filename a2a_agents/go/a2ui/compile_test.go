@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCompileCatalogs(t *testing.T) {
+	catalogs := []*Catalog{
+		nil, // standard catalog
+		{ID: "charts", Components: map[string]any{"Chart": map[string]any{"type": "object"}}},
+		{ID: "maps", Components: map[string]any{"GoogleMap": map[string]any{"type": "object"}}},
+	}
+	results, err := CompileCatalogs(context.Background(), catalogs, 2)
+	if err != nil {
+		t.Fatalf("CompileCatalogs: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	for i, result := range results {
+		if result.Err != nil || result.Schema == nil {
+			t.Errorf("result %d: err %v, schema %v", i, result.Err, result.Schema)
+		}
+		if result.Catalog != catalogs[i] {
+			t.Errorf("result %d out of order", i)
+		}
+	}
+}
+
+func TestCompileCatalogsAggregatesFailures(t *testing.T) {
+	catalogs := []*Catalog{
+		{ID: "good", Components: map[string]any{"Chart": map[string]any{"type": "object"}}},
+		{ID: "bad", Components: map[string]any{
+			"Broken": map[string]any{"$ref": "https://example.com/elsewhere"},
+		}},
+	}
+	results, err := CompileCatalogs(context.Background(), catalogs, 0)
+	if err == nil {
+		t.Fatal("failing catalog compiled without error")
+	}
+	if !strings.Contains(err.Error(), "1 of 2") || !strings.Contains(err.Error(), "bad") {
+		t.Errorf("aggregate error = %v", err)
+	}
+	if results[0].Err != nil {
+		t.Errorf("good catalog failed: %v", results[0].Err)
+	}
+	if results[1].Err == nil {
+		t.Error("bad catalog has no error")
+	}
+}
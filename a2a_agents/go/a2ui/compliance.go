@@ -0,0 +1,235 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+// Compliance vector kinds.
+const (
+	// VectorValidation checks a payload's verdict against the merged
+	// standard schema.
+	VectorValidation = "validation"
+	// VectorNegotiation checks an extension activation scenario.
+	VectorNegotiation = "negotiation"
+)
+
+// ComplianceVector is one spec-derived check an implementation must get
+// right. Validation vectors carry a payload and its expected verdict;
+// negotiation vectors describe a client request and whether the extension
+// must activate.
+type ComplianceVector struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+
+	// Validation vectors.
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	WantValid bool            `json:"wantValid,omitempty"`
+
+	// Negotiation vectors.
+	RequestExtension  bool     `json:"requestExtension,omitempty"`
+	SupportedCatalogs []string `json:"supportedCatalogs,omitempty"`
+	AgentCatalogs     []string `json:"agentCatalogs,omitempty"`
+	WantActivated     bool     `json:"wantActivated,omitempty"`
+}
+
+// ComplianceResult is one vector's outcome.
+type ComplianceResult struct {
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ComplianceReport is the machine-readable outcome of a compliance run,
+// comparable across implementations.
+type ComplianceReport struct {
+	Implementation string             `json:"implementation"`
+	Extension      string             `json:"extension"`
+	Passed         int                `json:"passed"`
+	Failed         int                `json:"failed"`
+	Results        []ComplianceResult `json:"results"`
+}
+
+// ComplianceVectors returns the built-in battery of spec-derived vectors:
+// payloads the schema must accept or reject, and extension negotiation
+// scenarios.
+func ComplianceVectors() []ComplianceVector {
+	return []ComplianceVector{
+		{
+			Name: "begin-rendering-minimal", Kind: VectorValidation,
+			Description: "beginRendering with its required surfaceId and root",
+			Payload:     json.RawMessage(`[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`),
+			WantValid:   true,
+		},
+		{
+			Name: "surface-update-text", Kind: VectorValidation,
+			Description: "surfaceUpdate carrying a standard Text component",
+			Payload: json.RawMessage(`[{"surfaceUpdate": {"surfaceId": "s1", "components": [` +
+				`{"id": "root", "component": {"Text": {"text": {"literalString": "hi"}}}}]}}]`),
+			WantValid: true,
+		},
+		{
+			Name: "data-model-update", Kind: VectorValidation,
+			Description: "dataModelUpdate with typed contents",
+			Payload: json.RawMessage(`[{"dataModelUpdate": {"surfaceId": "s1", "contents": [` +
+				`{"key": "total", "valueNumber": 3}]}}]`),
+			WantValid: true,
+		},
+		{
+			Name: "delete-surface", Kind: VectorValidation,
+			Description: "deleteSurface for an existing surface",
+			Payload:     json.RawMessage(`[{"deleteSurface": {"surfaceId": "s1"}}]`),
+			WantValid:   true,
+		},
+		{
+			Name: "begin-rendering-missing-root", Kind: VectorValidation,
+			Description: "beginRendering must carry its required root",
+			Payload:     json.RawMessage(`[{"beginRendering": {"surfaceId": "s1"}}]`),
+			WantValid:   false,
+		},
+		{
+			Name: "unknown-message-kind", Kind: VectorValidation,
+			Description: "a message with an unknown action property is invalid",
+			Payload:     json.RawMessage(`[{"explodeSurface": {"surfaceId": "s1"}}]`),
+			WantValid:   false,
+		},
+		{
+			Name: "surface-update-empty-components", Kind: VectorValidation,
+			Description: "surfaceUpdate must carry at least one component",
+			Payload:     json.RawMessage(`[{"surfaceUpdate": {"surfaceId": "s1", "components": []}}]`),
+			WantValid:   false,
+		},
+		{
+			Name: "non-object-message", Kind: VectorValidation,
+			Description: "payload elements must be objects",
+			Payload:     json.RawMessage(`["just a string"]`),
+			WantValid:   false,
+		},
+		{
+			Name: "negotiate-requested", Kind: VectorNegotiation,
+			Description:      "a client requesting the extension activates it",
+			RequestExtension: true,
+			WantActivated:    true,
+		},
+		{
+			Name: "negotiate-not-requested", Kind: VectorNegotiation,
+			Description:   "a client that did not request the extension is refused",
+			WantActivated: false,
+		},
+		{
+			Name: "negotiate-catalog-covered", Kind: VectorNegotiation,
+			Description:       "an advertised catalog list covering the agent's catalog passes",
+			RequestExtension:  true,
+			SupportedCatalogs: []string{StandardCatalogID},
+			AgentCatalogs:     []string{StandardCatalogID},
+			WantActivated:     true,
+		},
+		{
+			Name: "negotiate-catalog-missing", Kind: VectorNegotiation,
+			Description:       "a client lacking the agent's catalog is refused",
+			RequestExtension:  true,
+			SupportedCatalogs: []string{StandardCatalogID},
+			AgentCatalogs:     []string{"https://example.com/custom_catalog.json"},
+			WantActivated:     false,
+		},
+		{
+			Name: "negotiate-no-catalog-advertised", Kind: VectorNegotiation,
+			Description:      "clients predating the catalog capability pass the catalog check",
+			RequestExtension: true,
+			AgentCatalogs:    []string{"https://example.com/custom_catalog.json"},
+			WantActivated:    true,
+		},
+	}
+}
+
+// RunCompliance executes every built-in vector against this library — the
+// merged standard schema for validation vectors, Require for negotiation
+// vectors — and returns the report.
+func RunCompliance() (*ComplianceReport, error) {
+	doc, err := LoadA2UISchema(nil)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := WrapAsJSONArray(doc)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := jsonschema.Compile(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("a2ui: compiling standard schema: %w", err)
+	}
+
+	report := &ComplianceReport{Implementation: "go", Extension: ExtensionURI}
+	for _, vector := range ComplianceVectors() {
+		result := ComplianceResult{Name: vector.Name, Kind: vector.Kind}
+		switch vector.Kind {
+		case VectorValidation:
+			result.Passed, result.Detail = runValidationVector(schema, vector)
+		case VectorNegotiation:
+			result.Passed, result.Detail = runNegotiationVector(vector)
+		default:
+			result.Detail = fmt.Sprintf("unknown vector kind %q", vector.Kind)
+		}
+		if result.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+func runValidationVector(schema *jsonschema.Schema, vector ComplianceVector) (bool, string) {
+	var payload any
+	if err := json.Unmarshal(vector.Payload, &payload); err != nil {
+		return false, fmt.Sprintf("payload does not parse: %v", err)
+	}
+	err := schema.Validate(payload)
+	if vector.WantValid && err != nil {
+		return false, fmt.Sprintf("expected valid, got: %v", err)
+	}
+	if !vector.WantValid && err == nil {
+		return false, "expected the schema to reject the payload"
+	}
+	return true, ""
+}
+
+func runNegotiationVector(vector ComplianceVector) (bool, string) {
+	msg := &a2a.Message{Parts: []a2a.Part{a2a.NewTextPart("hello")}}
+	if vector.SupportedCatalogs != nil {
+		SetClientSupportedCatalogIDs(msg, vector.SupportedCatalogs)
+	}
+	var requested []string
+	if vector.RequestExtension {
+		requested = []string{ExtensionURI}
+	}
+	rc := a2a.NewRequestContext(msg, "compliance", "compliance", requested)
+	err := Require(rc, vector.AgentCatalogs...)
+	if vector.WantActivated && err != nil {
+		return false, fmt.Sprintf("expected activation, got: %v", err)
+	}
+	if !vector.WantActivated && err == nil {
+		return false, "expected the extension to be refused"
+	}
+	return true, ""
+}
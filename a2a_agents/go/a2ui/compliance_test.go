@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRunCompliancePasses(t *testing.T) {
+	report, err := RunCompliance()
+	if err != nil {
+		t.Fatalf("RunCompliance: %v", err)
+	}
+	if report.Failed != 0 {
+		for _, result := range report.Results {
+			if !result.Passed {
+				t.Errorf("vector %s failed: %s", result.Name, result.Detail)
+			}
+		}
+	}
+	if report.Passed != len(ComplianceVectors()) {
+		t.Errorf("passed = %d, want all %d vectors", report.Passed, len(ComplianceVectors()))
+	}
+	if report.Implementation != "go" || report.Extension != ExtensionURI {
+		t.Errorf("report header = %s/%s", report.Implementation, report.Extension)
+	}
+}
+
+func TestComplianceReportIsMachineReadable(t *testing.T) {
+	report, err := RunCompliance()
+	if err != nil {
+		t.Fatalf("RunCompliance: %v", err)
+	}
+	raw, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var decoded ComplianceReport
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(decoded.Results) != len(report.Results) {
+		t.Errorf("round trip lost results: %d != %d", len(decoded.Results), len(report.Results))
+	}
+}
+
+func TestComplianceVectorsCoverBothKinds(t *testing.T) {
+	counts := map[string]int{}
+	seen := map[string]bool{}
+	for _, vector := range ComplianceVectors() {
+		counts[vector.Kind]++
+		if seen[vector.Name] {
+			t.Errorf("duplicate vector name %q", vector.Name)
+		}
+		seen[vector.Name] = true
+	}
+	if counts[VectorValidation] == 0 || counts[VectorNegotiation] == 0 {
+		t.Errorf("vector kinds = %v, want both validation and negotiation", counts)
+	}
+}
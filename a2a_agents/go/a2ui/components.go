@@ -0,0 +1,281 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+// Typed definitions for the standard component catalog
+// (spec/v0_8/standard_catalog_definition.json), so builders, validators
+// and renderers share these names instead of scattering string literals.
+// The definitions mirror the bundled schema; TestComponentDefinitionsMatchCatalog
+// fails when they drift from it.
+
+// Standard-catalog component type names.
+const (
+	ComponentAudioPlayer    = "AudioPlayer"
+	ComponentButton         = "Button"
+	ComponentCard           = "Card"
+	ComponentCheckBox       = "CheckBox"
+	ComponentColumn         = "Column"
+	ComponentDateTimeInput  = "DateTimeInput"
+	ComponentDivider        = "Divider"
+	ComponentIcon           = "Icon"
+	ComponentImage          = "Image"
+	ComponentList           = "List"
+	ComponentModal          = "Modal"
+	ComponentMultipleChoice = "MultipleChoice"
+	ComponentRow            = "Row"
+	ComponentSlider         = "Slider"
+	ComponentTabs           = "Tabs"
+	ComponentText           = "Text"
+	ComponentTextField      = "TextField"
+	ComponentVideo          = "Video"
+)
+
+// StandardComponentNames lists every standard-catalog component type
+// name, sorted.
+var StandardComponentNames = []string{
+	ComponentAudioPlayer,
+	ComponentButton,
+	ComponentCard,
+	ComponentCheckBox,
+	ComponentColumn,
+	ComponentDateTimeInput,
+	ComponentDivider,
+	ComponentIcon,
+	ComponentImage,
+	ComponentList,
+	ComponentModal,
+	ComponentMultipleChoice,
+	ComponentRow,
+	ComponentSlider,
+	ComponentTabs,
+	ComponentText,
+	ComponentTextField,
+	ComponentVideo,
+}
+
+// StringValue is a string prop sourced from a literal or a data-model
+// path. The value-source types marshal to the catalog's wire shape, so a
+// struct-built component validates against the schema as is.
+type StringValue struct {
+	LiteralString string `json:"literalString,omitempty"`
+	Path          string `json:"path,omitempty"`
+}
+
+// NumberValue is a numeric prop sourced from a literal or a data-model
+// path. LiteralNumber is a pointer so zero survives marshaling.
+type NumberValue struct {
+	LiteralNumber *float64 `json:"literalNumber,omitempty"`
+	Path          string   `json:"path,omitempty"`
+}
+
+// BooleanValue is a boolean prop sourced from a literal or a data-model
+// path. LiteralBoolean is a pointer so false survives marshaling.
+type BooleanValue struct {
+	LiteralBoolean *bool  `json:"literalBoolean,omitempty"`
+	Path           string `json:"path,omitempty"`
+}
+
+// ArrayValue is an array prop sourced from a literal or a data-model
+// path.
+type ArrayValue struct {
+	LiteralArray []any  `json:"literalArray,omitempty"`
+	Path         string `json:"path,omitempty"`
+}
+
+// Children names a layout component's children: an explicit component ID
+// list, or a template stamped per element of a bound data-model list.
+type Children struct {
+	ExplicitList []string       `json:"explicitList,omitempty"`
+	Template     *ChildTemplate `json:"template,omitempty"`
+}
+
+// ChildTemplate stamps one component per element of the bound list.
+type ChildTemplate struct {
+	ComponentID string `json:"componentId"`
+	DataBinding string `json:"dataBinding"`
+}
+
+// Action is the client-side action a component dispatches, with an
+// optional context payload resolved against the data model.
+type Action struct {
+	Name    string              `json:"name"`
+	Context []ActionContextItem `json:"context,omitempty"`
+}
+
+// ActionContextItem is one key in an action's context payload.
+type ActionContextItem struct {
+	Key   string       `json:"key"`
+	Value ContextValue `json:"value"`
+}
+
+// ContextValue is an action-context value: a literal of any scalar type
+// or a data-model path.
+type ContextValue struct {
+	Path           string   `json:"path,omitempty"`
+	LiteralString  string   `json:"literalString,omitempty"`
+	LiteralNumber  *float64 `json:"literalNumber,omitempty"`
+	LiteralBoolean *bool    `json:"literalBoolean,omitempty"`
+}
+
+// TabItem is one tab of a Tabs component.
+type TabItem struct {
+	Title StringValue `json:"title"`
+	Child string      `json:"child"`
+}
+
+// ChoiceOption is one selectable option of a MultipleChoice component.
+type ChoiceOption struct {
+	Label StringValue `json:"label"`
+	Value string      `json:"value"`
+}
+
+// AudioPlayerProps are the properties of the AudioPlayer component.
+type AudioPlayerProps struct {
+	URL         StringValue  `json:"url"`
+	Description *StringValue `json:"description,omitempty"`
+}
+
+// ButtonProps are the properties of the Button component.
+type ButtonProps struct {
+	Child   string `json:"child"`
+	Primary bool   `json:"primary,omitempty"`
+	Action  Action `json:"action"`
+}
+
+// CardProps are the properties of the Card component.
+type CardProps struct {
+	Child string `json:"child"`
+}
+
+// CheckBoxProps are the properties of the CheckBox component.
+type CheckBoxProps struct {
+	Label StringValue  `json:"label"`
+	Value BooleanValue `json:"value"`
+}
+
+// ColumnProps are the properties of the Column component.
+type ColumnProps struct {
+	Children     Children `json:"children"`
+	Distribution string   `json:"distribution,omitempty"`
+	Alignment    string   `json:"alignment,omitempty"`
+}
+
+// DateTimeInputProps are the properties of the DateTimeInput component.
+type DateTimeInputProps struct {
+	Value      StringValue `json:"value"`
+	EnableDate *bool       `json:"enableDate,omitempty"`
+	EnableTime *bool       `json:"enableTime,omitempty"`
+}
+
+// DividerProps are the properties of the Divider component.
+type DividerProps struct {
+	Axis string `json:"axis,omitempty"`
+}
+
+// IconProps are the properties of the Icon component.
+type IconProps struct {
+	Name StringValue `json:"name"`
+}
+
+// ImageProps are the properties of the Image component.
+type ImageProps struct {
+	URL       StringValue `json:"url"`
+	Fit       string      `json:"fit,omitempty"`
+	UsageHint string      `json:"usageHint,omitempty"`
+}
+
+// ListProps are the properties of the List component.
+type ListProps struct {
+	Children  Children `json:"children"`
+	Direction string   `json:"direction,omitempty"`
+	Alignment string   `json:"alignment,omitempty"`
+}
+
+// ModalProps are the properties of the Modal component.
+type ModalProps struct {
+	EntryPointChild string `json:"entryPointChild"`
+	ContentChild    string `json:"contentChild"`
+}
+
+// MultipleChoiceProps are the properties of the MultipleChoice component.
+type MultipleChoiceProps struct {
+	Selections           ArrayValue     `json:"selections"`
+	Options              []ChoiceOption `json:"options"`
+	MaxAllowedSelections *int           `json:"maxAllowedSelections,omitempty"`
+	Variant              string         `json:"variant,omitempty"`
+	Filterable           *bool          `json:"filterable,omitempty"`
+}
+
+// RowProps are the properties of the Row component.
+type RowProps struct {
+	Children     Children `json:"children"`
+	Distribution string   `json:"distribution,omitempty"`
+	Alignment    string   `json:"alignment,omitempty"`
+}
+
+// SliderProps are the properties of the Slider component.
+type SliderProps struct {
+	Value    NumberValue `json:"value"`
+	MinValue *float64    `json:"minValue,omitempty"`
+	MaxValue *float64    `json:"maxValue,omitempty"`
+}
+
+// TabsProps are the properties of the Tabs component.
+type TabsProps struct {
+	TabItems []TabItem `json:"tabItems"`
+}
+
+// TextProps are the properties of the Text component.
+type TextProps struct {
+	Text      StringValue `json:"text"`
+	UsageHint string      `json:"usageHint,omitempty"`
+}
+
+// TextFieldProps are the properties of the TextField component.
+type TextFieldProps struct {
+	Label            StringValue  `json:"label"`
+	Text             *StringValue `json:"text,omitempty"`
+	TextFieldType    string       `json:"textFieldType,omitempty"`
+	ValidationRegexp string       `json:"validationRegexp,omitempty"`
+}
+
+// VideoProps are the properties of the Video component.
+type VideoProps struct {
+	URL StringValue `json:"url"`
+}
+
+// componentProps maps each component type name to the zero value of its
+// props struct, the hook the drift test and generic tooling reflect
+// over.
+var componentProps = map[string]any{
+	ComponentAudioPlayer:    AudioPlayerProps{},
+	ComponentButton:         ButtonProps{},
+	ComponentCard:           CardProps{},
+	ComponentCheckBox:       CheckBoxProps{},
+	ComponentColumn:         ColumnProps{},
+	ComponentDateTimeInput:  DateTimeInputProps{},
+	ComponentDivider:        DividerProps{},
+	ComponentIcon:           IconProps{},
+	ComponentImage:          ImageProps{},
+	ComponentList:           ListProps{},
+	ComponentModal:          ModalProps{},
+	ComponentMultipleChoice: MultipleChoiceProps{},
+	ComponentRow:            RowProps{},
+	ComponentSlider:         SliderProps{},
+	ComponentTabs:           TabsProps{},
+	ComponentText:           TextProps{},
+	ComponentTextField:      TextFieldProps{},
+	ComponentVideo:          VideoProps{},
+}
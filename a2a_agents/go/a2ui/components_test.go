@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+// jsonFieldNames lists a struct's json tag names.
+func jsonFieldNames(v any) map[string]bool {
+	names := map[string]bool{}
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// TestComponentDefinitionsMatchCatalog pins the typed definitions to the
+// bundled catalog schema: every component has a props struct, every
+// struct field exists in the schema and vice versa. A catalog update
+// that adds or renames anything fails here until the types follow.
+func TestComponentDefinitionsMatchCatalog(t *testing.T) {
+	catalog, err := StandardCatalog()
+	if err != nil {
+		t.Fatalf("StandardCatalog: %v", err)
+	}
+	for _, name := range StandardComponentNames {
+		if _, ok := catalog.Components[name]; !ok {
+			t.Errorf("component %s is not in the catalog", name)
+		}
+	}
+	for name, raw := range catalog.Components {
+		props, ok := componentProps[name]
+		if !ok {
+			t.Errorf("catalog component %s has no props struct", name)
+			continue
+		}
+		schema, _ := raw.(map[string]any)
+		schemaProps, _ := schema["properties"].(map[string]any)
+		structFields := jsonFieldNames(props)
+		for field := range structFields {
+			if _, ok := schemaProps[field]; !ok {
+				t.Errorf("%s props field %q is not in the catalog schema", name, field)
+			}
+		}
+		for prop := range schemaProps {
+			if !structFields[prop] {
+				t.Errorf("%s schema property %q has no struct field", name, prop)
+			}
+		}
+	}
+}
+
+// TestComponentPropsValidate round-trips struct-built props through JSON
+// and the component's own schema, so the wire shape of the shared value
+// types stays correct.
+func TestComponentPropsValidate(t *testing.T) {
+	primary := true
+	samples := map[string]any{
+		ComponentText: TextProps{
+			Text:      StringValue{Path: "/doc/title"},
+			UsageHint: "h1",
+		},
+		ComponentButton: ButtonProps{
+			Child:   "label",
+			Primary: primary,
+			Action: Action{Name: "submit", Context: []ActionContextItem{
+				{Key: "name", Value: ContextValue{Path: "/form/name"}},
+			}},
+		},
+		ComponentColumn: ColumnProps{
+			Children: Children{Template: &ChildTemplate{ComponentID: "row", DataBinding: "/rows"}},
+		},
+		ComponentTabs: TabsProps{
+			TabItems: []TabItem{{Title: StringValue{LiteralString: "Sales"}, Child: "sales"}},
+		},
+	}
+	catalog, err := StandardCatalog()
+	if err != nil {
+		t.Fatalf("StandardCatalog: %v", err)
+	}
+	for name, props := range samples {
+		schema, err := jsonschema.Compile(catalog.Components[name].(map[string]any))
+		if err != nil {
+			t.Fatalf("compiling %s schema: %v", name, err)
+		}
+		raw, err := json.Marshal(props)
+		if err != nil {
+			t.Fatalf("marshaling %s props: %v", name, err)
+		}
+		var decoded map[string]any
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			t.Fatalf("decoding %s props: %v", name, err)
+		}
+		if err := schema.Validate(decoded); err != nil {
+			t.Errorf("%s props do not validate: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// CompressionKey is the capabilities key listing the content codings the
+// client can decompress, in preference order, e.g. ["zstd", "gzip"].
+const CompressionKey = "compression"
+
+// ContentEncodingKey is the part metadata key flagging a compressed A2UI
+// DataPart with the coding applied to its payload.
+const ContentEncodingKey = "contentEncoding"
+
+// compressedPayloadKey is the data key carrying the compressed payload,
+// base64-encoded, inside a flagged part.
+const compressedPayloadKey = "compressedPayload"
+
+// Content codings for ContentEncodingKey and CompressionKey.
+const (
+	CodingGzip = "gzip"
+	CodingZstd = "zstd"
+)
+
+// Compressor implements one content coding for A2UI payloads.
+type Compressor interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// compressors holds the registered codings. Gzip ships built in; zstd is
+// available once a deployment registers an implementation.
+var compressors = map[string]Compressor{CodingGzip: gzipCompressor{}}
+
+// RegisterCompressor makes a coding available for negotiation, e.g. a
+// zstd implementation under CodingZstd. It must be called before
+// requests are served.
+func RegisterCompressor(coding string, c Compressor) {
+	compressors[coding] = c
+}
+
+// ClientCompression returns the content codings the client accepts, in
+// its preference order, from the message's capabilities.
+func ClientCompression(msg *a2a.Message) []string {
+	raw, _ := ClientCapabilities(msg)[CompressionKey].([]any)
+	var codings []string
+	for _, entry := range raw {
+		if coding, ok := entry.(string); ok {
+			codings = append(codings, coding)
+		}
+	}
+	return codings
+}
+
+// NegotiateCompression picks the client's most preferred coding this
+// agent also implements, or "" when the sides share none — in which case
+// parts go out uncompressed.
+func NegotiateCompression(msg *a2a.Message) string {
+	for _, coding := range ClientCompression(msg) {
+		if _, ok := compressors[coding]; ok {
+			return coding
+		}
+	}
+	return ""
+}
+
+// CompressPart returns the part with its A2UI payload compressed under
+// the negotiated coding, flagged in the part metadata, when the encoded
+// payload is at least minSize bytes — headers cost more than they save
+// on small payloads. Parts that are not A2UI data, already-compressed
+// parts and coding "" pass through unchanged.
+func CompressPart(part a2a.Part, coding string, minSize int) (a2a.Part, error) {
+	if coding == "" || !IsA2UIPart(part) || part.Metadata[ContentEncodingKey] != nil {
+		return part, nil
+	}
+	compressor, ok := compressors[coding]
+	if !ok {
+		return a2a.Part{}, fmt.Errorf("a2ui: no compressor registered for coding %q", coding)
+	}
+	payload := []byte(part.RawData)
+	if len(payload) == 0 {
+		var err error
+		if payload, err = json.Marshal(part.Data); err != nil {
+			return a2a.Part{}, fmt.Errorf("a2ui: encoding payload for compression: %w", err)
+		}
+	}
+	if len(payload) < minSize {
+		return part, nil
+	}
+	compressed, err := compressor.Compress(payload)
+	if err != nil {
+		return a2a.Part{}, fmt.Errorf("a2ui: compressing payload with %s: %w", coding, err)
+	}
+	metadata := make(map[string]any, len(part.Metadata)+1)
+	for key, value := range part.Metadata {
+		metadata[key] = value
+	}
+	metadata[ContentEncodingKey] = coding
+	return a2a.NewDataPart(map[string]any{
+		compressedPayloadKey: base64.StdEncoding.EncodeToString(compressed),
+	}, metadata), nil
+}
+
+// DecompressPart reverses CompressPart, restoring the plain A2UI part a
+// flagged part carries. Unflagged parts pass through unchanged, so a
+// receiver can run every incoming part through it.
+func DecompressPart(part a2a.Part) (a2a.Part, error) {
+	if part.Metadata == nil || part.Metadata[ContentEncodingKey] == nil {
+		return part, nil
+	}
+	coding, _ := part.Metadata[ContentEncodingKey].(string)
+	compressor, ok := compressors[coding]
+	if !ok {
+		return a2a.Part{}, fmt.Errorf("a2ui: no compressor registered for coding %q", coding)
+	}
+	encoded, _ := part.Data[compressedPayloadKey].(string)
+	if encoded == "" {
+		return a2a.Part{}, fmt.Errorf("a2ui: part flagged %s carries no compressed payload", coding)
+	}
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return a2a.Part{}, fmt.Errorf("a2ui: decoding compressed payload: %w", err)
+	}
+	payload, err := compressor.Decompress(compressed)
+	if err != nil {
+		return a2a.Part{}, fmt.Errorf("a2ui: decompressing %s payload: %w", coding, err)
+	}
+	metadata := make(map[string]any, len(part.Metadata)-1)
+	for key, value := range part.Metadata {
+		if key != ContentEncodingKey {
+			metadata[key] = value
+		}
+	}
+	return a2a.NewRawDataPart(payload, metadata), nil
+}
+
+// gzipCompressor is the built-in gzip coding.
+type gzipCompressor struct{}
+
+func (gzipCompressor) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCompressor) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
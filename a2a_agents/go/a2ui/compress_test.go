@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func compressionMessage(codings ...any) *a2a.Message {
+	return &a2a.Message{
+		MessageID: "m1",
+		Role:      a2a.RoleUser,
+		Metadata: map[string]any{
+			ClientCapabilitiesKey: map[string]any{CompressionKey: codings},
+		},
+	}
+}
+
+func TestNegotiateCompression(t *testing.T) {
+	if got := NegotiateCompression(compressionMessage("gzip")); got != CodingGzip {
+		t.Errorf("negotiated %q, want gzip", got)
+	}
+	// The client's preference order wins among codings both sides have.
+	if got := NegotiateCompression(compressionMessage("zstd", "gzip")); got != CodingGzip {
+		t.Errorf("negotiated %q, want gzip (zstd unregistered)", got)
+	}
+	if got := NegotiateCompression(compressionMessage()); got != "" {
+		t.Errorf("negotiated %q for a client without compression", got)
+	}
+	if got := NegotiateCompression(&a2a.Message{MessageID: "m1"}); got != "" {
+		t.Errorf("negotiated %q for a client without capabilities", got)
+	}
+}
+
+func TestCompressPartRoundTrip(t *testing.T) {
+	payload := map[string]any{
+		"surfaceUpdate": map[string]any{
+			"surfaceId":  "main",
+			"components": strings.Repeat("x", 2048),
+		},
+	}
+	part := NewPart(payload)
+
+	compressed, err := CompressPart(part, CodingGzip, 256)
+	if err != nil {
+		t.Fatalf("CompressPart: %v", err)
+	}
+	if compressed.Metadata[ContentEncodingKey] != CodingGzip {
+		t.Fatalf("compressed part not flagged: %v", compressed.Metadata)
+	}
+	if compressed.Metadata[MIMETypeKey] != MIMEType {
+		t.Error("compression dropped the A2UI MIME type")
+	}
+
+	plain, err := DecompressPart(compressed)
+	if err != nil {
+		t.Fatalf("DecompressPart: %v", err)
+	}
+	if plain.Metadata[ContentEncodingKey] != nil {
+		t.Error("decompressed part still flagged")
+	}
+	if !reflect.DeepEqual(Data(plain), payload) {
+		t.Error("payload did not survive the round trip")
+	}
+}
+
+func TestCompressPartPassThrough(t *testing.T) {
+	small := NewPart(map[string]any{"beginRendering": map[string]any{"surfaceId": "main"}})
+
+	// Below the size threshold nothing changes.
+	part, err := CompressPart(small, CodingGzip, 1<<20)
+	if err != nil {
+		t.Fatalf("CompressPart: %v", err)
+	}
+	if part.Metadata[ContentEncodingKey] != nil {
+		t.Error("part under the threshold was compressed")
+	}
+
+	// No negotiated coding, non-A2UI parts and unflagged parts pass through.
+	if part, _ := CompressPart(small, "", 0); part.Metadata[ContentEncodingKey] != nil {
+		t.Error("part compressed without a negotiated coding")
+	}
+	text := a2a.NewTextPart("hi")
+	if part, _ := CompressPart(text, CodingGzip, 0); !reflect.DeepEqual(part, text) {
+		t.Error("text part did not pass through compression")
+	}
+	if part, _ := DecompressPart(text); !reflect.DeepEqual(part, text) {
+		t.Error("text part did not pass through decompression")
+	}
+
+	// An unregistered coding is an error, not silent corruption.
+	if _, err := CompressPart(small, CodingZstd, 0); err == nil {
+		t.Error("unregistered coding compressed without error")
+	}
+}
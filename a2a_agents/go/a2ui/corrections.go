@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+// maxCorrections bounds how many findings are rendered; beyond it the
+// model is usually better off regenerating than patching.
+const maxCorrections = 8
+
+// RenderCorrections converts schema validation errors into short,
+// imperative correction instructions for the model's retry loop, naming
+// the offending component where the payload makes that possible. Errors
+// that are not jsonschema.ValidationErrors render as their plain text.
+func RenderCorrections(err error, messages []map[string]any) string {
+	var verrs jsonschema.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err.Error()
+	}
+	var lines []string
+	for i, verr := range verrs {
+		if i == maxCorrections {
+			lines = append(lines, fmt.Sprintf("...and %d more problems; regenerate the payload", len(verrs)-i))
+			break
+		}
+		lines = append(lines, correction(verr, messages))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// correction renders one finding as an imperative instruction.
+func correction(verr *jsonschema.ValidationError, messages []map[string]any) string {
+	loc := locate(verr.InstancePath, messages)
+	msg := verr.Message
+	switch {
+	case strings.HasPrefix(msg, "missing required property"):
+		name := quoted(msg)
+		return fmt.Sprintf("%s is missing required property %q; add it", loc, name)
+	case msg == "unexpected property":
+		prop := verr.InstancePath[strings.LastIndex(verr.InstancePath, "/")+1:]
+		return fmt.Sprintf("%s has unknown property %q; remove it", loc, prop)
+	case strings.HasPrefix(msg, "expected type"):
+		return fmt.Sprintf("%s has the wrong type; %s — change the value", loc, msg)
+	case strings.HasPrefix(msg, "value is not one of"):
+		return fmt.Sprintf("%s uses an invalid value; %s — pick one of the allowed values", loc, msg)
+	default:
+		return fmt.Sprintf("%s: %s — fix this", loc, msg)
+	}
+}
+
+// locate names the payload location for an instance path, preferring the
+// enclosing component's ID and type over the raw JSON pointer.
+func locate(instancePath string, messages []map[string]any) string {
+	segments := strings.Split(strings.TrimPrefix(instancePath, "/"), "/")
+	if len(segments) == 0 || segments[0] == "" {
+		return "the payload"
+	}
+	index, err := strconv.Atoi(segments[0])
+	if err != nil || index < 0 || index >= len(messages) {
+		return "the value at " + instancePath
+	}
+
+	// Walk into the message looking for the innermost component wrapper.
+	var node any = messages[index]
+	componentID, componentType := "", ""
+	for _, segment := range segments[1:] {
+		switch current := node.(type) {
+		case map[string]any:
+			if id, ok := current["id"].(string); ok {
+				if component, ok := current["component"].(map[string]any); ok && len(component) == 1 {
+					componentID = id
+					for name := range component {
+						componentType = name
+					}
+				}
+			}
+			node = current[segment]
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(current) {
+				node = nil
+				continue
+			}
+			node = current[i]
+		default:
+			node = nil
+		}
+	}
+	if componentID != "" {
+		return fmt.Sprintf("component %q (%s)", componentID, componentType)
+	}
+	kind := messageKind(messages[index])
+	if kind != "" {
+		return fmt.Sprintf("message %d (%s)", index, kind)
+	}
+	return "the value at " + instancePath
+}
+
+// messageKind returns a message's single top-level key, e.g.
+// "surfaceUpdate".
+func messageKind(msg map[string]any) string {
+	if len(msg) != 1 {
+		return ""
+	}
+	for key := range msg {
+		return key
+	}
+	return ""
+}
+
+// quoted extracts the first double-quoted token from a message.
+func quoted(msg string) string {
+	start := strings.Index(msg, `"`)
+	if start < 0 {
+		return ""
+	}
+	end := strings.Index(msg[start+1:], `"`)
+	if end < 0 {
+		return ""
+	}
+	return msg[start+1 : start+1+end]
+}
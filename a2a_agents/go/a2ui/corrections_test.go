@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+func TestRenderCorrections(t *testing.T) {
+	messages := []map[string]any{
+		{"surfaceUpdate": map[string]any{"surfaceId": "s1", "components": []any{
+			map[string]any{"id": "pie1", "component": map[string]any{"Chart": map[string]any{
+				"type": "pie",
+			}}},
+		}}},
+	}
+	verrs := jsonschema.ValidationErrors{
+		{InstancePath: "/0/surfaceUpdate/components/0/component/Chart", Message: `missing required property "dataRef"`},
+		{InstancePath: "/0/surfaceUpdate/components/0/component/Chart/bogus", Message: "unexpected property"},
+		{InstancePath: "/0/surfaceId", Message: "expected type string, got number"},
+	}
+
+	out := RenderCorrections(verrs, messages)
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines:\n%s", len(lines), out)
+	}
+	if !strings.Contains(lines[0], `component "pie1" (Chart)`) || !strings.Contains(lines[0], `"dataRef"; add it`) {
+		t.Errorf("missing-property line = %q", lines[0])
+	}
+	if !strings.Contains(lines[1], `"bogus"; remove it`) {
+		t.Errorf("unexpected-property line = %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "message 0 (surfaceUpdate)") || !strings.Contains(lines[2], "change the value") {
+		t.Errorf("type line = %q", lines[2])
+	}
+}
+
+func TestRenderCorrectionsCapsFindings(t *testing.T) {
+	var verrs jsonschema.ValidationErrors
+	for i := 0; i < maxCorrections+3; i++ {
+		verrs = append(verrs, &jsonschema.ValidationError{
+			InstancePath: fmt.Sprintf("/%d", i),
+			Message:      "expected type object, got string",
+		})
+	}
+	out := RenderCorrections(verrs, nil)
+	if got := len(strings.Split(out, "\n")); got != maxCorrections+1 {
+		t.Errorf("got %d lines, want cap plus summary:\n%s", got, out)
+	}
+	if !strings.Contains(out, "3 more problems") {
+		t.Errorf("summary line missing:\n%s", out)
+	}
+}
+
+func TestRenderCorrectionsPlainError(t *testing.T) {
+	if got := RenderCorrections(errors.New("boom"), nil); got != "boom" {
+		t.Errorf("plain error = %q", got)
+	}
+}
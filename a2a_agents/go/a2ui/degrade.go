@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// standardCatalogV08 is the standard catalog definition for A2UI v0.8,
+// bundled alongside the base schema.
+//
+//go:embed spec/v0_8/standard_catalog_definition.json
+var standardCatalogV08 []byte
+
+// StandardCatalog returns the bundled standard component catalog.
+func StandardCatalog() (*Catalog, error) {
+	var definition struct {
+		Components map[string]any `json:"components"`
+	}
+	if err := json.Unmarshal(standardCatalogV08, &definition); err != nil {
+		return nil, fmt.Errorf("a2ui: parsing standard catalog: %w", err)
+	}
+	return &Catalog{ID: StandardCatalogID, Components: definition.Components}, nil
+}
+
+// standardComponentNames lazily indexes the standard catalog's component
+// type names.
+var standardComponentNames = sync.OnceValue(func() map[string]bool {
+	names := map[string]bool{}
+	catalog, err := StandardCatalog()
+	if err != nil {
+		return names
+	}
+	for name := range catalog.Components {
+		names[name] = true
+	}
+	return names
+})
+
+// IsStandardComponent reports whether the type name belongs to the
+// standard catalog.
+func IsStandardComponent(name string) bool {
+	return standardComponentNames()[name]
+}
+
+// ComponentFallback rewrites a custom component's properties into a
+// standard-catalog equivalent, returning the standard type name and its
+// properties.
+type ComponentFallback func(props map[string]any) (string, map[string]any)
+
+// FallbackMap maps custom component type names to their standard-catalog
+// fallbacks.
+type FallbackMap map[string]ComponentFallback
+
+// DegradeToStandard rewrites messages so they only use standard-catalog
+// components, for clients whose negotiation fell back to the standard
+// catalog. Custom components are mapped through fallbacks; those without
+// a fallback become a Text placeholder naming the unsupported component.
+// The input is not modified.
+func DegradeToStandard(messages []map[string]any, fallbacks FallbackMap) ([]map[string]any, error) {
+	copied, err := deepCopyMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range copied {
+		update, _ := msg["surfaceUpdate"].(map[string]any)
+		if update == nil {
+			continue
+		}
+		components, _ := update["components"].([]any)
+		for _, entry := range components {
+			wrapper, _ := entry.(map[string]any)
+			component, _ := wrapper["component"].(map[string]any)
+			if len(component) != 1 {
+				continue
+			}
+			for name, value := range component {
+				if IsStandardComponent(name) {
+					continue
+				}
+				props, _ := value.(map[string]any)
+				delete(component, name)
+				if fallback, ok := fallbacks[name]; ok {
+					standardName, standardProps := fallback(props)
+					component[standardName] = standardProps
+				} else {
+					component["Text"] = map[string]any{
+						"text": map[string]any{
+							"literalString": fmt.Sprintf("This client cannot display %s content.", name),
+						},
+					}
+				}
+			}
+		}
+	}
+	return copied, nil
+}
+
+// deepCopyMessages copies messages through a JSON round-trip so callers
+// keep the original payload intact.
+func deepCopyMessages(messages []map[string]any) ([]map[string]any, error) {
+	raw, err := json.Marshal(messages)
+	if err != nil {
+		return nil, fmt.Errorf("a2ui: copying messages: %w", err)
+	}
+	var copied []map[string]any
+	if err := json.Unmarshal(raw, &copied); err != nil {
+		return nil, fmt.Errorf("a2ui: copying messages: %w", err)
+	}
+	return copied, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStandardCatalog(t *testing.T) {
+	catalog, err := StandardCatalog()
+	if err != nil {
+		t.Fatalf("StandardCatalog: %v", err)
+	}
+	if catalog.ID != StandardCatalogID {
+		t.Errorf("ID = %q", catalog.ID)
+	}
+	for _, name := range []string{"Text", "Row", "Column", "Card"} {
+		if !IsStandardComponent(name) {
+			t.Errorf("IsStandardComponent(%s) = false", name)
+		}
+	}
+	if IsStandardComponent("Chart") {
+		t.Error("Chart reported as standard")
+	}
+}
+
+func TestDegradeToStandard(t *testing.T) {
+	messages := []map[string]any{
+		{"surfaceUpdate": map[string]any{"surfaceId": "s1", "components": []any{
+			map[string]any{"id": "title", "component": map[string]any{"Text": map[string]any{
+				"text": map[string]any{"literalString": "Sales"},
+			}}},
+			map[string]any{"id": "chart", "component": map[string]any{"Chart": map[string]any{
+				"title": map[string]any{"literalString": "Sales by category"},
+			}}},
+			map[string]any{"id": "map", "component": map[string]any{"GoogleMap": map[string]any{}}},
+		}}},
+	}
+	fallbacks := FallbackMap{
+		"Chart": func(props map[string]any) (string, map[string]any) {
+			return "Text", map[string]any{"text": props["title"]}
+		},
+	}
+
+	degraded, err := DegradeToStandard(messages, fallbacks)
+	if err != nil {
+		t.Fatalf("DegradeToStandard: %v", err)
+	}
+	components := degraded[0]["surfaceUpdate"].(map[string]any)["components"].([]any)
+
+	// The standard component is untouched.
+	if _, ok := components[0].(map[string]any)["component"].(map[string]any)["Text"]; !ok {
+		t.Error("standard Text component was rewritten")
+	}
+	// Chart went through its fallback.
+	chart := components[1].(map[string]any)["component"].(map[string]any)
+	if text, ok := chart["Text"].(map[string]any); !ok {
+		t.Errorf("Chart not degraded: %v", chart)
+	} else if binding, _ := text["text"].(map[string]any); binding["literalString"] != "Sales by category" {
+		t.Errorf("fallback lost props: %v", text)
+	}
+	// GoogleMap has no fallback and became a placeholder.
+	googleMap := components[2].(map[string]any)["component"].(map[string]any)
+	text, ok := googleMap["Text"].(map[string]any)
+	if !ok {
+		t.Fatalf("GoogleMap not degraded: %v", googleMap)
+	}
+	binding, _ := text["text"].(map[string]any)
+	if s, _ := binding["literalString"].(string); !strings.Contains(s, "GoogleMap") {
+		t.Errorf("placeholder text = %v", binding)
+	}
+
+	// The original payload is untouched.
+	original := messages[0]["surfaceUpdate"].(map[string]any)["components"].([]any)
+	if _, ok := original[1].(map[string]any)["component"].(map[string]any)["Chart"]; !ok {
+		t.Error("DegradeToStandard modified its input")
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// DiffDataModel compares two snapshots of a surface's data model —
+// string-keyed trees with scalar leaves — and returns the merge-patch
+// style dataModelUpdate messages that bring a client holding old to new,
+// touching only the paths that changed. A nil old emits the full model.
+// Updates come out in sorted path order.
+//
+// Deleted keys cannot be expressed as a value write, so a map that lost
+// a key is rewritten wholesale at its own path; deleting a top-level key
+// is an error, as is a wholesale rewrite of a map that nests other maps.
+func DiffDataModel(surfaceID string, old, new map[string]any) ([]map[string]any, error) {
+	var updates []map[string]any
+	if err := diffMap(surfaceID, "", old, new, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// diffMap appends the updates converging one subtree, rooted at path
+// ("" for the model root), onto out.
+func diffMap(surfaceID, path string, old, new map[string]any, out *[]map[string]any) error {
+	for key := range old {
+		if _, ok := new[key]; ok {
+			continue
+		}
+		if path == "" {
+			return fmt.Errorf("a2ui: data model dropped top-level key %q; deletions below the root cannot be expressed as a dataModelUpdate", key)
+		}
+		update, err := dataModelUpdate(surfaceID, path, new)
+		if err != nil {
+			return err
+		}
+		*out = append(*out, update)
+		return nil
+	}
+
+	keys := make([]string, 0, len(new))
+	for key := range new {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		childPath := path + "/" + key
+		oldValue, had := old[key]
+		if newMap, ok := new[key].(map[string]any); ok {
+			// Descend so only the changed leaves are written; a map that
+			// replaced a scalar diffs against nothing and emits in full.
+			oldMap, _ := oldValue.(map[string]any)
+			if err := diffMap(surfaceID, childPath, oldMap, newMap, out); err != nil {
+				return err
+			}
+			continue
+		}
+		if had && reflect.DeepEqual(oldValue, new[key]) {
+			continue
+		}
+		update, err := dataModelUpdate(surfaceID, childPath, new[key])
+		if err != nil {
+			return err
+		}
+		*out = append(*out, update)
+	}
+	return nil
+}
+
+// copyDataModel deep-copies a snapshot, so later caller mutations do not
+// corrupt the baseline the next diff runs against.
+func copyDataModel(model map[string]any) map[string]any {
+	if model == nil {
+		return nil
+	}
+	out := make(map[string]any, len(model))
+	for key, value := range model {
+		if nested, ok := value.(map[string]any); ok {
+			out[key] = copyDataModel(nested)
+			continue
+		}
+		out[key] = value
+	}
+	return out
+}
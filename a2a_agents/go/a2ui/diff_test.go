@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"strings"
+	"testing"
+)
+
+// updatePaths flattens updates to "parent/key" strings for assertions.
+func updatePaths(t *testing.T, updates []map[string]any) []string {
+	t.Helper()
+	var paths []string
+	for _, msg := range updates {
+		update := msg["dataModelUpdate"].(map[string]any)
+		parent := update["path"].(string)
+		for _, raw := range update["contents"].([]any) {
+			entry := raw.(map[string]any)
+			paths = append(paths, strings.TrimSuffix(parent, "/")+"/"+entry["key"].(string))
+		}
+	}
+	return paths
+}
+
+func TestDiffDataModelChangedPathsOnly(t *testing.T) {
+	old := map[string]any{
+		"title": "Q2 sales",
+		"form":  map[string]any{"filter": "last_7_days", "expanded": true},
+		"sales": map[string]any{"apparel": 100.0, "shoes": 40.0},
+	}
+	new := map[string]any{
+		"title": "Q2 sales",
+		"form":  map[string]any{"filter": "last_30_days", "expanded": true},
+		"sales": map[string]any{"apparel": 120.0, "shoes": 40.0},
+	}
+	updates, err := DiffDataModel("main", old, new)
+	if err != nil {
+		t.Fatalf("DiffDataModel: %v", err)
+	}
+	got := updatePaths(t, updates)
+	want := []string{"/form/filter", "/sales/apparel"}
+	if len(got) != len(want) {
+		t.Fatalf("updated paths = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("updated paths = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestDiffDataModelFullOnFirstSnapshot(t *testing.T) {
+	updates, err := DiffDataModel("main", nil, map[string]any{
+		"title": "Q2 sales",
+		"form":  map[string]any{"filter": "last_7_days"},
+	})
+	if err != nil {
+		t.Fatalf("DiffDataModel: %v", err)
+	}
+	got := updatePaths(t, updates)
+	if len(got) != 2 || got[0] != "/form/filter" || got[1] != "/title" {
+		t.Errorf("updated paths = %v, want the full model", got)
+	}
+}
+
+func TestDiffDataModelDeletionRewritesMap(t *testing.T) {
+	old := map[string]any{"form": map[string]any{"filter": "all", "expanded": true}}
+	new := map[string]any{"form": map[string]any{"filter": "all"}}
+	updates, err := DiffDataModel("main", old, new)
+	if err != nil {
+		t.Fatalf("DiffDataModel: %v", err)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("got %d updates, want 1 rewriting the map: %v", len(updates), updates)
+	}
+	update := updates[0]["dataModelUpdate"].(map[string]any)
+	if update["path"] != "/" {
+		t.Errorf("rewrite path = %v, want /", update["path"])
+	}
+
+	// Dropping a top-level key cannot be expressed as an update.
+	if _, err := DiffDataModel("main", new, map[string]any{}); err == nil {
+		t.Error("top-level deletion did not error")
+	}
+}
+
+func TestSurfaceManagerSetDataModel(t *testing.T) {
+	manager := NewSurfaceManager(0)
+	first, err := manager.SetDataModel("main", map[string]any{
+		"sales": map[string]any{"apparel": 100.0},
+	})
+	if err != nil {
+		t.Fatalf("SetDataModel: %v", err)
+	}
+	if len(first) != 1 {
+		t.Fatalf("first snapshot emitted %d updates, want the full model", len(first))
+	}
+
+	second, err := manager.SetDataModel("main", map[string]any{
+		"sales": map[string]any{"apparel": 120.0},
+	})
+	if err != nil {
+		t.Fatalf("SetDataModel: %v", err)
+	}
+	got := updatePaths(t, second)
+	if len(got) != 1 || got[0] != "/sales/apparel" {
+		t.Errorf("updated paths = %v, want [/sales/apparel]", got)
+	}
+
+	// An identical snapshot produces nothing to send.
+	third, err := manager.SetDataModel("main", map[string]any{
+		"sales": map[string]any{"apparel": 120.0},
+	})
+	if err != nil {
+		t.Fatalf("SetDataModel: %v", err)
+	}
+	if len(third) != 0 {
+		t.Errorf("unchanged snapshot emitted %v", third)
+	}
+}
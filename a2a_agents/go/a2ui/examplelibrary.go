@@ -0,0 +1,182 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+// Example is one bundled few-shot example payload.
+type Example struct {
+	// Name is the file stem, e.g. "chart" for examples/chart.json.
+	Name string
+	// Path is the file the example was loaded from.
+	Path string
+	// Tags index the example for selection: the tokens of the file stem and
+	// its parent directories under the library root. examples/maps/store_map.json
+	// carries the tags "maps", "store" and "map".
+	Tags []string
+	// Payload is the validated A2UI message array.
+	Payload json.RawMessage
+}
+
+// ExampleLibrary holds the validated examples for one catalog, indexed by
+// name and tag, so instruction builders can pick examples without
+// hard-coding file paths.
+type ExampleLibrary struct {
+	catalog  *Catalog
+	examples []*Example
+	byName   map[string]*Example
+	byTag    map[string][]*Example
+}
+
+// LoadExampleLibrary loads every *.json file under dir (recursively) as an
+// example for the catalog, validating each against the catalog's merged
+// schema. Loading fails with a report of every invalid file, so a stale
+// example stops the agent at startup.
+func LoadExampleLibrary(dir string, catalog *Catalog) (*ExampleLibrary, error) {
+	return loadExampleLibrary(os.DirFS(dir), dir, catalog)
+}
+
+// LoadExampleLibraryFS is LoadExampleLibrary over any fs.FS — typically a
+// go:embed filesystem — so an agent's examples travel inside its binary
+// and resolve by logical name from any working directory. root names the
+// directory within fsys holding the examples; "." reads the whole
+// filesystem.
+func LoadExampleLibraryFS(fsys fs.FS, root string, catalog *Catalog) (*ExampleLibrary, error) {
+	if root != "" && root != "." {
+		sub, err := fs.Sub(fsys, root)
+		if err != nil {
+			return nil, fmt.Errorf("a2ui: reading examples under %s: %w", root, err)
+		}
+		fsys = sub
+	}
+	return loadExampleLibrary(fsys, root, catalog)
+}
+
+// loadExampleLibrary walks fsys from its root, using label only to prefix
+// paths in reports and Example.Path.
+func loadExampleLibrary(fsys fs.FS, label string, catalog *Catalog) (*ExampleLibrary, error) {
+	doc, err := LoadA2UISchema(catalog)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := WrapAsJSONArray(doc)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := jsonschema.Compile(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("a2ui: compiling schema for catalog %q: %w", catalogID(catalog), err)
+	}
+
+	lib := &ExampleLibrary{
+		catalog: catalog,
+		byName:  map[string]*Example{},
+		byTag:   map[string][]*Example{},
+	}
+	var report []string
+	err = fs.WalkDir(fsys, ".", func(rel string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			return nil
+		}
+		full := path.Join(label, rel)
+		raw, err := fs.ReadFile(fsys, rel)
+		if err != nil {
+			return err
+		}
+		payload, err := decodeExample(raw)
+		if err != nil {
+			report = append(report, fmt.Sprintf("%s: %v", full, err))
+			return nil
+		}
+		if err := schema.Validate(payload); err != nil {
+			report = append(report, fmt.Sprintf("%s (catalog %s): %v", full, catalogID(catalog), err))
+			return nil
+		}
+		normalized, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		example := &Example{
+			Name:    strings.TrimSuffix(entry.Name(), ".json"),
+			Path:    full,
+			Tags:    exampleTags(rel),
+			Payload: normalized,
+		}
+		lib.examples = append(lib.examples, example)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("a2ui: reading examples under %s: %w", label, err)
+	}
+	if len(report) > 0 {
+		sort.Strings(report)
+		return nil, fmt.Errorf("a2ui: %d example(s) under %s failed validation:\n%s",
+			len(report), label, strings.Join(report, "\n"))
+	}
+	sort.Slice(lib.examples, func(i, j int) bool { return lib.examples[i].Name < lib.examples[j].Name })
+	for _, example := range lib.examples {
+		lib.byName[example.Name] = example
+		for _, tag := range example.Tags {
+			lib.byTag[tag] = append(lib.byTag[tag], example)
+		}
+	}
+	return lib, nil
+}
+
+// Catalog returns the catalog the examples were validated against.
+func (l *ExampleLibrary) Catalog() *Catalog { return l.catalog }
+
+// Examples returns all examples, sorted by name.
+func (l *ExampleLibrary) Examples() []*Example { return l.examples }
+
+// Get returns the example with the given name, or nil.
+func (l *ExampleLibrary) Get(name string) *Example { return l.byName[name] }
+
+// WithTag returns the examples carrying the given tag, in name order.
+func (l *ExampleLibrary) WithTag(tag string) []*Example { return l.byTag[strings.ToLower(tag)] }
+
+// exampleTags derives index tags from an example's path relative to the
+// library root: each directory name and each token of the file stem.
+func exampleTags(rel string) []string {
+	rel = strings.TrimSuffix(filepath.ToSlash(rel), ".json")
+	seen := map[string]bool{}
+	var tags []string
+	for _, segment := range strings.Split(rel, "/") {
+		for _, tag := range strings.FieldsFunc(segment, func(r rune) bool {
+			return r == '_' || r == '-' || r == ' '
+		}) {
+			tag = strings.ToLower(tag)
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func writeExample(t *testing.T, dir, name, payload string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(payload), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadExampleLibrary(t *testing.T) {
+	dir := t.TempDir()
+	payload := `[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`
+	writeExample(t, dir, "chart.json", payload)
+	writeExample(t, dir, "maps/store_map.json", payload)
+
+	lib, err := LoadExampleLibrary(dir, nil)
+	if err != nil {
+		t.Fatalf("LoadExampleLibrary: %v", err)
+	}
+	if got := len(lib.Examples()); got != 2 {
+		t.Fatalf("got %d examples, want 2", got)
+	}
+	if lib.Get("chart") == nil {
+		t.Error("Get(chart) = nil")
+	}
+	storeMap := lib.Get("store_map")
+	if storeMap == nil {
+		t.Fatal("Get(store_map) = nil")
+	}
+	if want := []string{"maps", "store", "map"}; !reflect.DeepEqual(storeMap.Tags, want) {
+		t.Errorf("store_map tags = %v, want %v", storeMap.Tags, want)
+	}
+	if got := lib.WithTag("map"); len(got) != 1 || got[0] != storeMap {
+		t.Errorf("WithTag(map) = %v", got)
+	}
+	if got := lib.WithTag("nope"); len(got) != 0 {
+		t.Errorf("WithTag(nope) = %v", got)
+	}
+}
+
+func TestLoadExampleLibraryRejectsStaleExample(t *testing.T) {
+	dir := t.TempDir()
+	writeExample(t, dir, "stale.json", `[{"beginRendering": {"root": "root"}}]`)
+	if _, err := LoadExampleLibrary(dir, nil); err == nil {
+		t.Fatal("stale example did not fail the load")
+	}
+}
+
+func TestLoadExampleLibraryFS(t *testing.T) {
+	payload := `[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`
+	fsys := fstest.MapFS{
+		"examples/chart.json":          {Data: []byte(payload)},
+		"examples/maps/store_map.json": {Data: []byte(payload)},
+		"README.md":                    {Data: []byte("not an example")},
+	}
+	lib, err := LoadExampleLibraryFS(fsys, "examples", nil)
+	if err != nil {
+		t.Fatalf("LoadExampleLibraryFS: %v", err)
+	}
+	if got := len(lib.Examples()); got != 2 {
+		t.Fatalf("got %d examples, want 2", got)
+	}
+	storeMap := lib.Get("store_map")
+	if storeMap == nil {
+		t.Fatal("Get(store_map) = nil")
+	}
+	if want := []string{"maps", "store", "map"}; !reflect.DeepEqual(storeMap.Tags, want) {
+		t.Errorf("store_map tags = %v, want %v", storeMap.Tags, want)
+	}
+	if storeMap.Path != "examples/maps/store_map.json" {
+		t.Errorf("Path = %q, want the root-prefixed path", storeMap.Path)
+	}
+}
+
+func TestLoadExampleLibraryFSWholeFilesystem(t *testing.T) {
+	fsys := fstest.MapFS{
+		"chart.json": {Data: []byte(`[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`)},
+	}
+	lib, err := LoadExampleLibraryFS(fsys, ".", nil)
+	if err != nil {
+		t.Fatalf("LoadExampleLibraryFS: %v", err)
+	}
+	if lib.Get("chart") == nil {
+		t.Error("Get(chart) = nil")
+	}
+}
+
+func TestLoadExampleLibraryFSRejectsStaleExample(t *testing.T) {
+	fsys := fstest.MapFS{
+		"examples/stale.json": {Data: []byte(`[{"beginRendering": {"root": "root"}}]`)},
+	}
+	_, err := LoadExampleLibraryFS(fsys, "examples", nil)
+	if err == nil {
+		t.Fatal("stale example did not fail the load")
+	}
+	if !strings.Contains(err.Error(), "examples/stale.json") {
+		t.Errorf("report does not name the file: %v", err)
+	}
+}
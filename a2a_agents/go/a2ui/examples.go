@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+// ValidateExamplesDir validates every *.json file under dir (recursively)
+// against the merged schema of each catalog. It is meant to run at agent
+// startup so a stale example fails the process immediately with a precise
+// report, instead of exploding at request time when the example is inlined
+// into a prompt. The returned error lists every failing file and the
+// validation errors per catalog.
+func ValidateExamplesDir(dir string, catalogs []*Catalog) error {
+	if len(catalogs) == 0 {
+		catalogs = []*Catalog{nil}
+	}
+	type compiled struct {
+		id     string
+		schema *jsonschema.Schema
+	}
+	var schemas []compiled
+	for _, catalog := range catalogs {
+		doc, err := LoadA2UISchema(catalog)
+		if err != nil {
+			return err
+		}
+		wrapped, err := WrapAsJSONArray(doc)
+		if err != nil {
+			return err
+		}
+		schema, err := jsonschema.Compile(wrapped)
+		if err != nil {
+			return fmt.Errorf("a2ui: compiling schema for catalog %q: %w", catalogID(catalog), err)
+		}
+		schemas = append(schemas, compiled{id: catalogID(catalog), schema: schema})
+	}
+
+	var report []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			return nil
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		payload, err := decodeExample(raw)
+		if err != nil {
+			report = append(report, fmt.Sprintf("%s: %v", path, err))
+			return nil
+		}
+		for _, c := range schemas {
+			if err := c.schema.Validate(payload); err != nil {
+				report = append(report, fmt.Sprintf("%s (catalog %s): %v", path, c.id, err))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("a2ui: reading examples under %s: %w", dir, err)
+	}
+	if len(report) > 0 {
+		sort.Strings(report)
+		return fmt.Errorf("a2ui: %d example(s) under %s failed validation:\n%s",
+			len(report), dir, strings.Join(report, "\n"))
+	}
+	return nil
+}
+
+// decodeExample parses an example file, accepting either a JSON array of
+// A2UI messages or a single message object, which is wrapped into a
+// one-element array as elsewhere in the pipeline.
+func decodeExample(raw []byte) (any, error) {
+	var payload any
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if obj, ok := payload.(map[string]any); ok {
+		payload = []any{obj}
+	}
+	return payload, nil
+}
+
+// catalogID names a catalog in validation reports.
+func catalogID(catalog *Catalog) string {
+	if catalog == nil || catalog.ID == "" {
+		return StandardCatalogID
+	}
+	return catalog.ID
+}
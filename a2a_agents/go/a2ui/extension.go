@@ -0,0 +1,301 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// ExtensionURI is the URI identifying the A2UI A2A extension.
+const ExtensionURI = "https://a2ui.org/a2a-extension/a2ui/v0.8"
+
+// MIMETypeKey is the part metadata key carrying the content MIME type.
+const MIMETypeKey = "mimeType"
+
+// MIMEType is the MIME type marking a DataPart as A2UI content.
+const MIMEType = "application/json+a2ui"
+
+// ClientCapabilitiesKey is the message metadata key under which clients send
+// their A2UI capabilities.
+const ClientCapabilitiesKey = "a2uiClientCapabilities"
+
+// SupportedCatalogIDsKey is the capabilities key listing catalog IDs the
+// client supports.
+const SupportedCatalogIDsKey = "supportedCatalogIds"
+
+// DataModelStateKey is the message metadata key under which clients attach
+// a snapshot of the current data model of each active surface, keyed by
+// surface ID, so the agent can see the state the user is looking at.
+const DataModelStateKey = "a2uiDataModelState"
+
+// InlineCatalogsKey is the capabilities key carrying inline catalog
+// definitions.
+const InlineCatalogsKey = "inlineCatalogs"
+
+// LocaleKey is the capabilities key carrying the client's BCP 47 locale
+// tag, e.g. "de-DE".
+const LocaleKey = "locale"
+
+// TimeZoneKey is the capabilities key carrying the client's IANA timezone
+// name, e.g. "Europe/Berlin".
+const TimeZoneKey = "timeZone"
+
+// ThemeKey is the capabilities key carrying the client's theme
+// preference.
+const ThemeKey = "theme"
+
+// Theme values clients send under ThemeKey.
+const (
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+// ViewportKey is the capabilities key carrying the client's viewport
+// hints, an object with "width", "height" (CSS pixels) and "density"
+// (device pixel ratio).
+const ViewportKey = "viewport"
+
+// StandardCatalogID identifies the standard component catalog for this A2UI
+// version.
+const StandardCatalogID = "https://a2ui.org/specification/v0_8/standard_catalog_definition.json"
+
+// Agent-card extension param keys.
+const (
+	// AgentExtensionSupportedCatalogIDsKey lists pre-defined catalogs the
+	// agent supports.
+	AgentExtensionSupportedCatalogIDsKey = "supportedCatalogIds"
+	// AgentExtensionAcceptsInlineCatalogsKey reports whether the agent
+	// accepts inline custom catalogs.
+	AgentExtensionAcceptsInlineCatalogsKey = "acceptsInlineCatalogs"
+)
+
+// NewPart creates an A2A Part containing A2UI data.
+func NewPart(a2uiData map[string]any) a2a.Part {
+	return a2a.NewDataPart(a2uiData, map[string]any{
+		MIMETypeKey: MIMEType,
+	})
+}
+
+// NewRawPart creates an A2A Part carrying one A2UI message as verbatim
+// JSON, so validated payloads pass through without a re-marshal that
+// would perturb number formatting and key order.
+func NewRawPart(raw json.RawMessage) a2a.Part {
+	return a2a.NewRawDataPart(raw, map[string]any{
+		MIMETypeKey: MIMEType,
+	})
+}
+
+// IsA2UIPart checks whether an A2A Part contains A2UI data.
+func IsA2UIPart(part a2a.Part) bool {
+	return part.Kind == "data" && PartMIMEType(part) == MIMEType
+}
+
+// Data extracts the A2UI data from an A2A Part. It returns nil if the part
+// does not contain A2UI data. Raw pass-through parts are decoded on
+// demand.
+func Data(part a2a.Part) map[string]any {
+	if !IsA2UIPart(part) {
+		return nil
+	}
+	if part.Data == nil && len(part.RawData) > 0 {
+		var data map[string]any
+		if err := json.Unmarshal(part.RawData, &data); err != nil {
+			return nil
+		}
+		return data
+	}
+	return part.Data
+}
+
+// SplitRawMessages splits a raw A2UI payload into the verbatim JSON of
+// its messages: the elements of a top-level array, or the whole payload
+// for a single object, mirroring the auto-wrap on validation.
+func SplitRawMessages(raw []byte) ([]json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("a2ui: empty payload")
+	}
+	if trimmed[0] != '[' {
+		return []json.RawMessage{json.RawMessage(trimmed)}, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("a2ui: splitting payload: %w", err)
+	}
+	var segments []json.RawMessage
+	for dec.More() {
+		var segment json.RawMessage
+		if err := dec.Decode(&segment); err != nil {
+			return nil, fmt.Errorf("a2ui: splitting payload: %w", err)
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// ClientCapabilities extracts the A2UI capabilities object from a
+// message's metadata. It returns nil if the message carries none.
+func ClientCapabilities(msg *a2a.Message) map[string]any {
+	if msg == nil || msg.Metadata == nil {
+		return nil
+	}
+	capabilities, _ := msg.Metadata[ClientCapabilitiesKey].(map[string]any)
+	return capabilities
+}
+
+// ClientDataModelState extracts the per-surface data-model snapshots from
+// a message's metadata: keys are surface IDs, values are that surface's
+// data model when the message was sent (filters the user changed, rows
+// they expanded, ...). It returns nil if the message carries none.
+func ClientDataModelState(msg *a2a.Message) map[string]map[string]any {
+	if msg == nil || msg.Metadata == nil {
+		return nil
+	}
+	raw, _ := msg.Metadata[DataModelStateKey].(map[string]any)
+	if len(raw) == 0 {
+		return nil
+	}
+	state := make(map[string]map[string]any, len(raw))
+	for surfaceID, model := range raw {
+		if m, ok := model.(map[string]any); ok {
+			state[surfaceID] = m
+		}
+	}
+	if len(state) == 0 {
+		return nil
+	}
+	return state
+}
+
+// ClientSupportedCatalogIDs returns the catalog IDs the client declares
+// it can render, from the message's capabilities, or nil if the client
+// did not send any.
+func ClientSupportedCatalogIDs(msg *a2a.Message) []string {
+	raw, _ := ClientCapabilities(msg)[SupportedCatalogIDsKey].([]any)
+	var ids []string
+	for _, entry := range raw {
+		if id, ok := entry.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// ClientLocale returns the client's BCP 47 locale tag from the message's
+// capabilities, or "" if the client did not send one.
+func ClientLocale(msg *a2a.Message) string {
+	locale, _ := ClientCapabilities(msg)[LocaleKey].(string)
+	return locale
+}
+
+// ClientTimeZone returns the client's IANA timezone name from the
+// message's capabilities, or "" if the client did not send one.
+func ClientTimeZone(msg *a2a.Message) string {
+	zone, _ := ClientCapabilities(msg)[TimeZoneKey].(string)
+	return zone
+}
+
+// ClientTheme returns the client's theme preference (ThemeLight or
+// ThemeDark) from the message's capabilities, or "" if the client did not
+// send one.
+func ClientTheme(msg *a2a.Message) string {
+	theme, _ := ClientCapabilities(msg)[ThemeKey].(string)
+	return theme
+}
+
+// Viewport is the client's viewport hint: the rendering area in CSS
+// pixels and the device pixel ratio.
+type Viewport struct {
+	Width   int
+	Height  int
+	Density float64
+}
+
+// ClientViewport returns the client's viewport hints from the message's
+// capabilities, or nil if the client did not send any.
+func ClientViewport(msg *a2a.Message) *Viewport {
+	hints, _ := ClientCapabilities(msg)[ViewportKey].(map[string]any)
+	if hints == nil {
+		return nil
+	}
+	v := &Viewport{}
+	if width, ok := hints["width"].(float64); ok {
+		v.Width = int(width)
+	}
+	if height, ok := hints["height"].(float64); ok {
+		v.Height = int(height)
+	}
+	if density, ok := hints["density"].(float64); ok {
+		v.Density = density
+	}
+	return v
+}
+
+// AgentExtension creates the A2UI AgentExtension configuration for an agent
+// card.
+func AgentExtension(acceptsInlineCatalogs bool, supportedCatalogIDs []string) a2a.AgentExtension {
+	params := map[string]any{}
+	if acceptsInlineCatalogs {
+		// Only set if not the default of false.
+		params[AgentExtensionAcceptsInlineCatalogsKey] = true
+	}
+	if len(supportedCatalogIDs) > 0 {
+		params[AgentExtensionSupportedCatalogIDsKey] = supportedCatalogIDs
+	}
+	if len(params) == 0 {
+		params = nil
+	}
+	return a2a.AgentExtension{
+		URI:         ExtensionURI,
+		Description: "Provides agent driven UI using the A2UI JSON format.",
+		Params:      params,
+	}
+}
+
+// RegisterExtension wires A2UI activation into a composed extension
+// set, for agents that serve A2UI alongside other A2A extensions. The
+// set's Activate then covers what TryActivateExtension does alone.
+func RegisterExtension(set *a2a.ExtensionSet) {
+	set.Handle(ExtensionURI, func(rc *a2a.RequestContext) bool {
+		rc.AddActivatedExtension(ExtensionURI)
+		return true
+	})
+}
+
+// TryActivateExtension activates the A2UI extension on the request context
+// if the client asked for it, and reports whether it did.
+func TryActivateExtension(rc *a2a.RequestContext) bool {
+	requested := false
+	for _, uri := range rc.RequestedExtensions() {
+		if uri == ExtensionURI {
+			requested = true
+		}
+	}
+	if !requested && rc.Message != nil {
+		for _, uri := range rc.Message.Extensions {
+			if uri == ExtensionURI {
+				requested = true
+			}
+		}
+	}
+	if requested {
+		rc.AddActivatedExtension(ExtensionURI)
+	}
+	return requested
+}
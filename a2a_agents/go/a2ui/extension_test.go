@@ -0,0 +1,117 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func TestSplitRawMessages(t *testing.T) {
+	segments, err := SplitRawMessages([]byte(`[{"a": 1.50}, {"b": "two"}]`))
+	if err != nil {
+		t.Fatalf("SplitRawMessages: %v", err)
+	}
+	if len(segments) != 2 {
+		t.Fatalf("got %d segments, want 2", len(segments))
+	}
+	if string(segments[0]) != `{"a": 1.50}` {
+		t.Errorf("segment 0 = %q, want verbatim bytes", segments[0])
+	}
+
+	// A single object yields one segment, mirroring the validation
+	// auto-wrap.
+	single, err := SplitRawMessages([]byte(` {"a": 1} `))
+	if err != nil || len(single) != 1 {
+		t.Fatalf("single object: %v, %v", single, err)
+	}
+
+	if _, err := SplitRawMessages([]byte("")); err == nil {
+		t.Error("empty payload did not error")
+	}
+	if _, err := SplitRawMessages([]byte(`[{"a": `)); err == nil {
+		t.Error("truncated payload did not error")
+	}
+}
+
+func TestRawPartPassThrough(t *testing.T) {
+	raw := json.RawMessage(`{"dataModelUpdate": {"surfaceId": "s1", "contents": [{"key": "x", "valueNumber": 1.50}]}}`)
+	part := NewRawPart(raw)
+
+	if !IsA2UIPart(part) {
+		t.Error("raw part not recognized as A2UI")
+	}
+	data := Data(part)
+	if data == nil || data["dataModelUpdate"] == nil {
+		t.Errorf("Data did not decode raw part: %v", data)
+	}
+
+	// Serialization keeps the producer's bytes: 1.50 must not become 1.5.
+	out, err := json.Marshal(part)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(out), "1.50") {
+		t.Errorf("raw JSON was re-marshaled:\n%s", out)
+	}
+	if !strings.Contains(string(out), `"kind":"data"`) {
+		t.Errorf("part envelope missing:\n%s", out)
+	}
+}
+
+func TestClientDataModelState(t *testing.T) {
+	msg := &a2a.Message{Metadata: map[string]any{
+		DataModelStateKey: map[string]any{
+			"main":    map[string]any{"filter": "q3"},
+			"invalid": "not an object",
+		},
+	}}
+	state := ClientDataModelState(msg)
+	if len(state) != 1 {
+		t.Fatalf("state = %+v, want only the well-formed surface", state)
+	}
+	if got := state["main"]["filter"]; got != "q3" {
+		t.Errorf("state[main][filter] = %v, want q3", got)
+	}
+
+	if ClientDataModelState(nil) != nil {
+		t.Error("nil message should have nil state")
+	}
+	if ClientDataModelState(&a2a.Message{}) != nil {
+		t.Error("message without metadata should have nil state")
+	}
+	if ClientDataModelState(&a2a.Message{Metadata: map[string]any{DataModelStateKey: map[string]any{}}}) != nil {
+		t.Error("empty snapshot should collapse to nil")
+	}
+}
+
+func TestRegisterExtension(t *testing.T) {
+	set := a2a.NewExtensionSet()
+	RegisterExtension(set)
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", []string{ExtensionURI})
+	activated, unknown := set.Activate(rc)
+	if len(activated) != 1 || activated[0] != ExtensionURI {
+		t.Errorf("activated = %v, want the A2UI extension", activated)
+	}
+	if len(unknown) != 0 {
+		t.Errorf("unknown = %v, want none", unknown)
+	}
+	if got := rc.ActivatedExtensions(); len(got) != 1 || got[0] != ExtensionURI {
+		t.Errorf("context activated = %v", got)
+	}
+}
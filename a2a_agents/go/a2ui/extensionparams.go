@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+// extensionParamsSchema describes the A2UI AgentExtension params object.
+// It is closed, so a misspelled key — supportedCatalogIDs for
+// supportedCatalogIds, say — fails validation instead of being silently
+// ignored at negotiation time.
+var extensionParamsSchema = map[string]any{
+	"type":                 "object",
+	"additionalProperties": false,
+	"properties": map[string]any{
+		AgentExtensionSupportedCatalogIDsKey: map[string]any{
+			"type":  "array",
+			"items": map[string]any{"type": "string"},
+		},
+		AgentExtensionAcceptsInlineCatalogsKey: map[string]any{
+			"type": "boolean",
+		},
+	},
+}
+
+var compiledExtensionParams = sync.OnceValue(func() *jsonschema.Schema {
+	schema, err := jsonschema.Compile(extensionParamsSchema)
+	if err != nil {
+		// The schema is a ref-free literal; compilation cannot fail.
+		panic(err)
+	}
+	return schema
+})
+
+// ValidateExtensionParams checks an A2UI AgentExtension params object
+// against the schema. Call it when hand-building a card and on the
+// extension parsed from a remote card. Nil params are valid: every param
+// has a default.
+func ValidateExtensionParams(params map[string]any) error {
+	if params == nil {
+		return nil
+	}
+	// Round-trip through JSON so in-memory values ([]string from a card
+	// builder) and decoded values ([]any from a remote card) validate
+	// alike.
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("a2ui: encoding extension params: %w", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return fmt.Errorf("a2ui: decoding extension params: %w", err)
+	}
+	if err := compiledExtensionParams().Validate(decoded); err != nil {
+		return fmt.Errorf("a2ui: invalid extension params: %w", err)
+	}
+	return nil
+}
+
+// ValidateCardExtension locates the A2UI extension on an agent card and
+// validates its params. Cards without the extension pass — not
+// advertising A2UI is not a typo.
+func ValidateCardExtension(card a2a.AgentCard) error {
+	for _, ext := range card.Capabilities.Extensions {
+		if ext.URI != ExtensionURI {
+			continue
+		}
+		if err := ValidateExtensionParams(ext.Params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
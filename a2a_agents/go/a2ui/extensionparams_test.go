@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func TestValidateExtensionParams(t *testing.T) {
+	// The card builder's output is valid by construction.
+	ext := AgentExtension(true, []string{StandardCatalogID})
+	if err := ValidateExtensionParams(ext.Params); err != nil {
+		t.Errorf("builder params rejected: %v", err)
+	}
+	if err := ValidateExtensionParams(nil); err != nil {
+		t.Errorf("nil params rejected: %v", err)
+	}
+
+	// The classic typo: wrong capitalization of the catalog IDs key.
+	err := ValidateExtensionParams(map[string]any{
+		"supportedCatalogIDs": []string{StandardCatalogID},
+	})
+	if err == nil || !strings.Contains(err.Error(), "supportedCatalogIDs") {
+		t.Errorf("misspelled key error = %v, want it named", err)
+	}
+
+	// Wrongly typed values fail too.
+	if err := ValidateExtensionParams(map[string]any{
+		AgentExtensionAcceptsInlineCatalogsKey: "yes",
+	}); err == nil {
+		t.Error("string acceptsInlineCatalogs passed")
+	}
+	if err := ValidateExtensionParams(map[string]any{
+		AgentExtensionSupportedCatalogIDsKey: StandardCatalogID,
+	}); err == nil {
+		t.Error("unlisted supportedCatalogIds passed")
+	}
+}
+
+func TestValidateCardExtension(t *testing.T) {
+	card := a2a.AgentCard{
+		Name: "test",
+		Capabilities: a2a.AgentCapabilities{
+			Extensions: []a2a.AgentExtension{
+				{URI: "https://example.com/other-extension", Params: map[string]any{"weird": 1}},
+				AgentExtension(false, []string{StandardCatalogID}),
+			},
+		},
+	}
+	if err := ValidateCardExtension(card); err != nil {
+		t.Errorf("valid card rejected: %v", err)
+	}
+	// Cards without the A2UI extension pass.
+	if err := ValidateCardExtension(a2a.AgentCard{Name: "plain"}); err != nil {
+		t.Errorf("card without the extension rejected: %v", err)
+	}
+
+	// A remote card arrives through JSON decoding; its params validate
+	// in decoded form too.
+	raw, _ := json.Marshal(card)
+	var remote a2a.AgentCard
+	if err := json.Unmarshal(raw, &remote); err != nil {
+		t.Fatalf("round-tripping card: %v", err)
+	}
+	if err := ValidateCardExtension(remote); err != nil {
+		t.Errorf("decoded card rejected: %v", err)
+	}
+
+	remote.Capabilities.Extensions[1].Params["supportedCatalogIDs"] = []any{StandardCatalogID}
+	if err := ValidateCardExtension(remote); err == nil {
+		t.Error("card with a misspelled param key passed")
+	}
+}
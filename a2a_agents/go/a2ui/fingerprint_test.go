@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFingerprintIgnoresRepresentation(t *testing.T) {
+	var a, b map[string]any
+	if err := json.Unmarshal([]byte(`{"surfaceId":"main","count":1.0}`), &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"count":1e0,"surfaceId":"main"}`), &b); err != nil {
+		t.Fatal(err)
+	}
+	fpA, err := Fingerprint(a)
+	if err != nil {
+		t.Fatalf("Fingerprint: %v", err)
+	}
+	fpB, err := Fingerprint(b)
+	if err != nil {
+		t.Fatalf("Fingerprint: %v", err)
+	}
+	if fpA != fpB {
+		t.Errorf("equivalent payloads fingerprint differently: %s vs %s", fpA, fpB)
+	}
+	if len(fpA) != 64 {
+		t.Errorf("fingerprint %q is not a hex SHA-256", fpA)
+	}
+}
+
+func TestFingerprintDistinguishesSurfaces(t *testing.T) {
+	// Representative surfaces differing only in small details must not
+	// collide.
+	surfaces := []map[string]any{
+		walkSurfaceUpdate(),
+		{"surfaceId": "main", "components": []any{}},
+		{"surfaceId": "other", "components": []any{}},
+		{"surfaceId": "main", "components": []any{
+			map[string]any{"id": "title", "component": map[string]any{
+				"Text": map[string]any{"text": map[string]any{"literalString": "Hello"}},
+			}},
+		}},
+		{"surfaceId": "main", "components": []any{
+			map[string]any{"id": "title", "component": map[string]any{
+				"Text": map[string]any{"text": map[string]any{"literalString": "Hello!"}},
+			}},
+		}},
+		{"surfaceId": "main", "components": []any{
+			map[string]any{"id": "title2", "component": map[string]any{
+				"Text": map[string]any{"text": map[string]any{"literalString": "Hello"}},
+			}},
+		}},
+	}
+	seen := map[string]int{}
+	for i, surface := range surfaces {
+		fp, err := Fingerprint(surface)
+		if err != nil {
+			t.Fatalf("Fingerprint(surfaces[%d]): %v", i, err)
+		}
+		if prev, ok := seen[fp]; ok {
+			t.Errorf("surfaces[%d] and surfaces[%d] collide on %s", prev, i, fp)
+		}
+		seen[fp] = i
+	}
+}
+
+func TestFingerprintStable(t *testing.T) {
+	first, err := Fingerprint(walkSurfaceUpdate())
+	if err != nil {
+		t.Fatalf("Fingerprint: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		fp, err := Fingerprint(walkSurfaceUpdate())
+		if err != nil {
+			t.Fatalf("Fingerprint: %v", err)
+		}
+		if fp != first {
+			t.Fatalf("fingerprint is unstable: %s vs %s", first, fp)
+		}
+	}
+}
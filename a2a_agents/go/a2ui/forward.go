@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"fmt"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// ForwardOptions configures ForwardParts.
+type ForwardOptions struct {
+	// SurfacePrefix is prepended to every surfaceId, so surfaces from
+	// different downstream agents cannot collide when aggregated for one
+	// client. Empty leaves surface IDs unchanged.
+	SurfacePrefix string
+
+	// Catalogs lists the catalog IDs the upstream client supports. When
+	// non-nil, forwarding fails if a downstream surface begins rendering
+	// with a catalog outside the list; a beginRendering without a
+	// catalogId implies the standard catalog. Nil skips the check.
+	Catalogs []string
+}
+
+// ForwardParts re-emits A2UI data parts received from a downstream agent
+// for delivery to an upstream client. A2UI parts are rebuilt with the
+// extension metadata and their messages rewritten per opts; parts without
+// A2UI content are dropped. Messages are copied before rewriting, so the
+// downstream task's parts stay intact.
+func ForwardParts(parts []a2a.Part, opts ForwardOptions) ([]a2a.Part, error) {
+	var out []a2a.Part
+	for _, part := range parts {
+		data := Data(part)
+		if data == nil {
+			continue
+		}
+		forwarded, err := forwardMessage(data, opts)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, NewPart(forwarded))
+	}
+	return out, nil
+}
+
+// forwardMessage copies one A2UI message, namespacing its surface ID and
+// checking catalog compatibility.
+func forwardMessage(msg map[string]any, opts ForwardOptions) (map[string]any, error) {
+	out := make(map[string]any, len(msg))
+	for key, body := range msg {
+		wrapper, ok := body.(map[string]any)
+		if !ok {
+			out[key] = body
+			continue
+		}
+		if key == "beginRendering" && opts.Catalogs != nil {
+			// Every A2UI message carries its surface ID one level under the
+			// message key; only beginRendering names a catalog.
+			catalogID, _ := wrapper["catalogId"].(string)
+			if catalogID == "" {
+				catalogID = StandardCatalogID
+			}
+			if !containsString(opts.Catalogs, catalogID) {
+				surfaceID, _ := wrapper["surfaceId"].(string)
+				return nil, fmt.Errorf("a2ui: surface %q requires catalog %q, which the client does not support", surfaceID, catalogID)
+			}
+		}
+		copied := make(map[string]any, len(wrapper))
+		for k, v := range wrapper {
+			copied[k] = v
+		}
+		if id, ok := copied["surfaceId"].(string); ok {
+			copied["surfaceId"] = opts.SurfacePrefix + id
+		}
+		out[key] = copied
+	}
+	return out, nil
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
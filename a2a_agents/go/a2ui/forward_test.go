@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func TestForwardPartsPrefixesSurfaces(t *testing.T) {
+	original := map[string]any{
+		"surfaceUpdate": map[string]any{
+			"surfaceId":  "main",
+			"components": []any{},
+		},
+	}
+	parts := []a2a.Part{
+		NewPart(original),
+		a2a.NewTextPart("ignored"),
+		NewPart(map[string]any{
+			"deleteSurface": map[string]any{"surfaceId": "main"},
+		}),
+	}
+
+	forwarded, err := ForwardParts(parts, ForwardOptions{SurfacePrefix: "sub/"})
+	if err != nil {
+		t.Fatalf("ForwardParts: %v", err)
+	}
+	if len(forwarded) != 2 {
+		t.Fatalf("got %d parts, want 2 (text part dropped)", len(forwarded))
+	}
+	for i, part := range forwarded {
+		if !IsA2UIPart(part) {
+			t.Errorf("part %d is not marked as A2UI content", i)
+		}
+	}
+	update := Data(forwarded[0])["surfaceUpdate"].(map[string]any)
+	if got := update["surfaceId"]; got != "sub/main" {
+		t.Errorf("surfaceUpdate surfaceId = %v, want sub/main", got)
+	}
+	del := Data(forwarded[1])["deleteSurface"].(map[string]any)
+	if got := del["surfaceId"]; got != "sub/main" {
+		t.Errorf("deleteSurface surfaceId = %v, want sub/main", got)
+	}
+	// The downstream task's message must not be rewritten in place.
+	if got := original["surfaceUpdate"].(map[string]any)["surfaceId"]; got != "main" {
+		t.Errorf("original surfaceId mutated to %v", got)
+	}
+}
+
+func TestForwardPartsCatalogCheck(t *testing.T) {
+	begin := func(catalogID string) a2a.Part {
+		wrapper := map[string]any{"surfaceId": "main", "root": "root"}
+		if catalogID != "" {
+			wrapper["catalogId"] = catalogID
+		}
+		return NewPart(map[string]any{"beginRendering": wrapper})
+	}
+
+	// No catalogId implies the standard catalog.
+	if _, err := ForwardParts([]a2a.Part{begin("")}, ForwardOptions{
+		Catalogs: []string{StandardCatalogID},
+	}); err != nil {
+		t.Errorf("implied standard catalog rejected: %v", err)
+	}
+
+	// An unsupported catalog fails the whole forward.
+	_, err := ForwardParts([]a2a.Part{begin("https://example.com/custom.json")}, ForwardOptions{
+		Catalogs: []string{StandardCatalogID},
+	})
+	if err == nil {
+		t.Fatal("unsupported catalog was forwarded")
+	}
+	if !strings.Contains(err.Error(), "https://example.com/custom.json") {
+		t.Errorf("error %q does not name the catalog", err)
+	}
+
+	// Nil Catalogs skips the check entirely.
+	if _, err := ForwardParts([]a2a.Part{begin("https://example.com/custom.json")}, ForwardOptions{}); err != nil {
+		t.Errorf("nil Catalogs should skip the check, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import "sort"
+
+// ComponentInfo describes one catalog component for tooling: codegen,
+// the linter, and catalog-coverage dashboards.
+type ComponentInfo struct {
+	// Name is the component's type name, e.g. "Text".
+	Name string
+	// Properties lists the component's properties, sorted by name.
+	Properties []PropertyInfo
+}
+
+// PropertyInfo describes one property of a catalog component.
+type PropertyInfo struct {
+	// Name is the property name, e.g. "usageHint".
+	Name string
+	// Type is the property's JSON schema type, e.g. "string" or
+	// "object"; empty when the schema does not state one.
+	Type string
+	// Required reports whether the component schema requires the
+	// property.
+	Required bool
+	// Deprecated reports whether the schema marks the property
+	// "deprecated": true.
+	Deprecated bool
+	// Description is the schema's description text, if any.
+	Description string
+}
+
+// ComponentNames returns the catalog's component type names, sorted.
+func (c *Catalog) ComponentNames() []string {
+	names := make([]string, 0, len(c.Components))
+	for name := range c.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Component introspects one catalog component by type name.
+func (c *Catalog) Component(name string) (*ComponentInfo, bool) {
+	schema, ok := c.Components[name].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	required := map[string]bool{}
+	if list, ok := schema["required"].([]any); ok {
+		for _, entry := range list {
+			if prop, ok := entry.(string); ok {
+				required[prop] = true
+			}
+		}
+	}
+	info := &ComponentInfo{Name: name}
+	props, _ := schema["properties"].(map[string]any)
+	for propName, raw := range props {
+		prop := PropertyInfo{Name: propName, Required: required[propName]}
+		if def, ok := raw.(map[string]any); ok {
+			prop.Type, _ = def["type"].(string)
+			prop.Deprecated, _ = def["deprecated"].(bool)
+			prop.Description, _ = def["description"].(string)
+		}
+		info.Properties = append(info.Properties, prop)
+	}
+	sort.Slice(info.Properties, func(i, j int) bool {
+		return info.Properties[i].Name < info.Properties[j].Name
+	})
+	return info, true
+}
+
+// Coverage splits the catalog's component names into those a payload's
+// surfaces use and those they do not, both sorted. Components the
+// payload uses but the catalog does not define are ignored; UsedComponents
+// reports them.
+func (c *Catalog) Coverage(messages []map[string]any) (used, unused []string) {
+	inPayload := map[string]bool{}
+	for _, name := range UsedComponents(messages) {
+		inPayload[name] = true
+	}
+	for _, name := range c.ComponentNames() {
+		if inPayload[name] {
+			used = append(used, name)
+		} else {
+			unused = append(unused, name)
+		}
+	}
+	return used, unused
+}
+
+// UsedComponents returns the component type names a payload's surfaces
+// actually use, sorted, whether or not a catalog defines them.
+func UsedComponents(messages []map[string]any) []string {
+	seen := map[string]bool{}
+	Walk(messages, func(n *ComponentNode) error {
+		if n.Type != "" {
+			seen[n.Type] = true
+		}
+		return nil
+	}, nil)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCatalogComponentNames(t *testing.T) {
+	catalog, err := StandardCatalog()
+	if err != nil {
+		t.Fatalf("StandardCatalog: %v", err)
+	}
+	names := catalog.ComponentNames()
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("ComponentNames is not sorted: %v", names)
+	}
+	if !reflect.DeepEqual(names, StandardComponentNames) {
+		t.Errorf("ComponentNames = %v, want %v", names, StandardComponentNames)
+	}
+}
+
+func TestCatalogComponent(t *testing.T) {
+	catalog, err := StandardCatalog()
+	if err != nil {
+		t.Fatalf("StandardCatalog: %v", err)
+	}
+	info, ok := catalog.Component("Text")
+	if !ok {
+		t.Fatal("Component(Text) not found")
+	}
+	byName := map[string]PropertyInfo{}
+	for _, prop := range info.Properties {
+		byName[prop.Name] = prop
+	}
+	text, ok := byName["text"]
+	if !ok {
+		t.Fatalf("Text has no text property: %v", info.Properties)
+	}
+	if text.Type != "object" || !text.Required || text.Description == "" {
+		t.Errorf("text property = %+v, want a required described object", text)
+	}
+	if hint, ok := byName["usageHint"]; !ok || hint.Type != "string" || hint.Required {
+		t.Errorf("usageHint property = %+v, want an optional string", hint)
+	}
+
+	if _, ok := catalog.Component("NoSuchComponent"); ok {
+		t.Error("Component found a component the catalog does not define")
+	}
+}
+
+func TestCatalogCoverage(t *testing.T) {
+	catalog, err := StandardCatalog()
+	if err != nil {
+		t.Fatalf("StandardCatalog: %v", err)
+	}
+	messages := []map[string]any{{"surfaceUpdate": walkSurfaceUpdate()}}
+	used, unused := catalog.Coverage(messages)
+	want := []string{"Button", "Card", "Column", "Text"}
+	if !reflect.DeepEqual(used, want) {
+		t.Errorf("used = %v, want %v", used, want)
+	}
+	if len(used)+len(unused) != len(catalog.Components) {
+		t.Errorf("coverage split %d + %d components, catalog has %d", len(used), len(unused), len(catalog.Components))
+	}
+	for _, name := range unused {
+		if name == "Text" {
+			t.Error("Text reported both used and unused")
+		}
+	}
+}
+
+func TestUsedComponents(t *testing.T) {
+	messages := []map[string]any{
+		{"surfaceUpdate": walkSurfaceUpdate()},
+		{"surfaceUpdate": map[string]any{
+			"surfaceId": "extra",
+			"components": []any{
+				map[string]any{"id": "pie", "component": map[string]any{
+					"PieChart": map[string]any{},
+				}},
+			},
+		}},
+	}
+	got := UsedComponents(messages)
+	want := []string{"Button", "Card", "Column", "PieChart", "Text"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UsedComponents = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,406 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonschema implements the subset of JSON Schema used by the A2UI
+// specification schemas: type, properties, required, additionalProperties,
+// items, enum, const, oneOf/anyOf/allOf, local $ref/$defs, pattern and the
+// numeric and array bounds keywords. It validates instances decoded with
+// encoding/json (maps, slices, strings, float64, bool, nil).
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Schema is a compiled schema document ready for validation.
+type Schema struct {
+	root map[string]any
+}
+
+// ValidationError describes a single failed constraint.
+type ValidationError struct {
+	// InstancePath is a JSON-pointer-style path to the offending value,
+	// e.g. "/components/2/component".
+	InstancePath string
+	// Message describes the failed constraint.
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.InstancePath == "" {
+		return e.Message
+	}
+	return e.InstancePath + ": " + e.Message
+}
+
+// ValidationErrors is the error returned by Validate, collecting every
+// constraint the instance failed.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Compile prepares the schema document for validation. The document must be
+// the result of unmarshaling a JSON schema into map[string]any.
+func Compile(doc map[string]any) (*Schema, error) {
+	return CompileContext(context.Background(), doc)
+}
+
+// CompileContext is Compile honoring context cancellation, so a deadline
+// bounds compilation of pathological schema documents.
+func CompileContext(ctx context.Context, doc map[string]any) (*Schema, error) {
+	s := &Schema{root: doc}
+	if err := s.checkRefs(ctx, doc, map[string]bool{}); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("jsonschema: compilation aborted: %w", err)
+	}
+	return s, nil
+}
+
+// CompileBytes parses and compiles a JSON schema document.
+func CompileBytes(raw []byte) (*Schema, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("jsonschema: parsing schema: %w", err)
+	}
+	return Compile(doc)
+}
+
+// checkRefs verifies that every $ref in the document resolves locally.
+func (s *Schema) checkRefs(ctx context.Context, node any, seen map[string]bool) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	switch n := node.(type) {
+	case map[string]any:
+		if ref, ok := n["$ref"].(string); ok && !seen[ref] {
+			seen[ref] = true
+			if _, err := s.resolveRef(ref); err != nil {
+				return err
+			}
+		}
+		for _, v := range n {
+			if err := s.checkRefs(ctx, v, seen); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, v := range n {
+			if err := s.checkRefs(ctx, v, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolveRef resolves a local JSON pointer reference like "#/$defs/Component".
+func (s *Schema) resolveRef(ref string) (map[string]any, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("jsonschema: unsupported non-local $ref %q", ref)
+	}
+	var node any = s.root
+	pointer := strings.TrimPrefix(ref, "#")
+	for _, tok := range strings.Split(pointer, "/") {
+		if tok == "" {
+			continue
+		}
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("jsonschema: cannot resolve $ref %q", ref)
+		}
+		node, ok = m[tok]
+		if !ok {
+			return nil, fmt.Errorf("jsonschema: cannot resolve $ref %q", ref)
+		}
+	}
+	m, ok := node.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("jsonschema: $ref %q does not point at a schema object", ref)
+	}
+	return m, nil
+}
+
+// Validate checks the instance against the schema and returns a
+// ValidationErrors error if it does not conform.
+func (s *Schema) Validate(instance any) error {
+	return s.ValidateContext(context.Background(), instance)
+}
+
+// ValidateContext is Validate honoring context cancellation: the walk stops
+// descending once the context is done and the context error is returned
+// instead of the partial validation result.
+func (s *Schema) ValidateContext(ctx context.Context, instance any) error {
+	var errs ValidationErrors
+	s.validate(ctx, s.root, instance, "", &errs)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("jsonschema: validation aborted: %w", err)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (s *Schema) validate(ctx context.Context, schema map[string]any, instance any, path string, errs *ValidationErrors) {
+	if ctx.Err() != nil {
+		return
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := s.resolveRef(ref)
+		if err != nil {
+			*errs = append(*errs, &ValidationError{path, err.Error()})
+			return
+		}
+		s.validate(ctx, target, instance, path, errs)
+		return
+	}
+
+	if want, ok := schema["type"]; ok {
+		if !typeMatches(want, instance) {
+			*errs = append(*errs, &ValidationError{path,
+				fmt.Sprintf("expected type %v, got %s", want, typeName(instance))})
+			return
+		}
+	}
+
+	if c, ok := schema["const"]; ok && !deepEqual(c, instance) {
+		*errs = append(*errs, &ValidationError{path, fmt.Sprintf("expected constant %v", c)})
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		matched := false
+		for _, option := range enum {
+			if deepEqual(option, instance) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			*errs = append(*errs, &ValidationError{path, fmt.Sprintf("value is not one of %v", enum)})
+		}
+	}
+
+	s.validateCombinators(ctx, schema, instance, path, errs)
+
+	switch v := instance.(type) {
+	case map[string]any:
+		s.validateObject(ctx, schema, v, path, errs)
+	case []any:
+		s.validateArray(ctx, schema, v, path, errs)
+	case string:
+		validateString(schema, v, path, errs)
+	case float64:
+		validateNumber(schema, v, path, errs)
+	}
+}
+
+func (s *Schema) validateCombinators(ctx context.Context, schema map[string]any, instance any, path string, errs *ValidationErrors) {
+	if all, ok := schema["allOf"].([]any); ok {
+		for _, sub := range all {
+			if m, ok := sub.(map[string]any); ok {
+				s.validate(ctx, m, instance, path, errs)
+			}
+		}
+	}
+	for _, kw := range []string{"anyOf", "oneOf"} {
+		options, ok := schema[kw].([]any)
+		if !ok {
+			continue
+		}
+		matches := 0
+		var firstErrs ValidationErrors
+		for _, sub := range options {
+			m, ok := sub.(map[string]any)
+			if !ok {
+				continue
+			}
+			var subErrs ValidationErrors
+			s.validate(ctx, m, instance, path, &subErrs)
+			if len(subErrs) == 0 {
+				matches++
+			} else if firstErrs == nil {
+				firstErrs = subErrs
+			}
+		}
+		switch {
+		case matches == 0:
+			*errs = append(*errs, &ValidationError{path,
+				fmt.Sprintf("does not match any %s schema", kw)})
+			*errs = append(*errs, firstErrs...)
+		case matches > 1 && kw == "oneOf":
+			*errs = append(*errs, &ValidationError{path,
+				fmt.Sprintf("matches %d oneOf schemas, expected exactly one", matches)})
+		}
+	}
+}
+
+func (s *Schema) validateObject(ctx context.Context, schema map[string]any, obj map[string]any, path string, errs *ValidationErrors) {
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, present := obj[name]; !present {
+				*errs = append(*errs, &ValidationError{path,
+					fmt.Sprintf("missing required property %q", name)})
+			}
+		}
+	}
+	props, _ := schema["properties"].(map[string]any)
+	for name, value := range obj {
+		childPath := path + "/" + name
+		if propSchema, ok := props[name].(map[string]any); ok {
+			s.validate(ctx, propSchema, value, childPath, errs)
+			continue
+		}
+		switch ap := schema["additionalProperties"].(type) {
+		case bool:
+			if !ap {
+				*errs = append(*errs, &ValidationError{childPath, "unexpected property"})
+			}
+		case map[string]any:
+			s.validate(ctx, ap, value, childPath, errs)
+		}
+	}
+	if minProps, ok := schema["minProperties"].(float64); ok && float64(len(obj)) < minProps {
+		*errs = append(*errs, &ValidationError{path,
+			fmt.Sprintf("expected at least %d properties, got %d", int(minProps), len(obj))})
+	}
+	if maxProps, ok := schema["maxProperties"].(float64); ok && float64(len(obj)) > maxProps {
+		*errs = append(*errs, &ValidationError{path,
+			fmt.Sprintf("expected at most %d properties, got %d", int(maxProps), len(obj))})
+	}
+}
+
+func (s *Schema) validateArray(ctx context.Context, schema map[string]any, arr []any, path string, errs *ValidationErrors) {
+	if items, ok := schema["items"].(map[string]any); ok {
+		for i, elem := range arr {
+			s.validate(ctx, items, elem, fmt.Sprintf("%s/%d", path, i), errs)
+		}
+	}
+	if minItems, ok := schema["minItems"].(float64); ok && float64(len(arr)) < minItems {
+		*errs = append(*errs, &ValidationError{path,
+			fmt.Sprintf("expected at least %d items, got %d", int(minItems), len(arr))})
+	}
+	if maxItems, ok := schema["maxItems"].(float64); ok && float64(len(arr)) > maxItems {
+		*errs = append(*errs, &ValidationError{path,
+			fmt.Sprintf("expected at most %d items, got %d", int(maxItems), len(arr))})
+	}
+}
+
+func validateString(schema map[string]any, str, path string, errs *ValidationErrors) {
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err == nil && !re.MatchString(str) {
+			*errs = append(*errs, &ValidationError{path,
+				fmt.Sprintf("does not match pattern %q", pattern)})
+		}
+	}
+	if minLen, ok := schema["minLength"].(float64); ok && float64(len(str)) < minLen {
+		*errs = append(*errs, &ValidationError{path,
+			fmt.Sprintf("expected at least %d characters", int(minLen))})
+	}
+	if maxLen, ok := schema["maxLength"].(float64); ok && float64(len(str)) > maxLen {
+		*errs = append(*errs, &ValidationError{path,
+			fmt.Sprintf("expected at most %d characters", int(maxLen))})
+	}
+	// "format" values are annotations in the A2UI schemas; not enforced.
+}
+
+func validateNumber(schema map[string]any, n float64, path string, errs *ValidationErrors) {
+	if min, ok := schema["minimum"].(float64); ok && n < min {
+		*errs = append(*errs, &ValidationError{path, fmt.Sprintf("must be >= %v", min)})
+	}
+	if max, ok := schema["maximum"].(float64); ok && n > max {
+		*errs = append(*errs, &ValidationError{path, fmt.Sprintf("must be <= %v", max)})
+	}
+}
+
+// typeMatches reports whether the instance satisfies a "type" keyword, which
+// may be a single type name or a list of alternatives.
+func typeMatches(want any, instance any) bool {
+	switch w := want.(type) {
+	case string:
+		return typeNameMatches(w, instance)
+	case []any:
+		for _, alt := range w {
+			if name, ok := alt.(string); ok && typeNameMatches(name, instance) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeNameMatches(name string, instance any) bool {
+	switch name {
+	case "object":
+		_, ok := instance.(map[string]any)
+		return ok
+	case "array":
+		_, ok := instance.([]any)
+		return ok
+	case "string":
+		_, ok := instance.(string)
+		return ok
+	case "number":
+		_, ok := instance.(float64)
+		return ok
+	case "integer":
+		n, ok := instance.(float64)
+		return ok && n == float64(int64(n))
+	case "boolean":
+		_, ok := instance.(bool)
+		return ok
+	case "null":
+		return instance == nil
+	}
+	return false
+}
+
+func typeName(instance any) string {
+	switch instance.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", instance)
+	}
+}
+
+// deepEqual compares two JSON values for equality.
+func deepEqual(a, b any) bool {
+	ab, err1 := json.Marshal(a)
+	bb, err2 := json.Marshal(b)
+	return err1 == nil && err2 == nil && string(ab) == string(bb)
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func compile(t *testing.T, raw string) *Schema {
+	t.Helper()
+	s, err := CompileBytes([]byte(raw))
+	if err != nil {
+		t.Fatalf("CompileBytes: %v", err)
+	}
+	return s
+}
+
+func instance(t *testing.T, raw string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	return v
+}
+
+func TestValidateObject(t *testing.T) {
+	s := compile(t, `{
+	  "type": "object",
+	  "additionalProperties": false,
+	  "properties": {
+	    "surfaceId": {"type": "string"},
+	    "weight": {"type": "number", "minimum": 0}
+	  },
+	  "required": ["surfaceId"]
+	}`)
+
+	tests := []struct {
+		name     string
+		instance string
+		wantErr  string
+	}{
+		{"valid", `{"surfaceId": "s1", "weight": 2}`, ""},
+		{"missing required", `{"weight": 2}`, `missing required property "surfaceId"`},
+		{"unexpected property", `{"surfaceId": "s1", "extra": true}`, "unexpected property"},
+		{"wrong type", `{"surfaceId": 7}`, "expected type"},
+		{"below minimum", `{"surfaceId": "s1", "weight": -1}`, ">= 0"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := s.Validate(instance(t, tc.instance))
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("Validate = %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRefAndOneOf(t *testing.T) {
+	s := compile(t, `{
+	  "oneOf": [
+	    {"$ref": "#/$defs/text"},
+	    {"$ref": "#/$defs/num"}
+	  ],
+	  "$defs": {
+	    "text": {"type": "object", "properties": {"kind": {"const": "text"}}, "required": ["kind"]},
+	    "num": {"type": "number"}
+	  }
+	}`)
+	if err := s.Validate(instance(t, `{"kind": "text"}`)); err != nil {
+		t.Errorf("text variant: %v", err)
+	}
+	if err := s.Validate(instance(t, `42`)); err != nil {
+		t.Errorf("number variant: %v", err)
+	}
+	if err := s.Validate(instance(t, `true`)); err == nil {
+		t.Error("bool accepted, want oneOf failure")
+	}
+}
+
+func TestValidateArrayBounds(t *testing.T) {
+	s := compile(t, `{"type": "array", "minItems": 1, "items": {"type": "string", "enum": ["a", "b"]}}`)
+	if err := s.Validate(instance(t, `["a", "b"]`)); err != nil {
+		t.Errorf("valid array: %v", err)
+	}
+	if err := s.Validate(instance(t, `[]`)); err == nil {
+		t.Error("empty array accepted, want minItems failure")
+	}
+	if err := s.Validate(instance(t, `["c"]`)); err == nil {
+		t.Error("out-of-enum value accepted")
+	}
+}
+
+func TestValidationErrorPaths(t *testing.T) {
+	s := compile(t, `{
+	  "type": "object",
+	  "properties": {
+	    "components": {"type": "array", "items": {"type": "object", "required": ["id"]}}
+	  }
+	}`)
+	err := s.Validate(instance(t, `{"components": [{"id": "x"}, {}]}`))
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Validate = %v, want ValidationErrors", err)
+	}
+	if got := errs[0].InstancePath; got != "/components/1" {
+		t.Errorf("InstancePath = %q, want /components/1", got)
+	}
+}
+
+func TestCompileRejectsBrokenRef(t *testing.T) {
+	if _, err := CompileBytes([]byte(`{"$ref": "#/$defs/missing"}`)); err == nil {
+		t.Error("CompileBytes accepted a dangling $ref")
+	}
+}
+
+func TestValidateSpecServerToClientSchema(t *testing.T) {
+	raw, err := os.ReadFile("../../../../specification/v0_8/json/server_to_client.json")
+	if err != nil {
+		t.Skipf("spec schema not available: %v", err)
+	}
+	s, err := CompileBytes(raw)
+	if err != nil {
+		t.Fatalf("CompileBytes: %v", err)
+	}
+	valid := `{"beginRendering": {"surfaceId": "s1", "root": "root"}}`
+	if err := s.Validate(instance(t, valid)); err != nil {
+		t.Errorf("valid beginRendering rejected: %v", err)
+	}
+	invalid := `{"beginRendering": {"surfaceId": "s1"}}`
+	if err := s.Validate(instance(t, invalid)); err == nil {
+		t.Error("beginRendering without root accepted")
+	}
+}
+
+func TestValidateContextCanceled(t *testing.T) {
+	s, err := CompileBytes([]byte(`{"type": "object"}`))
+	if err != nil {
+		t.Fatalf("CompileBytes: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = s.ValidateContext(ctx, map[string]any{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ValidateContext = %v, want context.Canceled", err)
+	}
+	// The same instance still validates with a live context.
+	if err := s.ValidateContext(context.Background(), map[string]any{}); err != nil {
+		t.Errorf("ValidateContext with live context: %v", err)
+	}
+}
+
+func TestCompileContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := CompileContext(ctx, map[string]any{"type": "object"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CompileContext = %v, want context.Canceled", err)
+	}
+}
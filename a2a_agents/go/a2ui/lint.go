@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Warning is a non-fatal validation finding: the payload is valid per the
+// schema but uses something deprecated or exceeds a soft limit.
+type Warning struct {
+	// Path locates the finding, e.g. "messages[0].component[pie1]".
+	Path string
+	// Message describes the finding.
+	Message string
+}
+
+// String implements fmt.Stringer.
+func (w Warning) String() string { return w.Path + ": " + w.Message }
+
+// LintOptions sets the soft limits Lint checks in addition to deprecation
+// annotations. A zero limit disables its check.
+type LintOptions struct {
+	// MaxComponentsPerUpdate warns when one surfaceUpdate carries more
+	// components than this.
+	MaxComponentsPerUpdate int
+	// MaxMessageBytes warns when a single message serializes larger than
+	// this.
+	MaxMessageBytes int
+}
+
+// Lint checks schema-valid messages for warning-level findings: uses of
+// properties the catalog marks "deprecated": true, and soft limits from
+// opts. Unlike schema validation, findings here need not block sending —
+// that policy is the caller's.
+func Lint(schema map[string]any, messages []map[string]any, opts LintOptions) []Warning {
+	var warnings []Warning
+	componentDefs := map[string]any{}
+	if wrapper, err := componentWrapper(schema); err == nil {
+		componentDefs, _ = wrapper["properties"].(map[string]any)
+	}
+
+	for i, msg := range messages {
+		if opts.MaxMessageBytes > 0 {
+			// Encode into a counter instead of a buffer; the size is all
+			// this check needs.
+			var counter countingWriter
+			if json.NewEncoder(&counter).Encode(msg) == nil && counter.n > opts.MaxMessageBytes {
+				warnings = append(warnings, Warning{
+					Path:    fmt.Sprintf("messages[%d]", i),
+					Message: fmt.Sprintf("message is %d bytes, over the soft limit of %d", counter.n, opts.MaxMessageBytes),
+				})
+			}
+		}
+		update, _ := msg["surfaceUpdate"].(map[string]any)
+		if update == nil {
+			continue
+		}
+		components, _ := update["components"].([]any)
+		if opts.MaxComponentsPerUpdate > 0 && len(components) > opts.MaxComponentsPerUpdate {
+			warnings = append(warnings, Warning{
+				Path:    fmt.Sprintf("messages[%d]", i),
+				Message: fmt.Sprintf("surfaceUpdate has %d components, over the soft limit of %d", len(components), opts.MaxComponentsPerUpdate),
+			})
+		}
+		for _, entry := range components {
+			wrapper, _ := entry.(map[string]any)
+			component, _ := wrapper["component"].(map[string]any)
+			id, _ := wrapper["id"].(string)
+			for name, value := range component {
+				props, _ := value.(map[string]any)
+				for prop := range props {
+					def := digMap(componentDefs, name, "properties", prop)
+					if deprecated, _ := def["deprecated"].(bool); deprecated {
+						warnings = append(warnings, Warning{
+							Path:    fmt.Sprintf("messages[%d].component[%s]", i, id),
+							Message: fmt.Sprintf("property %q of %s is deprecated", prop, name),
+						})
+					}
+				}
+			}
+		}
+	}
+	return warnings
+}
+
+// countingWriter counts bytes written without retaining them.
+type countingWriter struct{ n int }
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += len(p)
+	return len(p), nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLintDeprecatedProperty(t *testing.T) {
+	schema, err := LoadA2UISchema(&Catalog{
+		ID: "test",
+		Components: map[string]any{
+			"Chart": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"title":  map[string]any{"type": "string"},
+					"legacy": map[string]any{"type": "string", "deprecated": true},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := []map[string]any{
+		{"surfaceUpdate": map[string]any{"surfaceId": "s1", "components": []any{
+			map[string]any{"id": "c1", "component": map[string]any{"Chart": map[string]any{
+				"title":  "Sales",
+				"legacy": "old",
+			}}},
+		}}},
+	}
+
+	warnings := Lint(schema, messages, LintOptions{})
+	if len(warnings) != 1 {
+		t.Fatalf("got %d warnings, want 1: %v", len(warnings), warnings)
+	}
+	if !strings.Contains(warnings[0].Message, "legacy") || !strings.Contains(warnings[0].Path, "c1") {
+		t.Errorf("warning = %v", warnings[0])
+	}
+}
+
+func TestLintSoftLimits(t *testing.T) {
+	schema, err := LoadA2UISchema(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := []map[string]any{
+		{"surfaceUpdate": map[string]any{"surfaceId": "s1", "components": []any{
+			map[string]any{"id": "a", "component": map[string]any{"Text": map[string]any{}}},
+			map[string]any{"id": "b", "component": map[string]any{"Text": map[string]any{}}},
+		}}},
+	}
+
+	warnings := Lint(schema, messages, LintOptions{MaxComponentsPerUpdate: 1, MaxMessageBytes: 10})
+	if len(warnings) != 2 {
+		t.Fatalf("got %d warnings, want component and size: %v", len(warnings), warnings)
+	}
+	if clean := Lint(schema, messages, LintOptions{}); len(clean) != 0 {
+		t.Errorf("disabled limits still warned: %v", clean)
+	}
+}
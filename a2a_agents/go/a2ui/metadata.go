@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// Accessors for the metadata keys the extension defines. Reading from a
+// nil metadata map or past a wrong-typed value yields the zero value;
+// writing allocates the maps as needed, so callers never have to check
+// them first.
+
+// PartMIMEType returns the MIME type recorded in a part's metadata, or ""
+// when the part carries none.
+func PartMIMEType(part a2a.Part) string {
+	mimeType, _ := part.Metadata[MIMETypeKey].(string)
+	return mimeType
+}
+
+// SetPartMIMEType records a part's content MIME type, allocating the
+// metadata map when the part has none.
+func SetPartMIMEType(part *a2a.Part, mimeType string) {
+	if part.Metadata == nil {
+		part.Metadata = map[string]any{}
+	}
+	part.Metadata[MIMETypeKey] = mimeType
+}
+
+// SetClientCapabilities attaches an A2UI capabilities object to a
+// message's metadata, replacing any existing one.
+func SetClientCapabilities(msg *a2a.Message, capabilities map[string]any) {
+	if msg.Metadata == nil {
+		msg.Metadata = map[string]any{}
+	}
+	msg.Metadata[ClientCapabilitiesKey] = capabilities
+}
+
+// SetClientCapability sets one key in a message's A2UI capabilities,
+// creating the metadata and capabilities maps as needed. A wrong-typed
+// value already under the capabilities key is replaced.
+func SetClientCapability(msg *a2a.Message, key string, value any) {
+	capabilities, ok := msg.Metadata[ClientCapabilitiesKey].(map[string]any)
+	if !ok {
+		capabilities = map[string]any{}
+		SetClientCapabilities(msg, capabilities)
+	}
+	capabilities[key] = value
+}
+
+// SetClientSupportedCatalogIDs records the catalog URIs the client can
+// render in the message's capabilities, pairing with
+// ClientSupportedCatalogIDs on the reading side.
+func SetClientSupportedCatalogIDs(msg *a2a.Message, ids []string) {
+	list := make([]any, len(ids))
+	for i, id := range ids {
+		list[i] = id
+	}
+	SetClientCapability(msg, SupportedCatalogIDsKey, list)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func TestPartMIMEType(t *testing.T) {
+	if got := PartMIMEType(a2a.NewTextPart("hi")); got != "" {
+		t.Errorf("PartMIMEType on a metadata-less part = %q, want empty", got)
+	}
+	wrongType := a2a.Part{Kind: "data", Metadata: map[string]any{MIMETypeKey: 42}}
+	if got := PartMIMEType(wrongType); got != "" {
+		t.Errorf("PartMIMEType on a non-string value = %q, want empty", got)
+	}
+	if got := PartMIMEType(NewPart(map[string]any{})); got != MIMEType {
+		t.Errorf("PartMIMEType = %q, want %q", got, MIMEType)
+	}
+}
+
+func TestSetPartMIMEType(t *testing.T) {
+	part := a2a.Part{Kind: "data", Data: map[string]any{}}
+	SetPartMIMEType(&part, MIMEType)
+	if !IsA2UIPart(part) {
+		t.Error("part not recognized as A2UI after SetPartMIMEType")
+	}
+	SetPartMIMEType(&part, "text/plain")
+	if got := PartMIMEType(part); got != "text/plain" {
+		t.Errorf("PartMIMEType after overwrite = %q, want text/plain", got)
+	}
+}
+
+func TestSetClientCapabilities(t *testing.T) {
+	msg := &a2a.Message{}
+	SetClientCapabilities(msg, map[string]any{LocaleKey: "de-DE"})
+	if got := ClientLocale(msg); got != "de-DE" {
+		t.Errorf("ClientLocale = %q, want de-DE", got)
+	}
+	SetClientCapabilities(msg, map[string]any{ThemeKey: ThemeDark})
+	if got := ClientLocale(msg); got != "" {
+		t.Errorf("replaced capabilities still report locale %q", got)
+	}
+	if got := ClientTheme(msg); got != ThemeDark {
+		t.Errorf("ClientTheme = %q, want %q", got, ThemeDark)
+	}
+}
+
+func TestSetClientCapability(t *testing.T) {
+	msg := &a2a.Message{}
+	SetClientCapability(msg, LocaleKey, "en-US")
+	SetClientCapability(msg, TimeZoneKey, "America/New_York")
+	if got := ClientLocale(msg); got != "en-US" {
+		t.Errorf("ClientLocale = %q, want en-US", got)
+	}
+	if got := ClientTimeZone(msg); got != "America/New_York" {
+		t.Errorf("ClientTimeZone = %q, want America/New_York", got)
+	}
+
+	// A wrong-typed value under the capabilities key is replaced, not a
+	// panic.
+	broken := &a2a.Message{Metadata: map[string]any{ClientCapabilitiesKey: "oops"}}
+	SetClientCapability(broken, LocaleKey, "fr-FR")
+	if got := ClientLocale(broken); got != "fr-FR" {
+		t.Errorf("ClientLocale after repair = %q, want fr-FR", got)
+	}
+}
+
+func TestSetClientSupportedCatalogIDs(t *testing.T) {
+	msg := &a2a.Message{}
+	SetClientSupportedCatalogIDs(msg, []string{StandardCatalogID, "https://example.com/catalog.json"})
+	ids := ClientSupportedCatalogIDs(msg)
+	if len(ids) != 2 || ids[0] != StandardCatalogID {
+		t.Errorf("ClientSupportedCatalogIDs = %v", ids)
+	}
+}
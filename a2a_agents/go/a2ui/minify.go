@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import "encoding/json"
+
+// MinifyOptions selects which annotation keywords MinifySchema keeps. The
+// zero value strips them all.
+type MinifyOptions struct {
+	KeepDescriptions bool
+	KeepExamples     bool
+	KeepTitles       bool
+}
+
+// MinifySchema returns a copy of the schema with annotation-only keywords
+// (description, examples, title) stripped, per the options. The injected
+// schema dominates prompt cost, and these keywords carry no constraints, so
+// prompts embed the minified copy while validation keeps the full schema.
+func MinifySchema(schema map[string]any, opts MinifyOptions) (map[string]any, error) {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var clone map[string]any
+	if err := json.Unmarshal(raw, &clone); err != nil {
+		return nil, err
+	}
+	var strip []string
+	if !opts.KeepDescriptions {
+		strip = append(strip, "description")
+	}
+	if !opts.KeepExamples {
+		strip = append(strip, "examples")
+	}
+	if !opts.KeepTitles {
+		strip = append(strip, "title")
+	}
+	minifyNode(clone, strip, true)
+	return clone, nil
+}
+
+// minifyNode strips the given keys from every schema object in the tree.
+// isSchema is false for maps whose keys are user-chosen names (the values of
+// "properties" and "$defs"), so a property that happens to be called
+// "description" is not deleted.
+func minifyNode(node map[string]any, strip []string, isSchema bool) {
+	if isSchema {
+		for _, key := range strip {
+			delete(node, key)
+		}
+	}
+	for key, value := range node {
+		childIsSchema := true
+		if isSchema && (key == "properties" || key == "$defs") {
+			childIsSchema = false
+		}
+		switch child := value.(type) {
+		case map[string]any:
+			minifyNode(child, strip, childIsSchema)
+		case []any:
+			if isSchema && (key == "enum" || key == "required" || key == "examples") {
+				continue
+			}
+			for _, elem := range child {
+				if m, ok := elem.(map[string]any); ok {
+					minifyNode(m, strip, true)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func minifyTestSchema(t *testing.T) map[string]any {
+	t.Helper()
+	raw := `{
+	  "title": "Root",
+	  "description": "The root schema.",
+	  "type": "object",
+	  "properties": {
+	    "description": {"type": "string", "description": "A property that is literally named description."},
+	    "items": {
+	      "type": "array",
+	      "items": {"type": "string", "examples": ["a", "b"], "title": "Item"}
+	    }
+	  },
+	  "$defs": {
+	    "title": {"type": "string", "description": "A def named title."}
+	  }
+	}`
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(raw), &schema); err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestMinifySchemaStripsAnnotations(t *testing.T) {
+	schema := minifyTestSchema(t)
+	minified, err := MinifySchema(schema, MinifyOptions{})
+	if err != nil {
+		t.Fatalf("MinifySchema: %v", err)
+	}
+	raw, _ := json.Marshal(minified)
+	for _, gone := range []string{"The root schema.", "A property that is literally", "A def named title.", `"examples"`, `"Item"`} {
+		if strings.Contains(string(raw), gone) {
+			t.Errorf("minified schema still contains %q", gone)
+		}
+	}
+	// Property and $defs names that collide with stripped keywords survive.
+	props := minified["properties"].(map[string]any)
+	if _, ok := props["description"]; !ok {
+		t.Error("property named description was deleted")
+	}
+	defs := minified["$defs"].(map[string]any)
+	if _, ok := defs["title"]; !ok {
+		t.Error("$defs entry named title was deleted")
+	}
+	// The original schema is untouched.
+	if _, ok := schema["description"]; !ok {
+		t.Error("MinifySchema mutated its input")
+	}
+}
+
+func TestMinifySchemaKeepOptions(t *testing.T) {
+	minified, err := MinifySchema(minifyTestSchema(t), MinifyOptions{KeepDescriptions: true})
+	if err != nil {
+		t.Fatalf("MinifySchema: %v", err)
+	}
+	raw, _ := json.Marshal(minified)
+	if !strings.Contains(string(raw), "The root schema.") {
+		t.Error("KeepDescriptions did not keep descriptions")
+	}
+	if strings.Contains(string(raw), `"title":"Root"`) {
+		t.Error("titles survived without KeepTitles")
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// DataModelLimits caps how large a surface's data model may grow, so a
+// table with thousands of rows is paginated (see Paginator) instead of
+// inlined wholesale. Zero fields leave the corresponding dimension
+// unlimited.
+type DataModelLimits struct {
+	// MaxBytes caps the JSON-encoded size of the model.
+	MaxBytes int
+	// MaxEntries caps the number of leaf values in the model.
+	MaxEntries int
+}
+
+// Check reports whether the model fits the limits, with an error naming
+// the exceeded dimension when it does not.
+func (l DataModelLimits) Check(model map[string]any) error {
+	if l.MaxBytes > 0 {
+		encoded, err := a2a.ActiveCodec().Marshal(model)
+		if err != nil {
+			return fmt.Errorf("a2ui: encoding data model: %w", err)
+		}
+		if len(encoded) > l.MaxBytes {
+			return fmt.Errorf("a2ui: data model is %d bytes, over the %d-byte limit; paginate large datasets", len(encoded), l.MaxBytes)
+		}
+	}
+	if l.MaxEntries > 0 {
+		if n := countLeaves(model); n > l.MaxEntries {
+			return fmt.Errorf("a2ui: data model has %d entries, over the %d-entry limit; paginate large datasets", n, l.MaxEntries)
+		}
+	}
+	return nil
+}
+
+// countLeaves counts the scalar values in a data-model tree.
+func countLeaves(model map[string]any) int {
+	n := 0
+	for _, value := range model {
+		if nested, ok := value.(map[string]any); ok {
+			n += countLeaves(nested)
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// PageActionName is the userAction name the framework reserves for page
+// requests. A "next page" button binds its action context to the
+// surface's stored token: {"surfaceId": ..., "pageToken": ...}.
+const PageActionName = "a2uiRequestPage"
+
+// PageProvider returns one page of rows for a paginated list. Each row
+// is a flat map of scalars. An empty token names the first page; the
+// returned nextToken is "" once no further pages exist.
+type PageProvider func(ctx context.Context, pageToken string) (rows []map[string]any, nextToken string, err error)
+
+// Paginator pages large datasets into surfaces one window at a time.
+// Register wires a surface's list to its data provider; Page fills the
+// first window when the surface is built, and HandleAction answers the
+// PageActionName userAction a "next page" button produces. Each page
+// writes its rows under <path>/rows/<index>, the row count at
+// <path>/rowCount, and the token for the following page at
+// <path>/nextPageToken — where the client's next request picks it up.
+type Paginator struct {
+	mu    sync.Mutex
+	lists map[string]paginatedList
+}
+
+type paginatedList struct {
+	path     string
+	provider PageProvider
+}
+
+// NewPaginator returns an empty paginator.
+func NewPaginator() *Paginator {
+	return &Paginator{lists: map[string]paginatedList{}}
+}
+
+// Register wires the surface's list, rooted at the data-model path, to
+// its provider. A surface holds at most one paginated list; registering
+// again replaces it.
+func (p *Paginator) Register(surfaceID, path string, provider PageProvider) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lists[surfaceID] = paginatedList{path: path, provider: provider}
+}
+
+// Unregister drops the surface's list, e.g. when the surface is closed.
+func (p *Paginator) Unregister(surfaceID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.lists, surfaceID)
+}
+
+// Page fetches the page named by the token and returns the
+// dataModelUpdate messages writing it into the surface.
+func (p *Paginator) Page(ctx context.Context, surfaceID, pageToken string) ([]map[string]any, error) {
+	p.mu.Lock()
+	list, ok := p.lists[surfaceID]
+	p.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("a2ui: surface %q has no paginated list", surfaceID)
+	}
+	rows, nextToken, err := list.provider(ctx, pageToken)
+	if err != nil {
+		return nil, fmt.Errorf("a2ui: fetching page for surface %q: %w", surfaceID, err)
+	}
+	updates := make([]map[string]any, 0, len(rows)+2)
+	for i, row := range rows {
+		update, err := dataModelUpdate(surfaceID, fmt.Sprintf("%s/rows/%d", list.path, i), row)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, update)
+	}
+	count, err := dataModelUpdate(surfaceID, list.path+"/rowCount", len(rows))
+	if err != nil {
+		return nil, err
+	}
+	token, err := dataModelUpdate(surfaceID, list.path+"/nextPageToken", nextToken)
+	if err != nil {
+		return nil, err
+	}
+	return append(updates, count, token), nil
+}
+
+// HandleAction answers a page-request userAction. handled reports
+// whether the action was one: other actions, and page requests for
+// surfaces with no registered list, pass through for the executor to
+// handle.
+func (p *Paginator) HandleAction(ctx context.Context, action map[string]any) (updates []map[string]any, handled bool, err error) {
+	if action == nil || action["name"] != PageActionName {
+		return nil, false, nil
+	}
+	actionContext, _ := action["context"].(map[string]any)
+	surfaceID, _ := actionContext["surfaceId"].(string)
+	p.mu.Lock()
+	_, ok := p.lists[surfaceID]
+	p.mu.Unlock()
+	if !ok {
+		return nil, false, nil
+	}
+	pageToken, _ := actionContext["pageToken"].(string)
+	updates, err = p.Page(ctx, surfaceID, pageToken)
+	return updates, true, err
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDataModelLimits(t *testing.T) {
+	model := map[string]any{
+		"title": "orders",
+		"rows":  map[string]any{"0": "a", "1": "b", "2": "c"},
+	}
+	if err := (DataModelLimits{}).Check(model); err != nil {
+		t.Errorf("zero limits rejected a model: %v", err)
+	}
+	if err := (DataModelLimits{MaxEntries: 4}).Check(model); err != nil {
+		t.Errorf("model under the entry limit rejected: %v", err)
+	}
+	if err := (DataModelLimits{MaxEntries: 3}).Check(model); err == nil {
+		t.Error("model over the entry limit passed")
+	}
+	if err := (DataModelLimits{MaxBytes: 16}).Check(model); err == nil {
+		t.Error("model over the byte limit passed")
+	}
+
+	manager := NewSurfaceManager(0)
+	manager.SetDataModelLimits(DataModelLimits{MaxEntries: 2})
+	if _, err := manager.SetDataModel("main", model); err == nil {
+		t.Error("SetDataModel accepted a model over the limits")
+	}
+}
+
+// ordersProvider pages a fixed dataset two rows at a time, using the
+// next row index as the token.
+func ordersProvider(ctx context.Context, pageToken string) ([]map[string]any, string, error) {
+	all := []map[string]any{
+		{"item": "apparel", "total": 100.0},
+		{"item": "shoes", "total": 40.0},
+		{"item": "hats", "total": 15.0},
+	}
+	start := 0
+	if pageToken == "2" {
+		start = 2
+	}
+	end := start + 2
+	if end >= len(all) {
+		return all[start:], "", nil
+	}
+	return all[start:end], "2", nil
+}
+
+func TestPaginatorPages(t *testing.T) {
+	paginator := NewPaginator()
+	paginator.Register("main", "/orders", ordersProvider)
+
+	updates, err := paginator.Page(context.Background(), "main", "")
+	if err != nil {
+		t.Fatalf("Page: %v", err)
+	}
+	// Two row updates plus rowCount and nextPageToken.
+	if len(updates) != 4 {
+		t.Fatalf("got %d updates, want 4: %v", len(updates), updates)
+	}
+	token := updates[3]["dataModelUpdate"].(map[string]any)
+	if token["path"] != "/orders" {
+		t.Errorf("token path = %v, want /orders", token["path"])
+	}
+	entry := token["contents"].([]any)[0].(map[string]any)
+	if entry["key"] != "nextPageToken" || entry["valueString"] != "2" {
+		t.Errorf("token entry = %v", entry)
+	}
+
+	// The client echoes the stored token in its next page request.
+	updates, handled, err := paginator.HandleAction(context.Background(), map[string]any{
+		"name":    PageActionName,
+		"context": map[string]any{"surfaceId": "main", "pageToken": "2"},
+	})
+	if err != nil || !handled {
+		t.Fatalf("HandleAction: handled=%v err=%v", handled, err)
+	}
+	// One row left plus rowCount and a cleared token.
+	if len(updates) != 3 {
+		t.Fatalf("got %d updates, want 3: %v", len(updates), updates)
+	}
+	last := updates[2]["dataModelUpdate"].(map[string]any)
+	entry = last["contents"].([]any)[0].(map[string]any)
+	if entry["valueString"] != "" {
+		t.Errorf("final page token = %v, want empty", entry["valueString"])
+	}
+}
+
+func TestPaginatorPassesThroughOtherActions(t *testing.T) {
+	paginator := NewPaginator()
+	paginator.Register("main", "/orders", ordersProvider)
+
+	if _, handled, _ := paginator.HandleAction(context.Background(), map[string]any{
+		"name": "submit",
+	}); handled {
+		t.Error("non-page action was handled")
+	}
+	if _, handled, _ := paginator.HandleAction(context.Background(), map[string]any{
+		"name":    PageActionName,
+		"context": map[string]any{"surfaceId": "other"},
+	}); handled {
+		t.Error("page request for an unregistered surface was handled")
+	}
+
+	paginator.Unregister("main")
+	if _, err := paginator.Page(context.Background(), "main", ""); err == nil || !strings.Contains(err.Error(), "no paginated list") {
+		t.Errorf("Page after Unregister = %v, want no-list error", err)
+	}
+}
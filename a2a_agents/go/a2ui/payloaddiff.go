@@ -0,0 +1,232 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PayloadChange is one difference between two A2UI payloads: a
+// component or data-model path that was added, removed or changed.
+type PayloadChange struct {
+	// Kind is "added", "removed" or "changed".
+	Kind string
+	// Surface identifies the surface the change belongs to.
+	Surface string
+	// Component is the changed component's id; empty for data-model
+	// changes.
+	Component string
+	// Path is the changed data-model path; empty for component changes.
+	Path string
+	// Detail describes the change, e.g. the before and after values.
+	Detail string
+}
+
+// String implements fmt.Stringer.
+func (c PayloadChange) String() string {
+	subject := "component " + c.Component
+	if c.Path != "" {
+		subject = "data " + c.Path
+	}
+	s := fmt.Sprintf("surface %s: %s %s", c.Surface, subject, c.Kind)
+	if c.Detail != "" {
+		s += ": " + c.Detail
+	}
+	return s
+}
+
+// DiffPayloads structurally compares two A2UI payloads (message slices
+// in emission order) and reports the components and data-model paths
+// that differ once each payload's updates are applied. Equal payloads
+// produce no changes; the result is sorted by surface, then subject.
+func DiffPayloads(before, after []map[string]any) []PayloadChange {
+	var changes []PayloadChange
+	changes = append(changes, diffComponents(collectComponents(before), collectComponents(after))...)
+	changes = append(changes, diffDataModels(collectDataModel(before), collectDataModel(after))...)
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Surface != b.Surface {
+			return a.Surface < b.Surface
+		}
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		return a.Path < b.Path
+	})
+	return changes
+}
+
+// collectComponents applies a payload's surfaceUpdates in order,
+// returning each surface's final components keyed by id. Later updates
+// of the same id win, matching client behavior.
+func collectComponents(messages []map[string]any) map[string]map[string]map[string]any {
+	surfaces := map[string]map[string]map[string]any{}
+	for _, msg := range messages {
+		update, _ := msg["surfaceUpdate"].(map[string]any)
+		if update == nil {
+			continue
+		}
+		surfaceID, _ := update["surfaceId"].(string)
+		components, _ := update["components"].([]any)
+		byID := surfaces[surfaceID]
+		if byID == nil {
+			byID = map[string]map[string]any{}
+			surfaces[surfaceID] = byID
+		}
+		for _, entry := range components {
+			wrapper, _ := entry.(map[string]any)
+			if id, _ := wrapper["id"].(string); id != "" {
+				byID[id], _ = wrapper["component"].(map[string]any)
+			}
+		}
+	}
+	return surfaces
+}
+
+func diffComponents(before, after map[string]map[string]map[string]any) []PayloadChange {
+	var changes []PayloadChange
+	for surfaceID, beforeByID := range before {
+		afterByID := after[surfaceID]
+		for id, beforeComponent := range beforeByID {
+			afterComponent, ok := afterByID[id]
+			if !ok {
+				changes = append(changes, PayloadChange{
+					Kind: "removed", Surface: surfaceID, Component: id,
+					Detail: string(mustCanonical(beforeComponent)),
+				})
+				continue
+			}
+			beforeCanonical := mustCanonical(beforeComponent)
+			afterCanonical := mustCanonical(afterComponent)
+			if string(beforeCanonical) != string(afterCanonical) {
+				changes = append(changes, PayloadChange{
+					Kind: "changed", Surface: surfaceID, Component: id,
+					Detail: fmt.Sprintf("%s -> %s", beforeCanonical, afterCanonical),
+				})
+			}
+		}
+	}
+	for surfaceID, afterByID := range after {
+		beforeByID := before[surfaceID]
+		for id, afterComponent := range afterByID {
+			if _, ok := beforeByID[id]; !ok {
+				changes = append(changes, PayloadChange{
+					Kind: "added", Surface: surfaceID, Component: id,
+					Detail: string(mustCanonical(afterComponent)),
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// collectDataModel applies a payload's dataModelUpdates in order,
+// returning each surface's leaf values keyed by full path.
+func collectDataModel(messages []map[string]any) map[string]map[string]any {
+	surfaces := map[string]map[string]any{}
+	for _, msg := range messages {
+		update, _ := msg["dataModelUpdate"].(map[string]any)
+		if update == nil {
+			continue
+		}
+		surfaceID, _ := update["surfaceId"].(string)
+		path, _ := update["path"].(string)
+		if path == "" {
+			path = "/"
+		}
+		values := surfaces[surfaceID]
+		if values == nil {
+			values = map[string]any{}
+			surfaces[surfaceID] = values
+		}
+		contents, _ := update["contents"].([]any)
+		applyContents(values, path, contents)
+	}
+	return surfaces
+}
+
+// applyContents writes contents entries under parent, descending into
+// valueMap adjacency lists so leaves land at their full paths.
+func applyContents(values map[string]any, parent string, contents []any) {
+	if parent == "/" {
+		parent = ""
+	}
+	for _, entry := range contents {
+		m, _ := entry.(map[string]any)
+		key, _ := m["key"].(string)
+		if key == "" {
+			continue
+		}
+		path := parent + "/" + key
+		if nested, ok := m["valueMap"].([]any); ok {
+			applyContents(values, path, nested)
+			continue
+		}
+		for _, field := range []string{"valueString", "valueNumber", "valueBoolean"} {
+			if value, ok := m[field]; ok {
+				values[path] = value
+				break
+			}
+		}
+	}
+}
+
+func diffDataModels(before, after map[string]map[string]any) []PayloadChange {
+	var changes []PayloadChange
+	for surfaceID, beforeValues := range before {
+		afterValues := after[surfaceID]
+		for path, beforeValue := range beforeValues {
+			afterValue, ok := afterValues[path]
+			if !ok {
+				changes = append(changes, PayloadChange{
+					Kind: "removed", Surface: surfaceID, Path: path,
+					Detail: string(mustCanonical(beforeValue)),
+				})
+				continue
+			}
+			beforeCanonical := mustCanonical(beforeValue)
+			afterCanonical := mustCanonical(afterValue)
+			if string(beforeCanonical) != string(afterCanonical) {
+				changes = append(changes, PayloadChange{
+					Kind: "changed", Surface: surfaceID, Path: path,
+					Detail: fmt.Sprintf("%s -> %s", beforeCanonical, afterCanonical),
+				})
+			}
+		}
+	}
+	for surfaceID, afterValues := range after {
+		beforeValues := before[surfaceID]
+		for path, afterValue := range afterValues {
+			if _, ok := beforeValues[path]; !ok {
+				changes = append(changes, PayloadChange{
+					Kind: "added", Surface: surfaceID, Path: path,
+					Detail: string(mustCanonical(afterValue)),
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// mustCanonical renders a value canonically for change details, falling
+// back to fmt for values that cannot marshal.
+func mustCanonical(value any) []byte {
+	canonical, err := CanonicalJSON(value)
+	if err != nil {
+		return []byte(fmt.Sprintf("%v", value))
+	}
+	return canonical
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func diffPayload(title string, count float64) []map[string]any {
+	return []map[string]any{
+		{"surfaceUpdate": map[string]any{
+			"surfaceId": "main",
+			"components": []any{
+				map[string]any{"id": "title", "component": map[string]any{
+					"Text": map[string]any{"text": map[string]any{"literalString": title}},
+				}},
+			},
+		}},
+		{"dataModelUpdate": map[string]any{
+			"surfaceId": "main",
+			"path":      "/",
+			"contents": []any{
+				map[string]any{"key": "count", "valueNumber": count},
+			},
+		}},
+	}
+}
+
+func TestDiffPayloadsEqual(t *testing.T) {
+	if changes := DiffPayloads(diffPayload("Hello", 1), diffPayload("Hello", 1)); len(changes) != 0 {
+		t.Errorf("equal payloads diff to %v", changes)
+	}
+}
+
+func TestDiffPayloadsChanges(t *testing.T) {
+	changes := DiffPayloads(diffPayload("Hello", 1), diffPayload("Hi", 2))
+	if len(changes) != 2 {
+		t.Fatalf("DiffPayloads = %v, want a component and a data change", changes)
+	}
+	component := changes[1]
+	if component.Kind != "changed" || component.Component != "title" || !strings.Contains(component.Detail, "->") {
+		t.Errorf("component change = %+v", component)
+	}
+	data := changes[0]
+	if data.Kind != "changed" || data.Path != "/count" || !strings.Contains(data.Detail, "1 -> 2") {
+		t.Errorf("data change = %+v", data)
+	}
+}
+
+func TestDiffPayloadsAddedRemoved(t *testing.T) {
+	before := diffPayload("Hello", 1)
+	after := diffPayload("Hello", 1)
+	after[0]["surfaceUpdate"].(map[string]any)["components"] = append(
+		after[0]["surfaceUpdate"].(map[string]any)["components"].([]any),
+		map[string]any{"id": "footer", "component": map[string]any{
+			"Text": map[string]any{"text": map[string]any{"literalString": "bye"}},
+		}},
+	)
+	after[1]["dataModelUpdate"].(map[string]any)["contents"] = []any{
+		map[string]any{"key": "user", "valueMap": []any{
+			map[string]any{"key": "name", "valueString": "Ada"},
+		}},
+	}
+
+	changes := DiffPayloads(before, after)
+	kinds := map[string]string{}
+	for _, change := range changes {
+		subject := change.Component + change.Path
+		kinds[subject] = change.Kind
+	}
+	if kinds["footer"] != "added" {
+		t.Errorf("footer = %q, want added (changes: %v)", kinds["footer"], changes)
+	}
+	if kinds["/count"] != "removed" {
+		t.Errorf("/count = %q, want removed (changes: %v)", kinds["/count"], changes)
+	}
+	if kinds["/user/name"] != "added" {
+		t.Errorf("/user/name = %q, want added (changes: %v)", kinds["/user/name"], changes)
+	}
+}
+
+func TestDiffPayloadsLaterUpdatesWin(t *testing.T) {
+	// A second surfaceUpdate that rewrites a component counts as the
+	// final state, not a separate change.
+	before := diffPayload("Hello", 1)
+	after := append(diffPayload("Hi", 1), map[string]any{
+		"surfaceUpdate": map[string]any{
+			"surfaceId": "main",
+			"components": []any{
+				map[string]any{"id": "title", "component": map[string]any{
+					"Text": map[string]any{"text": map[string]any{"literalString": "Hello"}},
+				}},
+			},
+		},
+	})
+	if changes := DiffPayloads(before, after); len(changes) != 0 {
+		t.Errorf("payloads converging to the same state diff to %v", changes)
+	}
+}
+
+func TestPayloadChangeString(t *testing.T) {
+	change := PayloadChange{Kind: "changed", Surface: "main", Path: "/count", Detail: "1 -> 2"}
+	want := "surface main: data /count changed: 1 -> 2"
+	if got := change.String(); got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+}
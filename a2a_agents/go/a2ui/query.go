@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FindByID returns the component with the given id from a surfaceUpdate
+// payload, or nil when the surface has no such component. The returned
+// node is live: mutating its Props or calling Replace edits the payload.
+func FindByID(update map[string]any, id string) *ComponentNode {
+	var found *ComponentNode
+	WalkSurface(update, func(n *ComponentNode) error {
+		if n.ID == id {
+			found = n
+			return SkipChildren
+		}
+		return nil
+	}, nil)
+	return found
+}
+
+// FindByType returns every component of the given type name from a
+// surfaceUpdate payload, in traversal order.
+func FindByType(update map[string]any, typeName string) []*ComponentNode {
+	return collect(update, func(n *ComponentNode) bool { return n.Type == typeName })
+}
+
+// Query returns the components of a surfaceUpdate payload matching a
+// CSS-like selector. A simple selector is a type name ("Text"), an id
+// ("#page_header"), both ("Text#page_header"), or "*"; simple selectors
+// combine with " " (descendant) and ">" (direct child). Components
+// reachable through more than one parent are reported once.
+func Query(update map[string]any, selector string) ([]*ComponentNode, error) {
+	parts, combinators, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	last := len(parts) - 1
+	return collect(update, func(n *ComponentNode) bool {
+		return matchesSelector(n, parts, combinators, last)
+	}), nil
+}
+
+// collect walks the surface and gathers matching nodes, keeping the
+// first visit of components the walk reaches through several parents.
+func collect(update map[string]any, match func(*ComponentNode) bool) []*ComponentNode {
+	var nodes []*ComponentNode
+	seen := map[string]bool{}
+	WalkSurface(update, func(n *ComponentNode) error {
+		if match(n) && !seen[n.ID] {
+			seen[n.ID] = true
+			nodes = append(nodes, n)
+		}
+		return nil
+	}, nil)
+	return nodes
+}
+
+// simpleSelector matches one component by type name and/or id; empty
+// fields match anything.
+type simpleSelector struct {
+	typeName string
+	id       string
+}
+
+func (s simpleSelector) matches(n *ComponentNode) bool {
+	return (s.typeName == "" || s.typeName == n.Type) && (s.id == "" || s.id == n.ID)
+}
+
+// parseSelector splits a selector into its simple selectors and the
+// combinators between them (' ' for descendant, '>' for child).
+func parseSelector(selector string) ([]simpleSelector, []byte, error) {
+	tokens := strings.Fields(strings.ReplaceAll(selector, ">", " > "))
+	var parts []simpleSelector
+	var combinators []byte
+	child := false
+	for _, token := range tokens {
+		if token == ">" {
+			if child || len(parts) == 0 {
+				return nil, nil, fmt.Errorf("a2ui: selector %q: misplaced '>'", selector)
+			}
+			child = true
+			continue
+		}
+		part, err := parseSimpleSelector(token)
+		if err != nil {
+			return nil, nil, fmt.Errorf("a2ui: selector %q: %w", selector, err)
+		}
+		if len(parts) > 0 {
+			if child {
+				combinators = append(combinators, '>')
+			} else {
+				combinators = append(combinators, ' ')
+			}
+		}
+		child = false
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 || child {
+		return nil, nil, fmt.Errorf("a2ui: selector %q is incomplete", selector)
+	}
+	return parts, combinators, nil
+}
+
+func parseSimpleSelector(token string) (simpleSelector, error) {
+	if token == "*" {
+		return simpleSelector{}, nil
+	}
+	typeName, id, hasID := strings.Cut(token, "#")
+	if typeName == "" && !hasID {
+		return simpleSelector{}, fmt.Errorf("empty simple selector")
+	}
+	if hasID && id == "" {
+		return simpleSelector{}, fmt.Errorf("%q names no id", token)
+	}
+	return simpleSelector{typeName: typeName, id: id}, nil
+}
+
+// matchesSelector reports whether the node matches parts[i] with its
+// ancestors satisfying the earlier simple selectors and combinators.
+func matchesSelector(n *ComponentNode, parts []simpleSelector, combinators []byte, i int) bool {
+	if !parts[i].matches(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	if combinators[i-1] == '>' {
+		return n.Parent != nil && matchesSelector(n.Parent, parts, combinators, i-1)
+	}
+	for ancestor := n.Parent; ancestor != nil; ancestor = ancestor.Parent {
+		if matchesSelector(ancestor, parts, combinators, i-1) {
+			return true
+		}
+	}
+	return false
+}
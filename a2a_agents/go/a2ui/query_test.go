@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import "testing"
+
+func queryIDs(nodes []*ComponentNode) []string {
+	ids := make([]string, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+	return ids
+}
+
+func TestFindByID(t *testing.T) {
+	update := walkSurfaceUpdate()
+	node := FindByID(update, "title")
+	if node == nil || node.Type != "Text" {
+		t.Fatalf("FindByID(title) = %v, want the Text component", node)
+	}
+	if FindByID(update, "missing") != nil {
+		t.Error("FindByID found a component that does not exist")
+	}
+
+	// The node is live: edits reach the payload.
+	node.Props["usageHint"] = "h1"
+	text := update["components"].([]any)[1].(map[string]any)["component"].(map[string]any)["Text"].(map[string]any)
+	if text["usageHint"] != "h1" {
+		t.Error("mutating the found node did not edit the payload")
+	}
+}
+
+func TestFindByType(t *testing.T) {
+	nodes := FindByType(walkSurfaceUpdate(), "Card")
+	if got := queryIDs(nodes); len(got) != 1 || got[0] != "card" {
+		t.Errorf("FindByType(Card) = %v, want [card]", got)
+	}
+	if nodes := FindByType(walkSurfaceUpdate(), "Slider"); len(nodes) != 0 {
+		t.Errorf("FindByType(Slider) = %v, want none", queryIDs(nodes))
+	}
+}
+
+func TestQuery(t *testing.T) {
+	update := walkSurfaceUpdate()
+	for _, tc := range []struct {
+		selector string
+		want     []string
+	}{
+		{"Text", []string{"title"}},
+		{"#ok", []string{"ok"}},
+		{"Text#title", []string{"title"}},
+		{"Card > Button", []string{"ok"}},
+		{"Column Button", []string{"ok"}},
+		{"Column > Button", nil},
+		{"*", []string{"root", "title", "card", "ok"}},
+		{"Button > Text", []string{"title"}},
+	} {
+		nodes, err := Query(update, tc.selector)
+		if err != nil {
+			t.Errorf("Query(%q): %v", tc.selector, err)
+			continue
+		}
+		got := queryIDs(nodes)
+		if len(got) != len(tc.want) {
+			t.Errorf("Query(%q) = %v, want %v", tc.selector, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("Query(%q) = %v, want %v", tc.selector, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestQueryInvalidSelector(t *testing.T) {
+	for _, selector := range []string{"", ">", "Card >", "> Button", "Card > > Button", "#"} {
+		if _, err := Query(walkSurfaceUpdate(), selector); err == nil {
+			t.Errorf("Query(%q) accepted an invalid selector", selector)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DataModelEdit is one change to a surface's data model.
+type DataModelEdit struct {
+	// Path is the JSON Pointer-style location of the change, e.g.
+	// "/form/name".
+	Path string
+	// Value is the new value at Path.
+	Value any
+	// Time is when the edit was made; between concurrent edits of the same
+	// path, the later one wins.
+	Time time.Time
+}
+
+// ConflictFunc resolves a path both sides edited concurrently and returns
+// the edit to keep. A nil ConflictFunc falls back to last-writer-wins by
+// edit time, with the server winning ties.
+type ConflictFunc func(path string, client, server DataModelEdit) DataModelEdit
+
+// Reconciliation is the outcome of merging concurrent data-model edits.
+type Reconciliation struct {
+	// Merged maps each edited path to its winning value; the server side
+	// should adopt it as the surface's current state.
+	Merged map[string]any
+	// Updates are the dataModelUpdate messages to send so the client
+	// converges on Merged. Paths the client already has the winning value
+	// for produce no update.
+	Updates []map[string]any
+}
+
+// Reconcile merges client-side data-model edits with server-side updates
+// for one surface, last-writer-wins per path. When both sides edited the
+// same path, resolve picks the winner (nil resolves by edit time). The
+// returned updates bring the client to the merged state; edits the client
+// made and won need no round trip.
+func Reconcile(surfaceID string, client, server []DataModelEdit, resolve ConflictFunc) (Reconciliation, error) {
+	clientByPath := latestByPath(client)
+	serverByPath := latestByPath(server)
+
+	paths := make([]string, 0, len(clientByPath)+len(serverByPath))
+	for path := range clientByPath {
+		paths = append(paths, path)
+	}
+	for path := range serverByPath {
+		if _, ok := clientByPath[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	out := Reconciliation{Merged: make(map[string]any, len(paths))}
+	for _, path := range paths {
+		clientEdit, fromClient := clientByPath[path]
+		serverEdit, fromServer := serverByPath[path]
+		var winner DataModelEdit
+		clientWon := false
+		switch {
+		case fromClient && !fromServer:
+			winner, clientWon = clientEdit, true
+		case fromServer && !fromClient:
+			winner = serverEdit
+		case resolve != nil:
+			// A callback may synthesize a merged value the client has not
+			// seen, so its result is always sent.
+			winner = resolve(path, clientEdit, serverEdit)
+		case serverEdit.Time.Before(clientEdit.Time):
+			winner, clientWon = clientEdit, true
+		default:
+			winner = serverEdit
+		}
+		out.Merged[path] = winner.Value
+		if clientWon {
+			// The client already sees its own edit; nothing to send.
+			continue
+		}
+		update, err := dataModelUpdate(surfaceID, path, winner.Value)
+		if err != nil {
+			return Reconciliation{}, err
+		}
+		out.Updates = append(out.Updates, update)
+	}
+	return out, nil
+}
+
+// latestByPath indexes edits by path, keeping only the latest edit to each
+// path within one side.
+func latestByPath(edits []DataModelEdit) map[string]DataModelEdit {
+	byPath := make(map[string]DataModelEdit, len(edits))
+	for _, edit := range edits {
+		if existing, ok := byPath[edit.Path]; ok && edit.Time.Before(existing.Time) {
+			continue
+		}
+		byPath[edit.Path] = edit
+	}
+	return byPath
+}
+
+// DataModelUpdateMessage builds a dataModelUpdate writing one value at
+// path on the surface. Values follow dataModelUpdate's contents rules:
+// scalars and flat maps of scalars.
+func DataModelUpdateMessage(surfaceID, path string, value any) (map[string]any, error) {
+	return dataModelUpdate(surfaceID, path, value)
+}
+
+// ProgressUpdate builds a dataModelUpdate writing a progress fraction,
+// clamped to [0, 1], at path — for surfaces that render a progress bar
+// while a long-running tool works.
+func ProgressUpdate(surfaceID, path string, fraction float64) (map[string]any, error) {
+	if fraction < 0 {
+		fraction = 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	return dataModelUpdate(surfaceID, path, fraction)
+}
+
+func dataModelUpdate(surfaceID, path string, value any) (map[string]any, error) {
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 || path == "/" || path == "" {
+		return nil, fmt.Errorf("a2ui: data-model path %q must name a key below the root", path)
+	}
+	parent, key := path[:idx], path[idx+1:]
+	if parent == "" {
+		parent = "/"
+	}
+	entry, err := dataModelEntry(key, value)
+	if err != nil {
+		return nil, fmt.Errorf("a2ui: data-model path %q: %w", path, err)
+	}
+	return map[string]any{
+		"dataModelUpdate": map[string]any{
+			"surfaceId": surfaceID,
+			"path":      parent,
+			"contents":  []any{entry},
+		},
+	}, nil
+}
+
+// dataModelEntry builds one typed contents entry for a dataModelUpdate.
+func dataModelEntry(key string, value any) (map[string]any, error) {
+	entry := map[string]any{"key": key}
+	switch v := value.(type) {
+	case string:
+		entry["valueString"] = v
+	case bool:
+		entry["valueBoolean"] = v
+	case float64:
+		entry["valueNumber"] = v
+	case float32:
+		entry["valueNumber"] = float64(v)
+	case int:
+		entry["valueNumber"] = float64(v)
+	case int64:
+		entry["valueNumber"] = float64(v)
+	case map[string]any:
+		// Maps are carried as an adjacency list of scalar entries.
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		list := make([]any, 0, len(keys))
+		for _, k := range keys {
+			if _, ok := v[k].(map[string]any); ok {
+				return nil, fmt.Errorf("map value for key %q nests another map; edit the nested path directly", k)
+			}
+			nested, err := dataModelEntry(k, v[k])
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, nested)
+		}
+		entry["valueMap"] = list
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+	return entry, nil
+}
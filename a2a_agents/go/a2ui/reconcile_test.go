@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+func TestReconcileLastWriterWins(t *testing.T) {
+	earlier := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Minute)
+
+	client := []DataModelEdit{
+		{Path: "/form/filter", Value: "last_7_days", Time: later},
+		{Path: "/form/expanded", Value: true, Time: earlier},
+	}
+	server := []DataModelEdit{
+		{Path: "/form/filter", Value: "last_30_days", Time: earlier},
+		{Path: "/form/total", Value: 42.0, Time: later},
+	}
+
+	got, err := Reconcile("main", client, server, nil)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	want := map[string]any{
+		"/form/filter":   "last_7_days", // client edited later
+		"/form/expanded": true,          // client only
+		"/form/total":    42.0,          // server only
+	}
+	for path, value := range want {
+		if got.Merged[path] != value {
+			t.Errorf("Merged[%s] = %v, want %v", path, got.Merged[path], value)
+		}
+	}
+	// Only the server-only path needs a round trip to the client.
+	if len(got.Updates) != 1 {
+		t.Fatalf("got %d updates, want 1: %v", len(got.Updates), got.Updates)
+	}
+	update := got.Updates[0]["dataModelUpdate"].(map[string]any)
+	if update["surfaceId"] != "main" || update["path"] != "/form" {
+		t.Errorf("update = %v", update)
+	}
+	entry := update["contents"].([]any)[0].(map[string]any)
+	if entry["key"] != "total" || entry["valueNumber"] != 42.0 {
+		t.Errorf("entry = %v", entry)
+	}
+}
+
+func TestReconcileServerWinsTies(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	got, err := Reconcile("main",
+		[]DataModelEdit{{Path: "/form/name", Value: "client", Time: at}},
+		[]DataModelEdit{{Path: "/form/name", Value: "server", Time: at}},
+		nil)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if got.Merged["/form/name"] != "server" {
+		t.Errorf("Merged = %v, want the server to win ties", got.Merged)
+	}
+	if len(got.Updates) != 1 {
+		t.Errorf("got %d updates, want 1 correcting the client", len(got.Updates))
+	}
+}
+
+func TestReconcileConflictCallback(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	resolve := func(path string, client, server DataModelEdit) DataModelEdit {
+		// Synthesize a merged value neither side has.
+		return DataModelEdit{Path: path, Value: client.Value.(string) + "+" + server.Value.(string)}
+	}
+	got, err := Reconcile("main",
+		[]DataModelEdit{{Path: "/form/tags", Value: "a", Time: at.Add(time.Hour)}},
+		[]DataModelEdit{{Path: "/form/tags", Value: "b", Time: at}},
+		resolve)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if got.Merged["/form/tags"] != "a+b" {
+		t.Errorf("Merged = %v, want the callback's value", got.Merged)
+	}
+	// A synthesized value must reach the client even though its edit was
+	// later.
+	if len(got.Updates) != 1 {
+		t.Fatalf("got %d updates, want 1", len(got.Updates))
+	}
+}
+
+func TestReconcileKeepsLatestPerSide(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	got, err := Reconcile("main", nil, []DataModelEdit{
+		{Path: "/count", Value: 1.0, Time: at},
+		{Path: "/count", Value: 2.0, Time: at.Add(time.Second)},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if got.Merged["/count"] != 2.0 {
+		t.Errorf("Merged = %v, want the later edit within one side", got.Merged)
+	}
+}
+
+func TestReconcileUpdatesValidate(t *testing.T) {
+	doc, err := LoadA2UISchema(nil)
+	if err != nil {
+		t.Fatalf("LoadA2UISchema: %v", err)
+	}
+	schema, err := jsonschema.Compile(doc)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	got, err := Reconcile("main", nil, []DataModelEdit{
+		{Path: "/name", Value: "x", Time: at},
+		{Path: "/form/done", Value: true, Time: at},
+		{Path: "/form/options", Value: map[string]any{"a": 1, "b": "two"}, Time: at},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if len(got.Updates) != 3 {
+		t.Fatalf("got %d updates, want 3", len(got.Updates))
+	}
+	for _, update := range got.Updates {
+		if err := schema.Validate(update); err != nil {
+			t.Errorf("update fails schema validation: %v\n%v", err, update)
+		}
+	}
+}
+
+func TestProgressUpdate(t *testing.T) {
+	msg, err := ProgressUpdate("main", "/progress/load", 1.4)
+	if err != nil {
+		t.Fatalf("ProgressUpdate: %v", err)
+	}
+	update, ok := msg["dataModelUpdate"].(map[string]any)
+	if !ok {
+		t.Fatalf("message = %v", msg)
+	}
+	contents, _ := update["contents"].([]any)
+	if len(contents) != 1 {
+		t.Fatalf("contents = %v", contents)
+	}
+	entry, _ := contents[0].(map[string]any)
+	if entry["valueNumber"] != 1.0 {
+		t.Errorf("valueNumber = %v, want the fraction clamped to 1", entry["valueNumber"])
+	}
+}
+
+func TestReconcileRejectsUnsupported(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	if _, err := Reconcile("main", nil, []DataModelEdit{
+		{Path: "/bad", Value: []string{"no", "lists"}, Time: at},
+	}, nil); err == nil {
+		t.Error("slice value should be rejected")
+	}
+	if _, err := Reconcile("main", nil, []DataModelEdit{
+		{Path: "/", Value: "x", Time: at},
+	}, nil); err == nil {
+		t.Error("root path should be rejected")
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// TaskMessages extracts the A2UI messages from a task's artifacts, in
+// emission order. Non-A2UI parts are skipped.
+func TaskMessages(task *a2a.Task) []map[string]any {
+	var messages []map[string]any
+	for _, artifact := range task.Artifacts {
+		for _, part := range artifact.Parts {
+			if data := Data(part); data != nil {
+				messages = append(messages, data)
+			}
+		}
+	}
+	return messages
+}
+
+// Replay loads a stored task and re-emits its A2UI artifacts on the queue,
+// in their original order, so a client can re-render exactly what the user
+// saw without rerunning the LLM.
+func Replay(ctx context.Context, store a2a.TaskStore, taskID string, queue *a2a.EventQueue) error {
+	task, err := store.Get(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("a2ui: loading task %s: %w", taskID, err)
+	}
+	for _, artifact := range task.Artifacts {
+		if !hasA2UIPart(artifact) {
+			continue
+		}
+		event := &a2a.ArtifactUpdateEvent{
+			TaskID:    task.ID,
+			ContextID: task.ContextID,
+			Artifact:  artifact,
+		}
+		if err := queue.Write(event); err != nil {
+			return fmt.Errorf("a2ui: replaying artifact %s: %w", artifact.ArtifactID, err)
+		}
+	}
+	return nil
+}
+
+// ExportMessages writes a task's A2UI messages as an indented JSON array.
+func ExportMessages(task *a2a.Task, w io.Writer) error {
+	messages := TaskMessages(task)
+	if messages == nil {
+		messages = []map[string]any{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(messages)
+}
+
+func hasA2UIPart(artifact a2a.Artifact) bool {
+	for _, part := range artifact.Parts {
+		if IsA2UIPart(part) {
+			return true
+		}
+	}
+	return false
+}
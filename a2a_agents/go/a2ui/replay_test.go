@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func replayTestTask() *a2a.Task {
+	return &a2a.Task{
+		ID:        "task1",
+		ContextID: "ctx1",
+		Status:    a2a.NewTaskStatus(a2a.TaskStateCompleted, nil),
+		Artifacts: []a2a.Artifact{
+			{
+				ArtifactID: "art1",
+				Name:       "a2ui",
+				Parts: []a2a.Part{
+					NewPart(map[string]any{"beginRendering": map[string]any{"surfaceId": "s1", "root": "r"}}),
+					NewPart(map[string]any{"surfaceUpdate": map[string]any{"surfaceId": "s1", "components": []any{}}}),
+				},
+			},
+			{
+				ArtifactID: "art2",
+				Name:       "notes",
+				Parts:      []a2a.Part{a2a.NewTextPart("not a surface")},
+			},
+		},
+	}
+}
+
+func TestTaskMessages(t *testing.T) {
+	messages := TaskMessages(replayTestTask())
+	if len(messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(messages))
+	}
+	if _, ok := messages[0]["beginRendering"]; !ok {
+		t.Errorf("messages out of order: %v", messages)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	store := a2a.NewInMemoryTaskStore()
+	task := replayTestTask()
+	if err := store.Save(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+
+	queue := a2a.NewEventQueue(16)
+	if err := Replay(context.Background(), store, "task1", queue); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	queue.Close()
+
+	var artifacts []a2a.Artifact
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		if ev, ok := event.(*a2a.ArtifactUpdateEvent); ok {
+			artifacts = append(artifacts, ev.Artifact)
+		}
+	}
+	if len(artifacts) != 1 {
+		t.Fatalf("replayed %d artifacts, want only the A2UI one", len(artifacts))
+	}
+	if artifacts[0].ArtifactID != "art1" {
+		t.Errorf("replayed artifact %q, want art1", artifacts[0].ArtifactID)
+	}
+
+	if err := Replay(context.Background(), store, "missing", a2a.NewEventQueue(1)); err == nil {
+		t.Error("replaying a missing task did not error")
+	}
+}
+
+func TestExportMessages(t *testing.T) {
+	var b strings.Builder
+	if err := ExportMessages(replayTestTask(), &b); err != nil {
+		t.Fatalf("ExportMessages: %v", err)
+	}
+	out := b.String()
+	if !strings.Contains(out, "beginRendering") || !strings.Contains(out, "surfaceUpdate") {
+		t.Errorf("export missing messages:\n%s", out)
+	}
+	if strings.Contains(out, "not a surface") {
+		t.Errorf("export includes non-A2UI content:\n%s", out)
+	}
+}
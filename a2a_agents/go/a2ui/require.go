@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// RequireReason classifies why A2UI cannot be used for a request.
+type RequireReason string
+
+const (
+	// RequireNotRequested means the client did not ask for the A2UI
+	// extension, via the transport or the message.
+	RequireNotRequested RequireReason = "not-requested"
+	// RequireUnsupportedCatalog means the client speaks A2UI but lacks a
+	// component catalog the agent's surfaces are built from.
+	RequireUnsupportedCatalog RequireReason = "unsupported-catalog"
+)
+
+// RequireError says why Require failed. Executors send UserMessage as
+// the task's final text, so the user learns what their client is missing
+// instead of seeing an opaque failure.
+type RequireError struct {
+	Reason RequireReason
+	// MissingCatalogIDs lists the catalogs the agent needs and the client
+	// did not advertise, for RequireUnsupportedCatalog.
+	MissingCatalogIDs []string
+	// UserMessage is a ready-made user-facing explanation.
+	UserMessage string
+}
+
+func (e *RequireError) Error() string {
+	if len(e.MissingCatalogIDs) > 0 {
+		return fmt.Sprintf("a2ui: extension unusable (%s: %s)", e.Reason,
+			strings.Join(e.MissingCatalogIDs, ", "))
+	}
+	return fmt.Sprintf("a2ui: extension unusable (%s)", e.Reason)
+}
+
+// Require activates the A2UI extension like TryActivateExtension and
+// verifies the client can render what the agent emits: when catalog IDs
+// are given, a client that advertises its supported catalogs must cover
+// them all. Clients that advertise no catalog list pass the catalog
+// check — they predate the capability. On failure it returns a
+// *RequireError carrying a user-facing explanation.
+func Require(rc *a2a.RequestContext, catalogIDs ...string) error {
+	if !TryActivateExtension(rc) {
+		return &RequireError{
+			Reason: RequireNotRequested,
+			UserMessage: "This agent answers with interactive UI, which your client " +
+				"did not request. Please connect with an A2UI-capable client.",
+		}
+	}
+	supported := ClientSupportedCatalogIDs(rc.Message)
+	if len(catalogIDs) == 0 || supported == nil {
+		return nil
+	}
+	supportedSet := make(map[string]bool, len(supported))
+	for _, id := range supported {
+		supportedSet[id] = true
+	}
+	var missing []string
+	for _, id := range catalogIDs {
+		if !supportedSet[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &RequireError{
+		Reason:            RequireUnsupportedCatalog,
+		MissingCatalogIDs: missing,
+		UserMessage: "Your client speaks A2UI but not the component catalogs this " +
+			"agent's UI is built from, so its surfaces cannot be rendered here.",
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// requireContext builds a request context that asked for A2UI and
+// advertises the given catalog IDs (nil advertises none).
+func requireContext(catalogIDs []any) *a2a.RequestContext {
+	msg := &a2a.Message{MessageID: "m1", Role: a2a.RoleUser}
+	if catalogIDs != nil {
+		msg.Metadata = map[string]any{
+			ClientCapabilitiesKey: map[string]any{SupportedCatalogIDsKey: catalogIDs},
+		}
+	}
+	return a2a.NewRequestContext(msg, "task1", "ctx1", []string{ExtensionURI})
+}
+
+func TestRequireNotRequested(t *testing.T) {
+	rc := a2a.NewRequestContext(&a2a.Message{MessageID: "m1"}, "task1", "ctx1", nil)
+	err := Require(rc)
+	var reqErr *RequireError
+	if !errors.As(err, &reqErr) || reqErr.Reason != RequireNotRequested {
+		t.Fatalf("Require = %v, want a not-requested RequireError", err)
+	}
+	if reqErr.UserMessage == "" {
+		t.Error("RequireError carries no user-facing explanation")
+	}
+	if len(rc.ActivatedExtensions()) != 0 {
+		t.Error("extension activated despite not being requested")
+	}
+}
+
+func TestRequireActivates(t *testing.T) {
+	rc := requireContext(nil)
+	if err := Require(rc); err != nil {
+		t.Fatalf("Require: %v", err)
+	}
+	if got := rc.ActivatedExtensions(); len(got) != 1 || got[0] != ExtensionURI {
+		t.Errorf("activated = %v, want the A2UI extension", got)
+	}
+}
+
+func TestRequireCatalogs(t *testing.T) {
+	// A client that advertises catalogs must cover the required ones.
+	rc := requireContext([]any{StandardCatalogID})
+	if err := Require(rc, StandardCatalogID); err != nil {
+		t.Errorf("Require with a supported catalog: %v", err)
+	}
+
+	custom := "https://example.com/custom-catalog.json"
+	err := Require(requireContext([]any{StandardCatalogID}), StandardCatalogID, custom)
+	var reqErr *RequireError
+	if !errors.As(err, &reqErr) || reqErr.Reason != RequireUnsupportedCatalog {
+		t.Fatalf("Require = %v, want an unsupported-catalog RequireError", err)
+	}
+	if len(reqErr.MissingCatalogIDs) != 1 || reqErr.MissingCatalogIDs[0] != custom {
+		t.Errorf("missing = %v, want [%s]", reqErr.MissingCatalogIDs, custom)
+	}
+
+	// Clients that advertise no catalog list predate the capability and
+	// pass the check.
+	if err := Require(requireContext(nil), custom); err != nil {
+		t.Errorf("Require without advertised catalogs: %v", err)
+	}
+}
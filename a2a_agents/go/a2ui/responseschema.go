@@ -0,0 +1,210 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ReduceOptions tunes ReduceForResponseSchema.
+type ReduceOptions struct {
+	// MaxDepth bounds schema nesting; deeper subtrees collapse to a plain
+	// typed schema. Defaults to 8. Component trees recurse through $refs, so
+	// a bound is required to terminate.
+	MaxDepth int
+	// MaxBytes bounds the marshaled size of the reduced schema to fit
+	// provider limits; 0 means unlimited. When over budget, descriptions are
+	// stripped first, then enums.
+	MaxBytes int
+}
+
+const defaultReduceDepth = 8
+
+// ReduceForResponseSchema converts a merged A2UI schema into the reduced
+// form accepted as a model responseSchema for constrained decoding. Local
+// $refs are inlined, combinators are flattened, and keywords providers do
+// not accept (additionalProperties, pattern, numeric bounds, $defs) are
+// dropped. The reduction is lossy by design: it constrains generation
+// server-side, while full validation still runs against the complete schema.
+func ReduceForResponseSchema(schema map[string]any, opts ReduceOptions) (map[string]any, error) {
+	if len(schema) == 0 {
+		return nil, fmt.Errorf("a2ui: schema is empty")
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = defaultReduceDepth
+	}
+	reduced := reduceNode(schema, schema, opts.MaxDepth)
+	if opts.MaxBytes > 0 {
+		raw, err := json.Marshal(reduced)
+		if err != nil {
+			return nil, err
+		}
+		if len(raw) > opts.MaxBytes {
+			stripKey(reduced, "description")
+			raw, _ = json.Marshal(reduced)
+		}
+		if len(raw) > opts.MaxBytes {
+			stripKey(reduced, "enum")
+			raw, _ = json.Marshal(reduced)
+		}
+		if len(raw) > opts.MaxBytes {
+			return nil, fmt.Errorf("a2ui: reduced schema is %d bytes, over the %d byte limit",
+				len(raw), opts.MaxBytes)
+		}
+	}
+	return reduced, nil
+}
+
+// reducedKeys are the keywords carried over verbatim.
+var reducedKeys = []string{"type", "description", "format", "enum", "required"}
+
+func reduceNode(node, root map[string]any, depth int) map[string]any {
+	if ref, ok := node["$ref"].(string); ok {
+		if target := resolvePointer(root, ref); target != nil {
+			node = target
+		}
+	}
+	if depth <= 0 {
+		// Too deep for the provider: collapse to a loosely typed schema.
+		out := map[string]any{}
+		if t, ok := node["type"]; ok {
+			out["type"] = t
+		} else {
+			out["type"] = "object"
+		}
+		return out
+	}
+
+	out := map[string]any{}
+	for _, key := range reducedKeys {
+		if v, ok := node[key]; ok {
+			out[key] = v
+		}
+	}
+	if c, ok := node["const"]; ok {
+		out["enum"] = []any{c}
+	}
+	if props, ok := node["properties"].(map[string]any); ok {
+		reducedProps := make(map[string]any, len(props))
+		for name, prop := range props {
+			if m, ok := prop.(map[string]any); ok {
+				reducedProps[name] = reduceNode(m, root, depth-1)
+			}
+		}
+		out["properties"] = reducedProps
+	}
+	if items, ok := node["items"].(map[string]any); ok {
+		out["items"] = reduceNode(items, root, depth-1)
+	}
+	if all, ok := node["allOf"].([]any); ok {
+		mergeAllOf(out, all, root, depth)
+	}
+	for _, kw := range []string{"oneOf", "anyOf"} {
+		options, ok := node[kw].([]any)
+		if !ok {
+			continue
+		}
+		reducedOpts := make([]any, 0, len(options))
+		for _, option := range options {
+			if m, ok := option.(map[string]any); ok {
+				reducedOpts = append(reducedOpts, reduceNode(m, root, depth-1))
+			}
+		}
+		// Providers accept anyOf only; oneOf maps onto it.
+		out["anyOf"] = reducedOpts
+	}
+	if _, hasType := out["type"]; !hasType {
+		if _, hasAnyOf := out["anyOf"]; !hasAnyOf {
+			out["type"] = "object"
+		}
+	}
+	return out
+}
+
+// mergeAllOf folds allOf members into the parent schema, unioning
+// properties and required lists.
+func mergeAllOf(out map[string]any, members []any, root map[string]any, depth int) {
+	for _, member := range members {
+		m, ok := member.(map[string]any)
+		if !ok {
+			continue
+		}
+		reduced := reduceNode(m, root, depth-1)
+		if t, ok := reduced["type"]; ok {
+			out["type"] = t
+		}
+		if props, ok := reduced["properties"].(map[string]any); ok {
+			existing, _ := out["properties"].(map[string]any)
+			if existing == nil {
+				existing = map[string]any{}
+				out["properties"] = existing
+			}
+			for name, prop := range props {
+				existing[name] = prop
+			}
+		}
+		if required, ok := reduced["required"].([]any); ok {
+			existing, _ := out["required"].([]any)
+			out["required"] = append(existing, required...)
+		}
+	}
+}
+
+// resolvePointer resolves a local "#/..." JSON pointer, returning nil when
+// it does not resolve to a schema object.
+func resolvePointer(root map[string]any, ref string) map[string]any {
+	if !strings.HasPrefix(ref, "#") {
+		return nil
+	}
+	var node any = root
+	for _, tok := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if tok == "" {
+			continue
+		}
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if node, ok = m[tok]; !ok {
+			return nil
+		}
+	}
+	m, _ := node.(map[string]any)
+	return m
+}
+
+// stripKey removes a key from every object in the schema tree, except
+// "required" entries that happen to share its name.
+func stripKey(node map[string]any, key string) {
+	delete(node, key)
+	for name, v := range node {
+		if name == "enum" || name == "required" {
+			continue
+		}
+		switch child := v.(type) {
+		case map[string]any:
+			stripKey(child, key)
+		case []any:
+			for _, elem := range child {
+				if m, ok := elem.(map[string]any); ok {
+					stripKey(m, key)
+				}
+			}
+		}
+	}
+}
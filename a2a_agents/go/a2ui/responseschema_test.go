@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func reduceTestSchema(t *testing.T) map[string]any {
+	t.Helper()
+	raw := `{
+	  "type": "object",
+	  "additionalProperties": false,
+	  "properties": {
+	    "surfaceId": {"type": "string", "description": "The surface ID.", "pattern": "^s"},
+	    "kind": {"const": "surface"},
+	    "component": {"$ref": "#/$defs/component"}
+	  },
+	  "required": ["surfaceId"],
+	  "$defs": {
+	    "component": {
+	      "type": "object",
+	      "properties": {
+	        "id": {"type": "string"},
+	        "children": {"type": "array", "items": {"$ref": "#/$defs/component"}}
+	      }
+	    }
+	  }
+	}`
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(raw), &schema); err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestReduceForResponseSchema(t *testing.T) {
+	reduced, err := ReduceForResponseSchema(reduceTestSchema(t), ReduceOptions{MaxDepth: 4})
+	if err != nil {
+		t.Fatalf("ReduceForResponseSchema: %v", err)
+	}
+	raw, _ := json.Marshal(reduced)
+	text := string(raw)
+	for _, dropped := range []string{"additionalProperties", "pattern", "$ref", "$defs", "const"} {
+		if strings.Contains(text, dropped) {
+			t.Errorf("reduced schema still contains %q", dropped)
+		}
+	}
+	props := reduced["properties"].(map[string]any)
+	// const becomes a single-value enum.
+	kind := props["kind"].(map[string]any)
+	enum, ok := kind["enum"].([]any)
+	if !ok || len(enum) != 1 || enum[0] != "surface" {
+		t.Errorf("const not converted to enum: %v", kind)
+	}
+	// The $ref was inlined.
+	component := props["component"].(map[string]any)
+	if component["type"] != "object" {
+		t.Errorf("$ref not inlined: %v", component)
+	}
+}
+
+func TestReduceForResponseSchemaDepthBound(t *testing.T) {
+	reduced, err := ReduceForResponseSchema(reduceTestSchema(t), ReduceOptions{MaxDepth: 50})
+	if err != nil {
+		t.Fatalf("ReduceForResponseSchema: %v", err)
+	}
+	// The recursive component $ref must terminate despite the generous
+	// depth; success here means no stack overflow and a finite document.
+	if _, err := json.Marshal(reduced); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReduceForResponseSchemaSizeBudget(t *testing.T) {
+	schema := reduceTestSchema(t)
+	unlimited, err := ReduceForResponseSchema(schema, ReduceOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	full, _ := json.Marshal(unlimited)
+
+	// A budget below the full size forces descriptions out.
+	reduced, err := ReduceForResponseSchema(schema, ReduceOptions{MaxBytes: len(full) - 1})
+	if err != nil {
+		t.Fatalf("ReduceForResponseSchema with budget: %v", err)
+	}
+	raw, _ := json.Marshal(reduced)
+	if strings.Contains(string(raw), "The surface ID.") {
+		t.Error("descriptions survived a tight size budget")
+	}
+
+	// An impossible budget errors rather than silently overflowing.
+	if _, err := ReduceForResponseSchema(schema, ReduceOptions{MaxBytes: 10}); err == nil {
+		t.Error("impossible budget did not error")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RefreshFunc produces a surface's fresh values, keyed by data-model
+// path (e.g. "/sales/apparel"). Values follow dataModelUpdate's contents
+// rules: scalars and flat maps of scalars.
+type RefreshFunc func(ctx context.Context) (map[string]any, error)
+
+// PushFunc delivers refresh messages for a surface to whatever channel
+// reaches the client — a live event stream, a WebSocket, a push
+// notification hook.
+type PushFunc func(ctx context.Context, surfaceID string, messages []map[string]any) error
+
+// Scheduler periodically re-runs registered refresh functions and pushes
+// the resulting dataModelUpdates, so surfaces like dashboards stay
+// current between user turns. Refreshes run until their surface is
+// closed; a failing refresh logs and retries on the next tick.
+type Scheduler struct {
+	push PushFunc
+
+	mu   sync.Mutex
+	jobs map[string]*refreshJob
+}
+
+type refreshJob struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewScheduler returns a scheduler delivering refreshes through push.
+func NewScheduler(push PushFunc) *Scheduler {
+	return &Scheduler{push: push, jobs: map[string]*refreshJob{}}
+}
+
+// Register starts refreshing the surface every interval. A surface holds
+// at most one refresh; register again after Close to change it.
+func (s *Scheduler) Register(surfaceID string, interval time.Duration, refresh RefreshFunc) error {
+	if interval <= 0 {
+		return fmt.Errorf("a2ui: refresh interval for surface %q must be positive", surfaceID)
+	}
+	if refresh == nil {
+		return fmt.Errorf("a2ui: surface %q needs a refresh function", surfaceID)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[surfaceID]; ok {
+		return fmt.Errorf("a2ui: surface %q already has a registered refresh", surfaceID)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &refreshJob{cancel: cancel, done: make(chan struct{})}
+	s.jobs[surfaceID] = job
+	go s.run(ctx, job, surfaceID, interval, refresh)
+	return nil
+}
+
+// Close stops the surface's refresh, e.g. when the client deletes the
+// surface or its subscription lapses. Closing an unknown surface is a
+// no-op.
+func (s *Scheduler) Close(surfaceID string) {
+	s.mu.Lock()
+	job, ok := s.jobs[surfaceID]
+	delete(s.jobs, surfaceID)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	job.cancel()
+	<-job.done
+}
+
+// CloseAll stops every registered refresh.
+func (s *Scheduler) CloseAll() {
+	s.mu.Lock()
+	jobs := s.jobs
+	s.jobs = map[string]*refreshJob{}
+	s.mu.Unlock()
+	for _, job := range jobs {
+		job.cancel()
+		<-job.done
+	}
+}
+
+// run is the per-surface refresh loop.
+func (s *Scheduler) run(ctx context.Context, job *refreshJob, surfaceID string, interval time.Duration, refresh RefreshFunc) {
+	defer close(job.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		values, err := refresh(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("surface refresh failed", "surface", surfaceID, "error", err)
+			continue
+		}
+		messages, err := refreshMessages(surfaceID, values)
+		if err != nil {
+			slog.Warn("dropping malformed refresh", "surface", surfaceID, "error", err)
+			continue
+		}
+		if len(messages) == 0 {
+			continue
+		}
+		if err := s.push(ctx, surfaceID, messages); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("pushing surface refresh failed", "surface", surfaceID, "error", err)
+		}
+	}
+}
+
+// refreshMessages builds one dataModelUpdate per refreshed path, in
+// deterministic path order.
+func refreshMessages(surfaceID string, values map[string]any) ([]map[string]any, error) {
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	messages := make([]map[string]any, 0, len(paths))
+	for _, path := range paths {
+		msg, err := dataModelUpdate(surfaceID, path, values[path])
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
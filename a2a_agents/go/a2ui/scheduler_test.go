@@ -0,0 +1,131 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerPushesRefreshes(t *testing.T) {
+	pushes := make(chan []map[string]any, 16)
+	s := NewScheduler(func(ctx context.Context, surfaceID string, messages []map[string]any) error {
+		if surfaceID != "dashboard" {
+			t.Errorf("surfaceID = %q, want dashboard", surfaceID)
+		}
+		pushes <- messages
+		return nil
+	})
+	defer s.CloseAll()
+
+	err := s.Register("dashboard", 5*time.Millisecond, func(ctx context.Context) (map[string]any, error) {
+		return map[string]any{"/sales/apparel": 41.0, "/sales/home": 15.0}, nil
+	})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	select {
+	case messages := <-pushes:
+		if len(messages) != 2 {
+			t.Fatalf("got %d messages, want 2", len(messages))
+		}
+		update, _ := messages[0]["dataModelUpdate"].(map[string]any)
+		if update == nil || update["surfaceId"] != "dashboard" {
+			t.Errorf("first message = %v", messages[0])
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no refresh arrived")
+	}
+}
+
+func TestSchedulerCloseStopsRefresh(t *testing.T) {
+	var count atomic.Int64
+	s := NewScheduler(func(ctx context.Context, surfaceID string, messages []map[string]any) error {
+		count.Add(1)
+		return nil
+	})
+	if err := s.Register("dashboard", time.Millisecond, func(ctx context.Context) (map[string]any, error) {
+		return map[string]any{"/n": 1.0}, nil
+	}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	for count.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	s.Close("dashboard")
+	settled := count.Load()
+	time.Sleep(10 * time.Millisecond)
+	if count.Load() != settled {
+		t.Error("refresh kept running after Close")
+	}
+	// The surface can be registered again once closed.
+	if err := s.Register("dashboard", time.Minute, func(ctx context.Context) (map[string]any, error) {
+		return nil, nil
+	}); err != nil {
+		t.Errorf("re-register after Close: %v", err)
+	}
+	s.CloseAll()
+	s.Close("unknown") // closing an unknown surface is a no-op
+}
+
+func TestSchedulerRejectsBadRegistrations(t *testing.T) {
+	s := NewScheduler(func(ctx context.Context, surfaceID string, messages []map[string]any) error {
+		return nil
+	})
+	defer s.CloseAll()
+	refresh := func(ctx context.Context) (map[string]any, error) { return nil, nil }
+	if err := s.Register("a", 0, refresh); err == nil {
+		t.Error("zero interval accepted")
+	}
+	if err := s.Register("a", time.Minute, nil); err == nil {
+		t.Error("nil refresh accepted")
+	}
+	if err := s.Register("a", time.Minute, refresh); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := s.Register("a", time.Minute, refresh); err == nil {
+		t.Error("duplicate registration accepted")
+	}
+}
+
+func TestSchedulerRetriesAfterError(t *testing.T) {
+	var calls atomic.Int64
+	pushed := make(chan struct{}, 1)
+	s := NewScheduler(func(ctx context.Context, surfaceID string, messages []map[string]any) error {
+		select {
+		case pushed <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+	defer s.CloseAll()
+	if err := s.Register("dashboard", time.Millisecond, func(ctx context.Context) (map[string]any, error) {
+		if calls.Add(1) == 1 {
+			return nil, errors.New("backend down")
+		}
+		return map[string]any{"/n": 1.0}, nil
+	}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		t.Fatal("no push after a failed refresh")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+// baseSchemaV08 is the A2UI v0.8 server-to-client message schema, bundled so
+// agents do not depend on the specification checkout at runtime.
+//
+//go:embed spec/v0_8/server_to_client.json
+var baseSchemaV08 []byte
+
+// Catalog describes a component catalog that can be merged into the base
+// A2UI message schema.
+type Catalog struct {
+	// ID is the catalog identifier, e.g. StandardCatalogID or the URI of a
+	// custom catalog definition.
+	ID string
+	// Components maps component type names to their property schemas. They
+	// are spliced into the component wrapper of surfaceUpdate, alongside the
+	// components of the base schema.
+	Components map[string]any
+	// Aliases maps retired component type names to their current form, so
+	// payloads using old names keep working across catalog renames. They
+	// are applied by ApplyAliases before validation and emission.
+	Aliases AliasMap
+}
+
+// LoadA2UISchema returns the A2UI message schema for the given catalog. A
+// nil catalog (or one without components) yields the standard-catalog schema
+// unchanged; otherwise the catalog's component definitions are merged into
+// the component wrapper so payloads using them validate.
+func LoadA2UISchema(catalog *Catalog) (map[string]any, error) {
+	var schema map[string]any
+	if err := json.Unmarshal(baseSchemaV08, &schema); err != nil {
+		return nil, fmt.Errorf("a2ui: parsing base schema: %w", err)
+	}
+	if catalog == nil || len(catalog.Components) == 0 {
+		return schema, nil
+	}
+	wrapper, err := componentWrapper(schema)
+	if err != nil {
+		return nil, err
+	}
+	props, _ := wrapper["properties"].(map[string]any)
+	if props == nil {
+		props = map[string]any{}
+		wrapper["properties"] = props
+	}
+	for name, componentSchema := range catalog.Components {
+		props[name] = componentSchema
+	}
+	return schema, nil
+}
+
+// componentWrapper digs to the component wrapper object inside the
+// surfaceUpdate components item schema.
+func componentWrapper(schema map[string]any) (map[string]any, error) {
+	wrapper := digMap(schema,
+		"properties", "surfaceUpdate",
+		"properties", "components",
+		"items",
+		"properties", "component")
+	if wrapper == nil {
+		return nil, fmt.Errorf("a2ui: base schema has no component wrapper to merge into")
+	}
+	return wrapper, nil
+}
+
+// digMap walks nested map keys, returning nil when any step is missing or
+// not an object.
+func digMap(m map[string]any, keys ...string) map[string]any {
+	for _, key := range keys {
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			return nil
+		}
+		m = next
+	}
+	return m
+}
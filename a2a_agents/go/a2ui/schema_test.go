@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+func TestLoadA2UISchemaStandard(t *testing.T) {
+	schema, err := LoadA2UISchema(nil)
+	if err != nil {
+		t.Fatalf("LoadA2UISchema(nil): %v", err)
+	}
+	if _, err := jsonschema.Compile(schema); err != nil {
+		t.Fatalf("base schema does not compile: %v", err)
+	}
+}
+
+func TestLoadA2UISchemaMergesCatalog(t *testing.T) {
+	catalog := &Catalog{
+		ID: "https://example.com/catalog.json",
+		Components: map[string]any{
+			"Sparkline": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"values": map[string]any{"type": "array"},
+				},
+			},
+		},
+	}
+	schema, err := LoadA2UISchema(catalog)
+	if err != nil {
+		t.Fatalf("LoadA2UISchema: %v", err)
+	}
+	wrapper, err := componentWrapper(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	props := wrapper["properties"].(map[string]any)
+	if _, ok := props["Sparkline"]; !ok {
+		t.Error("catalog component was not merged into the component wrapper")
+	}
+}
+
+func TestValidateExamplesDir(t *testing.T) {
+	dir := t.TempDir()
+	valid := `[
+	  {"beginRendering": {"surfaceId": "s1", "root": "root"}},
+	  {"surfaceUpdate": {"surfaceId": "s1", "components": [
+	    {"id": "root", "component": {"Text": {"text": {"literalString": "hi"}}}}
+	  ]}}
+	]`
+	if err := os.WriteFile(filepath.Join(dir, "valid.json"), []byte(valid), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateExamplesDir(dir, nil); err != nil {
+		t.Fatalf("valid example rejected: %v", err)
+	}
+
+	// A stale example (missing the required surfaceId) must fail with a
+	// report naming the file.
+	nested := filepath.Join(dir, "nested")
+	if err := os.Mkdir(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stale := `{"beginRendering": {"root": "root"}}`
+	if err := os.WriteFile(filepath.Join(nested, "stale.json"), []byte(stale), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := ValidateExamplesDir(dir, nil)
+	if err == nil {
+		t.Fatal("stale example passed validation")
+	}
+	if want := filepath.Join(nested, "stale.json"); !strings.Contains(err.Error(), want) {
+		t.Errorf("report does not name the failing file %q:\n%v", want, err)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SpecVersion is the A2UI specification version the bundled schemas
+// implement.
+const SpecVersion = "v0_8"
+
+// SchemaCache memoizes merged and compiled message schemas keyed by spec
+// version and the set of catalog IDs, so repeated sessions negotiating
+// the same catalogs reuse the result instead of re-running the merge in
+// LoadA2UISchema. The zero value is not usable; use NewSchemaCache.
+type SchemaCache struct {
+	mu      sync.Mutex
+	entries map[string]*CompiledSchema
+}
+
+// NewSchemaCache returns an empty cache.
+func NewSchemaCache() *SchemaCache {
+	return &SchemaCache{entries: map[string]*CompiledSchema{}}
+}
+
+// Get returns the merged and compiled schema for the catalogs, merging
+// lazily on first use. Catalog order does not affect the cache key;
+// components from later catalogs win on name collisions. Failed merges
+// are not cached, so a corrected catalog registration can retry. The
+// context bounds the compile on a cache miss.
+func (c *SchemaCache) Get(ctx context.Context, catalogs ...*Catalog) (*CompiledSchema, error) {
+	key := cacheKey(catalogs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok {
+		return entry, nil
+	}
+	entry := compileCatalog(ctx, mergeCatalogs(catalogs))
+	if entry.Err != nil {
+		return nil, entry.Err
+	}
+	c.entries[key] = entry
+	return entry, nil
+}
+
+// Len reports how many merged schemas the cache holds.
+func (c *SchemaCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// cacheKey builds the cache key: the spec version plus the sorted IDs of
+// the catalogs that contribute components. Catalogs without components
+// merge to the standard schema, so they key identically to an empty set.
+func cacheKey(catalogs []*Catalog) string {
+	var ids []string
+	for _, catalog := range catalogs {
+		if catalog == nil || len(catalog.Components) == 0 {
+			continue
+		}
+		ids = append(ids, catalogID(catalog))
+	}
+	if len(ids) == 0 {
+		ids = []string{StandardCatalogID}
+	}
+	sort.Strings(ids)
+	return SpecVersion + "|" + strings.Join(ids, ",")
+}
+
+// mergeCatalogs combines the catalogs' components into one catalog for
+// the schema merge. Nil catalogs contribute nothing; nil input yields a
+// nil catalog, i.e. the standard schema.
+func mergeCatalogs(catalogs []*Catalog) *Catalog {
+	var merged *Catalog
+	for _, catalog := range catalogs {
+		if catalog == nil || len(catalog.Components) == 0 {
+			continue
+		}
+		if merged == nil {
+			merged = &Catalog{ID: catalog.ID, Components: map[string]any{}}
+		}
+		for name, definition := range catalog.Components {
+			merged.Components[name] = definition
+		}
+	}
+	return merged
+}
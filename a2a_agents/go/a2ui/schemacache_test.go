@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSchemaCacheReusesMergedSchemas(t *testing.T) {
+	cache := NewSchemaCache()
+	charts := &Catalog{ID: "charts", Components: map[string]any{"Chart": map[string]any{"type": "object"}}}
+	maps := &Catalog{ID: "maps", Components: map[string]any{"GoogleMap": map[string]any{"type": "object"}}}
+
+	first, err := cache.Get(context.Background(), charts, maps)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if first.Schema == nil || first.Doc == nil {
+		t.Fatalf("entry incomplete: %+v", first)
+	}
+	// Order must not matter for the key.
+	second, err := cache.Get(context.Background(), maps, charts)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if first != second {
+		t.Error("same catalog set compiled twice")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("cache has %d entries, want 1", cache.Len())
+	}
+
+	// A different set gets its own entry.
+	if _, err := cache.Get(context.Background(), charts); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("cache has %d entries, want 2", cache.Len())
+	}
+}
+
+func TestSchemaCacheStandardCatalog(t *testing.T) {
+	cache := NewSchemaCache()
+	entry, err := cache.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if entry.Catalog != nil {
+		t.Errorf("no catalogs should merge to the standard schema, got %+v", entry.Catalog)
+	}
+	again, err := cache.Get(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Get(nil): %v", err)
+	}
+	if entry != again {
+		t.Error("nil catalog and empty set have different cache entries")
+	}
+}
+
+func TestSchemaCacheDoesNotCacheFailures(t *testing.T) {
+	cache := NewSchemaCache()
+	bad := &Catalog{ID: "bad", Components: map[string]any{
+		"Broken": map[string]any{"$ref": "https://example.com/elsewhere"},
+	}}
+	if _, err := cache.Get(context.Background(), bad); err == nil {
+		t.Fatal("failing catalog cached without error")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("failure was cached: %d entries", cache.Len())
+	}
+}
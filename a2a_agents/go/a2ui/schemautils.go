@@ -0,0 +1,47 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WrapAsJSONArray wraps the A2UI schema in an array schema to support
+// payloads carrying multiple parts.
+func WrapAsJSONArray(a2uiSchema map[string]any) (map[string]any, error) {
+	if len(a2uiSchema) == 0 {
+		return nil, fmt.Errorf("a2ui: schema is empty")
+	}
+	return map[string]any{"type": "array", "items": a2uiSchema}, nil
+}
+
+// Markers delimiting the schema block in system instructions. The send tool's
+// description points the model at this block.
+const (
+	SchemaBlockBegin = "---BEGIN A2UI JSON SCHEMA---"
+	SchemaBlockEnd   = "---END A2UI JSON SCHEMA---"
+)
+
+// SchemaInstructionBlock renders the schema as the delimited block appended
+// to the agent's system instructions.
+func SchemaInstructionBlock(schema map[string]any) string {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		// Schemas are built from JSON documents; marshaling cannot fail.
+		panic(err)
+	}
+	return fmt.Sprintf("\n%s\n%s\n%s\n", SchemaBlockBegin, raw, SchemaBlockEnd)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Select returns the at most max examples most relevant to the query,
+// scored by how many of the query's keywords match each example's tags.
+// When no example matches at all — or the query is empty — the full set is
+// returned regardless of max, so an unrecognized query degrades to the
+// complete few-shot set rather than an arbitrary subset. max <= 0 means no
+// cap.
+func (l *ExampleLibrary) Select(query string, max int) []*Example {
+	keywords := queryKeywords(query)
+	type scored struct {
+		example *Example
+		score   int
+	}
+	var matches []scored
+	for _, example := range l.examples {
+		score := 0
+		for _, tag := range example.Tags {
+			if keywords[tag] {
+				score++
+			}
+		}
+		if score > 0 {
+			matches = append(matches, scored{example, score})
+		}
+	}
+	if len(matches) == 0 {
+		return l.examples
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	selected := make([]*Example, len(matches))
+	for i, m := range matches {
+		selected[i] = m.example
+	}
+	return capExamples(selected, max)
+}
+
+// queryKeywords lowercases and tokenizes a user query, with a light stemming
+// pass so "charts" matches the "chart" tag.
+func queryKeywords(query string) map[string]bool {
+	keywords := map[string]bool{}
+	for _, token := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		keywords[token] = true
+		if trimmed := strings.TrimSuffix(token, "s"); trimmed != token && trimmed != "" {
+			keywords[trimmed] = true
+		}
+	}
+	return keywords
+}
+
+func capExamples(examples []*Example, max int) []*Example {
+	if max > 0 && len(examples) > max {
+		return examples[:max]
+	}
+	return examples
+}
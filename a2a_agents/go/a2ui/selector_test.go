@@ -0,0 +1,61 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import "testing"
+
+func selectorTestLibrary(t *testing.T) *ExampleLibrary {
+	t.Helper()
+	dir := t.TempDir()
+	payload := `[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`
+	writeExample(t, dir, "chart.json", payload)
+	writeExample(t, dir, "store_map.json", payload)
+	lib, err := LoadExampleLibrary(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lib
+}
+
+func TestSelect(t *testing.T) {
+	lib := selectorTestLibrary(t)
+	tests := []struct {
+		name  string
+		query string
+		max   int
+		want  []string
+	}{
+		{"keyword match", "show my sales breakdown as a chart", 1, []string{"chart"}},
+		{"plural keyword", "compare the charts by quarter", 1, []string{"chart"}},
+		{"map intent", "any outlier stores on the map?", 1, []string{"store_map"}},
+		{"no match falls back to all", "what is the weather like", 1, []string{"chart", "store_map"}},
+		{"no cap", "chart and map please", 0, []string{"chart", "store_map"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := lib.Select(tc.query, tc.max)
+			if len(got) != len(tc.want) {
+				t.Fatalf("Select(%q, %d) returned %d examples, want %d",
+					tc.query, tc.max, len(got), len(tc.want))
+			}
+			for i, example := range got {
+				if example.Name != tc.want[i] {
+					t.Errorf("Select(%q, %d)[%d] = %q, want %q",
+						tc.query, tc.max, i, example.Name, tc.want[i])
+				}
+			}
+		})
+	}
+}
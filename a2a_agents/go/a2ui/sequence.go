@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"sync"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// SequenceKey is the part metadata key carrying a per-surface,
+// monotonically increasing sequence number, so clients can restore order
+// when a streaming transport delivers artifact chunks out of order. Like
+// MessageIDKey, it rides on the part because the payload schema is
+// closed.
+const SequenceKey = "a2uiSequence"
+
+// Sequencer stamps outgoing A2UI parts with per-surface sequence numbers.
+// Numbering starts at 1 for each surface and increments per message. It
+// is safe for concurrent use.
+type Sequencer struct {
+	mu   sync.Mutex
+	next map[string]int
+}
+
+// NewSequencer creates an empty Sequencer.
+func NewSequencer() *Sequencer {
+	return &Sequencer{next: map[string]int{}}
+}
+
+// NewPart wraps one A2UI message in a part stamped with the next sequence
+// number for the surface it targets.
+func (s *Sequencer) NewPart(a2uiData map[string]any) a2a.Part {
+	surfaceID := messageSurfaceID(a2uiData)
+	s.mu.Lock()
+	s.next[surfaceID]++
+	seq := s.next[surfaceID]
+	s.mu.Unlock()
+	return a2a.NewDataPart(a2uiData, map[string]any{
+		MIMETypeKey: MIMEType,
+		SequenceKey: seq,
+	})
+}
+
+// PartSequence returns a part's sequence number. ok is false when the
+// part carries none.
+func PartSequence(part a2a.Part) (seq int, ok bool) {
+	if part.Metadata == nil {
+		return 0, false
+	}
+	switch v := part.Metadata[SequenceKey].(type) {
+	case int:
+		return v, true
+	case float64:
+		// Metadata decoded from JSON carries numbers as float64.
+		return int(v), true
+	}
+	return 0, false
+}
+
+// Reorderer restores per-surface message order on the receiving side:
+// parts may arrive in any order and duplicates are dropped. Parts without
+// a sequence number are passed through untouched.
+type Reorderer struct {
+	mu       sync.Mutex
+	surfaces map[string]*surfaceOrder
+}
+
+type surfaceOrder struct {
+	next     int
+	buffered map[int]map[string]any
+}
+
+// NewReorderer creates an empty Reorderer.
+func NewReorderer() *Reorderer {
+	return &Reorderer{surfaces: map[string]*surfaceOrder{}}
+}
+
+// Add accepts one incoming part and returns the A2UI messages that became
+// deliverable, in sequence order. A part that arrives ahead of a gap is
+// buffered and returned once the gap fills; duplicates and non-A2UI parts
+// produce nothing.
+func (r *Reorderer) Add(part a2a.Part) []map[string]any {
+	data := Data(part)
+	if data == nil {
+		return nil
+	}
+	seq, ok := PartSequence(part)
+	if !ok {
+		return []map[string]any{data}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	surfaceID := messageSurfaceID(data)
+	order := r.surfaces[surfaceID]
+	if order == nil {
+		order = &surfaceOrder{next: 1, buffered: map[int]map[string]any{}}
+		r.surfaces[surfaceID] = order
+	}
+	if seq < order.next {
+		return nil // already delivered
+	}
+	if _, dup := order.buffered[seq]; dup {
+		return nil
+	}
+	order.buffered[seq] = data
+
+	var ready []map[string]any
+	for {
+		msg, ok := order.buffered[order.next]
+		if !ok {
+			break
+		}
+		delete(order.buffered, order.next)
+		order.next++
+		ready = append(ready, msg)
+	}
+	return ready
+}
+
+// Buffered reports how many messages are waiting on a gap for the given
+// surface.
+func (r *Reorderer) Buffered(surfaceID string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if order := r.surfaces[surfaceID]; order != nil {
+		return len(order.buffered)
+	}
+	return 0
+}
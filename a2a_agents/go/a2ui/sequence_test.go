@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func surfaceMsg(surfaceID, marker string) map[string]any {
+	return map[string]any{
+		"dataModelUpdate": map[string]any{
+			"surfaceId": surfaceID,
+			"contents":  []any{map[string]any{"key": "marker", "valueString": marker}},
+		},
+	}
+}
+
+func TestSequencerNumbersPerSurface(t *testing.T) {
+	s := NewSequencer()
+	first := s.NewPart(surfaceMsg("a", "1"))
+	second := s.NewPart(surfaceMsg("a", "2"))
+	other := s.NewPart(surfaceMsg("b", "1"))
+
+	for i, part := range []a2a.Part{first, second, other} {
+		if !IsA2UIPart(part) {
+			t.Errorf("part %d is not marked as A2UI content", i)
+		}
+	}
+	if seq, ok := PartSequence(first); !ok || seq != 1 {
+		t.Errorf("first = %d, %v", seq, ok)
+	}
+	if seq, _ := PartSequence(second); seq != 2 {
+		t.Errorf("second = %d, want 2", seq)
+	}
+	if seq, _ := PartSequence(other); seq != 1 {
+		t.Errorf("other surface starts at %d, want 1", seq)
+	}
+	if _, ok := PartSequence(NewPart(surfaceMsg("a", "x"))); ok {
+		t.Error("unstamped part reports a sequence")
+	}
+}
+
+func TestReordererRestoresOrder(t *testing.T) {
+	s := NewSequencer()
+	parts := []a2a.Part{
+		s.NewPart(surfaceMsg("a", "first")),
+		s.NewPart(surfaceMsg("a", "second")),
+		s.NewPart(surfaceMsg("a", "third")),
+	}
+
+	r := NewReorderer()
+	// Deliver out of order: 3, 1, 3 (duplicate), 2.
+	if got := r.Add(parts[2]); got != nil {
+		t.Errorf("out-of-order part delivered early: %v", got)
+	}
+	if got := r.Add(parts[0]); len(got) != 1 || marker(got[0]) != "first" {
+		t.Errorf("first delivery = %v", got)
+	}
+	if got := r.Add(parts[2]); got != nil {
+		t.Errorf("duplicate delivered: %v", got)
+	}
+	if r.Buffered("a") != 1 {
+		t.Errorf("Buffered = %d, want 1", r.Buffered("a"))
+	}
+	got := r.Add(parts[1])
+	if len(got) != 2 || marker(got[0]) != "second" || marker(got[1]) != "third" {
+		t.Errorf("gap fill delivered %v", got)
+	}
+	// Re-delivering an already-delivered part produces nothing.
+	if got := r.Add(parts[0]); got != nil {
+		t.Errorf("replay delivered: %v", got)
+	}
+}
+
+func TestReordererPassThrough(t *testing.T) {
+	r := NewReorderer()
+	if got := r.Add(a2a.NewTextPart("hi")); got != nil {
+		t.Errorf("non-A2UI part delivered: %v", got)
+	}
+	// Parts without a sequence number flow straight through.
+	if got := r.Add(NewPart(surfaceMsg("a", "x"))); len(got) != 1 {
+		t.Errorf("unsequenced part = %v, want immediate delivery", got)
+	}
+}
+
+// marker digs the test marker back out of a delivered message.
+func marker(msg map[string]any) string {
+	update := msg["dataModelUpdate"].(map[string]any)
+	entry := update["contents"].([]any)[0].(map[string]any)
+	s, _ := entry["valueString"].(string)
+	return s
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import "github.com/google/A2UI/a2a_agents/go/a2a"
+
+// This file pins the narrow slice of the A2A package that A2UI agents
+// depend on behind small interfaces. Executors written against EventSink,
+// Request and CardSource survive breaking changes in the underlying A2A
+// types: when a field becomes a method or a signature shifts, only the
+// adapters here need updating, not every agent.
+
+// EventSink is the slice of the A2A event queue agents write to. The
+// concrete *a2a.EventQueue satisfies it directly.
+type EventSink interface {
+	// Write publishes one event, failing once the consumer is gone.
+	Write(event a2a.Event) error
+	// Closed reports whether the consumer stopped reading.
+	Closed() bool
+}
+
+var _ EventSink = (*a2a.EventQueue)(nil)
+
+// Request is the slice of the A2A request context agents read: the task
+// identity, the triggering message, and extension negotiation.
+type Request interface {
+	// TaskID identifies the task this execution works on.
+	TaskID() string
+	// ContextID identifies the conversation the task belongs to.
+	ContextID() string
+	// Message is the user message that triggered the execution.
+	Message() *a2a.Message
+	// RequestedExtensions lists the extension URIs the client asked for.
+	RequestedExtensions() []string
+	// AddActivatedExtension records that the agent activated an
+	// extension for this request.
+	AddActivatedExtension(uri string)
+}
+
+// NewRequest adapts an A2A request context to the Request interface.
+func NewRequest(rc *a2a.RequestContext) Request {
+	return requestShim{rc: rc}
+}
+
+type requestShim struct {
+	rc *a2a.RequestContext
+}
+
+func (r requestShim) TaskID() string                 { return r.rc.TaskID }
+func (r requestShim) ContextID() string              { return r.rc.ContextID }
+func (r requestShim) Message() *a2a.Message          { return r.rc.Message }
+func (r requestShim) RequestedExtensions() []string  { return r.rc.RequestedExtensions() }
+func (r requestShim) AddActivatedExtension(u string) { r.rc.AddActivatedExtension(u) }
+
+// CardSource provides the agent card served to clients, so card handling
+// can be swapped — static, built per request, or loaded from config —
+// without touching serving code.
+type CardSource interface {
+	// AgentCard returns the card to serve.
+	AgentCard() a2a.AgentCard
+}
+
+// StaticCard adapts a fixed card to CardSource.
+type StaticCard a2a.AgentCard
+
+// AgentCard returns the card itself.
+func (c StaticCard) AgentCard() a2a.AgentCard { return a2a.AgentCard(c) }
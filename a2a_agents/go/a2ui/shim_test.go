@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func TestRequestShim(t *testing.T) {
+	msg := &a2a.Message{Parts: []a2a.Part{a2a.NewTextPart("hi")}}
+	rc := a2a.NewRequestContext(msg, "task1", "ctx1", []string{ExtensionURI})
+	request := NewRequest(rc)
+	if request.TaskID() != "task1" || request.ContextID() != "ctx1" {
+		t.Errorf("identity = %s/%s", request.TaskID(), request.ContextID())
+	}
+	if request.Message() != msg {
+		t.Error("Message() is not the triggering message")
+	}
+	if got := request.RequestedExtensions(); !reflect.DeepEqual(got, []string{ExtensionURI}) {
+		t.Errorf("RequestedExtensions = %v", got)
+	}
+	// Activation through the shim reaches the underlying context.
+	request.AddActivatedExtension(ExtensionURI)
+	if got := rc.ActivatedExtensions(); !reflect.DeepEqual(got, []string{ExtensionURI}) {
+		t.Errorf("ActivatedExtensions = %v", got)
+	}
+}
+
+func TestEventSinkAcceptsEventQueue(t *testing.T) {
+	var sink EventSink = a2a.NewEventQueue(1)
+	if err := sink.Write(&a2a.StatusUpdateEvent{TaskID: "task1"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if sink.Closed() {
+		t.Error("fresh queue reports closed")
+	}
+}
+
+func TestStaticCard(t *testing.T) {
+	card := a2a.AgentCard{Name: "Shimmed Agent"}
+	var source CardSource = StaticCard(card)
+	if got := source.AgentCard(); got.Name != "Shimmed Agent" {
+		t.Errorf("AgentCard().Name = %q", got.Name)
+	}
+}
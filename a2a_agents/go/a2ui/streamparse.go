@@ -0,0 +1,262 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MessageStreamParser incrementally splits a streamed A2UI payload — a JSON
+// array of A2UI messages, or a single bare message object — into complete
+// top-level messages as their closing brace arrives.
+//
+// Feeding the parser chunk by chunk lets an executor validate and emit the
+// leading beginRendering message while the model is still generating the much
+// larger surfaceUpdate that follows it, instead of waiting for the whole
+// payload to finish.
+type MessageStreamParser struct {
+	buf     strings.Builder // the current, incomplete top-level element
+	depth   int             // object/array nesting depth within the element
+	inStr   bool
+	escaped bool
+	started bool // saw the opening '[' or first '{'
+	bare    bool // payload is a single object, not an array
+	done    bool
+}
+
+// Feed appends a chunk of payload text and returns any messages completed by
+// it, in order. The returned raw messages are syntactically complete JSON
+// objects; callers are expected to validate them before use.
+func (p *MessageStreamParser) Feed(chunk string) ([]json.RawMessage, error) {
+	var out []json.RawMessage
+	for _, r := range chunk {
+		if p.done {
+			if !isJSONSpace(r) {
+				return out, fmt.Errorf("a2ui: unexpected %q after end of payload", r)
+			}
+			continue
+		}
+		if !p.started {
+			switch {
+			case isJSONSpace(r):
+				continue
+			case r == '[':
+				p.started = true
+				continue
+			case r == '{':
+				p.started = true
+				p.bare = true
+				// The brace is part of the element; fall through below.
+			default:
+				return out, fmt.Errorf("a2ui: payload must be a JSON array or object, got %q", r)
+			}
+		}
+		if p.depth == 0 {
+			// Between elements: skip separators until the next object opens.
+			switch {
+			case isJSONSpace(r), r == ',':
+				continue
+			case r == ']' && !p.bare:
+				p.done = true
+				continue
+			case r == '{':
+			default:
+				return out, fmt.Errorf("a2ui: expected an A2UI message object, got %q", r)
+			}
+		}
+		p.buf.WriteRune(r)
+		if p.inStr {
+			switch {
+			case p.escaped:
+				p.escaped = false
+			case r == '\\':
+				p.escaped = true
+			case r == '"':
+				p.inStr = false
+			}
+			continue
+		}
+		switch r {
+		case '"':
+			p.inStr = true
+		case '{', '[':
+			p.depth++
+		case '}', ']':
+			p.depth--
+			if p.depth == 0 {
+				msg := json.RawMessage(p.buf.String())
+				if !json.Valid(msg) {
+					return out, fmt.Errorf("a2ui: malformed message in streamed payload")
+				}
+				out = append(out, msg)
+				p.buf.Reset()
+				if p.bare {
+					p.done = true
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// Close reports whether the streamed payload ended cleanly. It returns an
+// error if the stream stopped inside an element or before the array closed.
+func (p *MessageStreamParser) Close() error {
+	if !p.started {
+		return fmt.Errorf("a2ui: empty streamed payload")
+	}
+	if p.buf.Len() > 0 || (!p.done && !p.bare) {
+		return fmt.Errorf("a2ui: streamed payload ended mid-message")
+	}
+	return nil
+}
+
+func isJSONSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+// ArgsStreamParser extracts A2UI messages from the model's streamed
+// function-call arguments before the call finishes generating. It scans the
+// partial arguments JSON for the payload string argument, incrementally
+// unescapes its contents, and forwards them to a MessageStreamParser.
+type ArgsStreamParser struct {
+	argName string
+	msgs    MessageStreamParser
+
+	stack    []byte // open containers, '{' or '['
+	key      strings.Builder
+	inKey    bool
+	skipStr  bool // inside a string value we do not care about
+	expValue bool // the next token is an object member value
+	value    bool // currently inside the payload string value
+	pending  strings.Builder
+	escaped  bool
+	done     bool
+}
+
+// NewArgsStreamParser returns a parser that watches the streamed arguments
+// for the string argument named argName (e.g. "a2ui_json").
+func NewArgsStreamParser(argName string) *ArgsStreamParser {
+	return &ArgsStreamParser{argName: argName}
+}
+
+// Feed appends a chunk of the raw function-call arguments JSON and returns
+// any A2UI messages completed by it.
+func (p *ArgsStreamParser) Feed(chunk string) ([]json.RawMessage, error) {
+	var out []json.RawMessage
+	for _, r := range chunk {
+		if p.done {
+			break
+		}
+		if p.value {
+			if p.escaped {
+				p.pending.WriteRune(r)
+				p.escaped = false
+				continue
+			}
+			switch r {
+			case '\\':
+				p.pending.WriteRune(r)
+				p.escaped = true
+			case '"':
+				// End of the payload string argument.
+				p.done = true
+				if err := p.flush(&out); err != nil {
+					return out, err
+				}
+			default:
+				p.pending.WriteRune(r)
+				if r == '}' || r == ']' || r == ',' {
+					if err := p.flush(&out); err != nil {
+						return out, err
+					}
+				}
+			}
+			continue
+		}
+		switch {
+		case p.inKey || p.skipStr:
+			switch {
+			case p.escaped:
+				p.escaped = false
+			case r == '\\':
+				p.escaped = true
+			case r == '"':
+				p.inKey, p.skipStr = false, false
+			default:
+				if p.inKey {
+					p.key.WriteRune(r)
+				}
+			}
+		case r == '{', r == '[':
+			p.stack = append(p.stack, byte(r))
+			p.expValue = false
+		case r == '}', r == ']':
+			if len(p.stack) > 0 {
+				p.stack = p.stack[:len(p.stack)-1]
+			}
+			p.expValue = false
+		case r == ',':
+			// Scalar values — numbers, booleans, null — have no closing
+			// token of their own; the separator ends them.
+			p.expValue = false
+		case r == ':':
+			p.expValue = true
+		case r == '"':
+			inArray := len(p.stack) > 0 && p.stack[len(p.stack)-1] == '['
+			switch {
+			case p.expValue || inArray:
+				if p.expValue && len(p.stack) == 1 && p.key.String() == p.argName {
+					p.value = true
+				} else {
+					p.skipStr = true
+				}
+				p.expValue = false
+			default:
+				p.key.Reset()
+				p.inKey = true
+			}
+		}
+	}
+	return out, nil
+}
+
+// flush unescapes the buffered payload fragment and feeds it to the message
+// parser. It is called at message-significant boundaries so escape sequences
+// are never split mid-decode.
+func (p *ArgsStreamParser) flush(out *[]json.RawMessage) error {
+	if p.escaped || p.pending.Len() == 0 {
+		return nil
+	}
+	var decoded string
+	if err := json.Unmarshal([]byte(`"`+p.pending.String()+`"`), &decoded); err != nil {
+		// An escape sequence may still be incomplete; wait for more input.
+		return nil
+	}
+	p.pending.Reset()
+	msgs, err := p.msgs.Feed(decoded)
+	*out = append(*out, msgs...)
+	return err
+}
+
+// Close reports whether a complete payload argument was seen.
+func (p *ArgsStreamParser) Close() error {
+	if !p.done {
+		return fmt.Errorf("a2ui: streamed arguments ended before %q completed", p.argName)
+	}
+	return p.msgs.Close()
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const streamedPayload = `[
+  {"beginRendering": {"surfaceId": "s1", "root": "root"}},
+  {"surfaceUpdate": {"surfaceId": "s1", "components": [
+    {"id": "root", "component": {"Text": {"text": {"literalString": "a,b}c"}}}}
+  ]}}
+]`
+
+func feedInChunks(t *testing.T, p *MessageStreamParser, payload string, size int) []json.RawMessage {
+	t.Helper()
+	var msgs []json.RawMessage
+	for i := 0; i < len(payload); i += size {
+		end := i + size
+		if end > len(payload) {
+			end = len(payload)
+		}
+		got, err := p.Feed(payload[i:end])
+		if err != nil {
+			t.Fatalf("Feed(%q): %v", payload[i:end], err)
+		}
+		msgs = append(msgs, got...)
+	}
+	return msgs
+}
+
+func TestMessageStreamParserSplitsMessages(t *testing.T) {
+	for _, size := range []int{1, 3, 7, len(streamedPayload)} {
+		p := &MessageStreamParser{}
+		msgs := feedInChunks(t, p, streamedPayload, size)
+		if err := p.Close(); err != nil {
+			t.Fatalf("chunk size %d: Close: %v", size, err)
+		}
+		if len(msgs) != 2 {
+			t.Fatalf("chunk size %d: got %d messages, want 2", size, len(msgs))
+		}
+		var first map[string]any
+		if err := json.Unmarshal(msgs[0], &first); err != nil {
+			t.Fatalf("chunk size %d: first message invalid: %v", size, err)
+		}
+		if _, ok := first["beginRendering"]; !ok {
+			t.Errorf("chunk size %d: first message %s is not beginRendering", size, msgs[0])
+		}
+	}
+}
+
+func TestMessageStreamParserEmitsFirstMessageEarly(t *testing.T) {
+	p := &MessageStreamParser{}
+	head := `[{"beginRendering": {"surfaceId": "s1", "root": "root"}}, {"surfaceUpdate": {"surfa`
+	msgs, err := p.Feed(head)
+	if err != nil {
+		t.Fatalf("Feed: %v", err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("got %d messages before payload finished, want 1", len(msgs))
+	}
+	if err := p.Close(); err == nil {
+		t.Error("Close succeeded on a truncated payload, want error")
+	}
+}
+
+func TestMessageStreamParserBareObject(t *testing.T) {
+	p := &MessageStreamParser{}
+	msgs, err := p.Feed(`{"deleteSurface": {"surfaceId": "s1"}}`)
+	if err != nil {
+		t.Fatalf("Feed: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("got %d messages, want 1", len(msgs))
+	}
+}
+
+func TestMessageStreamParserRejectsNonObject(t *testing.T) {
+	p := &MessageStreamParser{}
+	if _, err := p.Feed(`"not a message"`); err == nil {
+		t.Error("Feed accepted a non-object payload")
+	}
+}
+
+func TestArgsStreamParser(t *testing.T) {
+	args, err := json.Marshal(map[string]any{"a2ui_json": streamedPayload})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, size := range []int{1, 5, len(args)} {
+		p := NewArgsStreamParser("a2ui_json")
+		var msgs []json.RawMessage
+		for i := 0; i < len(args); i += size {
+			end := i + size
+			if end > len(args) {
+				end = len(args)
+			}
+			got, err := p.Feed(string(args[i:end]))
+			if err != nil {
+				t.Fatalf("chunk size %d: Feed: %v", size, err)
+			}
+			msgs = append(msgs, got...)
+		}
+		if err := p.Close(); err != nil {
+			t.Fatalf("chunk size %d: Close: %v", size, err)
+		}
+		if len(msgs) != 2 {
+			t.Fatalf("chunk size %d: got %d messages, want 2", size, len(msgs))
+		}
+	}
+}
+
+func TestArgsStreamParserScalarArgsBeforePayload(t *testing.T) {
+	args := `{"flag": true, "count": 3, "note": null, "a2ui_json": "[{\"deleteSurface\": {\"surfaceId\": \"s1\"}}]"}`
+	for _, size := range []int{1, 4, len(args)} {
+		p := NewArgsStreamParser("a2ui_json")
+		var msgs []json.RawMessage
+		for i := 0; i < len(args); i += size {
+			end := i + size
+			if end > len(args) {
+				end = len(args)
+			}
+			got, err := p.Feed(args[i:end])
+			if err != nil {
+				t.Fatalf("chunk size %d: Feed: %v", size, err)
+			}
+			msgs = append(msgs, got...)
+		}
+		if err := p.Close(); err != nil {
+			t.Fatalf("chunk size %d: Close: %v", size, err)
+		}
+		if len(msgs) != 1 {
+			t.Fatalf("chunk size %d: got %d messages, want 1", size, len(msgs))
+		}
+	}
+}
+
+func TestArgsStreamParserIgnoresOtherArgs(t *testing.T) {
+	p := NewArgsStreamParser("a2ui_json")
+	msgs, err := p.Feed(`{"note": "a2ui_json", "a2ui_json": "[{\"deleteSurface\": {\"surfaceId\": \"s1\"}}]"}`)
+	if err != nil {
+		t.Fatalf("Feed: %v", err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("got %d messages, want 1", len(msgs))
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import "encoding/json"
+
+// StrictSchema returns a copy of the schema with "additionalProperties":
+// false added to every object schema that declares properties but leaves
+// additionalProperties unset, so payloads with hallucinated props are
+// rejected instead of passing through to clients that may choke on them.
+// Schemas that set additionalProperties explicitly — like the standard
+// catalog's open component wrapper — are left alone.
+func StrictSchema(schema map[string]any) (map[string]any, error) {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var clone map[string]any
+	if err := json.Unmarshal(raw, &clone); err != nil {
+		return nil, err
+	}
+	strictNode(clone, true)
+	return clone, nil
+}
+
+// strictNode closes object schemas throughout the tree. isSchema is false
+// for maps whose keys are user-chosen names (the values of "properties"
+// and "$defs"), mirroring minifyNode.
+func strictNode(node map[string]any, isSchema bool) {
+	if isSchema {
+		_, hasProps := node["properties"]
+		_, hasAdditional := node["additionalProperties"]
+		if hasProps && !hasAdditional {
+			node["additionalProperties"] = false
+		}
+	}
+	for key, value := range node {
+		childIsSchema := true
+		if isSchema && (key == "properties" || key == "$defs") {
+			childIsSchema = false
+		}
+		switch child := value.(type) {
+		case map[string]any:
+			strictNode(child, childIsSchema)
+		case []any:
+			if isSchema && (key == "enum" || key == "required" || key == "examples") {
+				continue
+			}
+			for _, elem := range child {
+				if m, ok := elem.(map[string]any); ok {
+					strictNode(m, true)
+				}
+			}
+		}
+	}
+}
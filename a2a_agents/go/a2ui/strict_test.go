@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+func TestStrictSchema(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"title": map[string]any{"type": "string"},
+			"open": map[string]any{
+				"type":                 "object",
+				"properties":           map[string]any{"x": map[string]any{"type": "string"}},
+				"additionalProperties": true,
+			},
+		},
+	}
+
+	strict, err := StrictSchema(schema)
+	if err != nil {
+		t.Fatalf("StrictSchema: %v", err)
+	}
+	if strict["additionalProperties"] != false {
+		t.Error("root schema not closed")
+	}
+	open := strict["properties"].(map[string]any)["open"].(map[string]any)
+	if open["additionalProperties"] != true {
+		t.Error("explicit additionalProperties was overridden")
+	}
+	if _, ok := schema["additionalProperties"]; ok {
+		t.Error("StrictSchema modified its input")
+	}
+
+	compiled, err := jsonschema.Compile(strict)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	if err := compiled.Validate(map[string]any{"title": "ok"}); err != nil {
+		t.Errorf("valid payload rejected: %v", err)
+	}
+	if err := compiled.Validate(map[string]any{"title": "ok", "bogus": 1}); err == nil {
+		t.Error("hallucinated property passed strict validation")
+	}
+	if err := compiled.Validate(map[string]any{"open": map[string]any{"x": "a", "extra": "b"}}); err != nil {
+		t.Errorf("open sub-schema rejected extras: %v", err)
+	}
+}
+
+func TestStrictSchemaKeepsPropertyNames(t *testing.T) {
+	// A property literally named "properties" must not be treated as a
+	// schema node and closed.
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"properties": map[string]any{"type": "string"},
+		},
+	}
+	strict, err := StrictSchema(schema)
+	if err != nil {
+		t.Fatalf("StrictSchema: %v", err)
+	}
+	prop := strict["properties"].(map[string]any)["properties"].(map[string]any)
+	if _, ok := prop["additionalProperties"]; ok {
+		t.Errorf("string property was closed as an object schema: %v", prop)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SubsetSchemaComponents returns a copy of the message schema whose
+// component wrapper keeps only the named components. A skill that can only
+// ever render charts does not need form components in its prompt: injecting
+// the subset shrinks the prompt and leaves the model fewer components to
+// hallucinate, while validation keeps using the full schema. Naming a
+// component the schema does not define is an error, so typos fail loudly.
+func SubsetSchemaComponents(schema map[string]any, components []string) (map[string]any, error) {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var clone map[string]any
+	if err := json.Unmarshal(raw, &clone); err != nil {
+		return nil, err
+	}
+	wrapper, err := componentWrapper(clone)
+	if err != nil {
+		return nil, err
+	}
+	props, _ := wrapper["properties"].(map[string]any)
+	keep := make(map[string]bool, len(components))
+	var missing []string
+	for _, name := range components {
+		if _, ok := props[name]; !ok {
+			missing = append(missing, name)
+			continue
+		}
+		keep[name] = true
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("a2ui: schema does not define component(s) %s",
+			strings.Join(missing, ", "))
+	}
+	for name := range props {
+		if !keep[name] {
+			delete(props, name)
+		}
+	}
+	return clone, nil
+}
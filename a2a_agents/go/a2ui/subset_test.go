@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSubsetSchemaComponents(t *testing.T) {
+	catalog := &Catalog{
+		ID: "https://example.com/catalog.json",
+		Components: map[string]any{
+			"Chart": map[string]any{"type": "object"},
+			"Form":  map[string]any{"type": "object"},
+		},
+	}
+	schema, err := LoadA2UISchema(catalog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subset, err := SubsetSchemaComponents(schema, []string{"Chart"})
+	if err != nil {
+		t.Fatalf("SubsetSchemaComponents: %v", err)
+	}
+	wrapper, err := componentWrapper(subset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	props := wrapper["properties"].(map[string]any)
+	if _, ok := props["Chart"]; !ok {
+		t.Error("kept component missing from subset")
+	}
+	if _, ok := props["Form"]; ok {
+		t.Error("excluded component survived in subset")
+	}
+
+	// The input schema still has both components.
+	original, err := componentWrapper(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := original["properties"].(map[string]any)["Form"]; !ok {
+		t.Error("SubsetSchemaComponents mutated its input")
+	}
+}
+
+func TestSubsetSchemaComponentsUnknownName(t *testing.T) {
+	schema, err := LoadA2UISchema(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = SubsetSchemaComponents(schema, []string{"Chart", "Nope"})
+	if err == nil {
+		t.Fatal("unknown component name did not error")
+	}
+	if !strings.Contains(err.Error(), "Nope") {
+		t.Errorf("error does not name the unknown component: %v", err)
+	}
+}
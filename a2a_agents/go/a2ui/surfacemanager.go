@@ -0,0 +1,279 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// MessageIDKey is the part metadata key carrying an optional ID for one
+// A2UI message. The payload schema is closed, so the ID rides on the part
+// rather than in the message itself.
+const MessageIDKey = "a2uiMessageId"
+
+// SurfaceAcksKey is the message metadata key under which clients list the
+// IDs of A2UI messages they have rendered.
+const SurfaceAcksKey = "a2uiSurfaceAcks"
+
+// IdempotencyKeyKey is the message metadata key under which clients send
+// an idempotency key for the request, so retried sends (client retries,
+// at-least-once queues) do not create duplicate surfaces.
+const IdempotencyKeyKey = "a2uiIdempotencyKey"
+
+// IdempotencyKey extracts the client's idempotency key from a message's
+// metadata, or "" if the message carries none.
+func IdempotencyKey(msg *a2a.Message) string {
+	if msg == nil || msg.Metadata == nil {
+		return ""
+	}
+	key, _ := msg.Metadata[IdempotencyKeyKey].(string)
+	return key
+}
+
+// SurfaceAcks extracts the acknowledged message IDs from a client
+// message's metadata. It returns nil if the message carries none.
+func SurfaceAcks(msg *a2a.Message) []string {
+	if msg == nil || msg.Metadata == nil {
+		return nil
+	}
+	raw, _ := msg.Metadata[SurfaceAcksKey].([]any)
+	var ids []string
+	for _, entry := range raw {
+		if id, ok := entry.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// SurfaceManager tracks surface messages awaiting client acknowledgment,
+// so an agent can know a surface was actually rendered and retry or fall
+// back to text when it was not. Acknowledgment is optional: clients that
+// never ack simply leave messages pending until the timeout fires.
+type SurfaceManager struct {
+	timeout   time.Duration
+	onAck     func(messageID, surfaceID string)
+	onTimeout func(messageID, surfaceID string)
+	limits    DataModelLimits
+
+	mu      sync.Mutex
+	pending map[string]*pendingSurface
+	emitted map[string]emittedSurface
+	models  map[string]map[string]any
+}
+
+type pendingSurface struct {
+	surfaceID string
+	timer     *time.Timer
+}
+
+// emittedSurface remembers the outcome of one idempotent send.
+type emittedSurface struct {
+	part a2a.Part
+	id   string
+}
+
+// NewSurfaceManager creates a manager whose tracked messages time out
+// after the given duration; zero disables the deadline.
+func NewSurfaceManager(timeout time.Duration) *SurfaceManager {
+	return &SurfaceManager{
+		timeout: timeout,
+		pending: map[string]*pendingSurface{},
+		emitted: map[string]emittedSurface{},
+		models:  map[string]map[string]any{},
+	}
+}
+
+// OnAck registers the callback invoked when a tracked message is
+// acknowledged. It must be set before tracking begins.
+func (m *SurfaceManager) OnAck(f func(messageID, surfaceID string)) {
+	m.onAck = f
+}
+
+// OnTimeout registers the callback invoked when a tracked message's
+// deadline passes without an acknowledgment. It must be set before
+// tracking begins.
+func (m *SurfaceManager) OnTimeout(f func(messageID, surfaceID string)) {
+	m.onTimeout = f
+}
+
+// TrackPart wraps one A2UI message in a part stamped with a fresh message
+// ID and registers it for acknowledgment. The returned ID matches the one
+// passed to the callbacks.
+func (m *SurfaceManager) TrackPart(a2uiData map[string]any) (a2a.Part, string) {
+	id := a2a.NewID()
+	part := a2a.NewDataPart(a2uiData, map[string]any{
+		MIMETypeKey:  MIMEType,
+		MessageIDKey: id,
+	})
+
+	entry := &pendingSurface{surfaceID: messageSurfaceID(a2uiData)}
+	if m.timeout > 0 {
+		entry.timer = time.AfterFunc(m.timeout, func() { m.expire(id) })
+	}
+	m.mu.Lock()
+	m.pending[id] = entry
+	m.mu.Unlock()
+	return part, id
+}
+
+// TrackPartIdempotent is TrackPart with an idempotency key: the first
+// send under a key is tracked normally, and every retry under the same
+// key replays the originally emitted part and ID instead of creating a
+// duplicate surface message. An empty key disables the check. replayed
+// reports whether a previous result was returned.
+func (m *SurfaceManager) TrackPartIdempotent(key string, a2uiData map[string]any) (part a2a.Part, messageID string, replayed bool) {
+	if key == "" {
+		part, messageID = m.TrackPart(a2uiData)
+		return part, messageID, false
+	}
+	m.mu.Lock()
+	if previous, ok := m.emitted[key]; ok {
+		m.mu.Unlock()
+		return previous.part, previous.id, true
+	}
+	m.mu.Unlock()
+
+	part, messageID = m.TrackPart(a2uiData)
+	m.mu.Lock()
+	m.emitted[key] = emittedSurface{part: part, id: messageID}
+	m.mu.Unlock()
+	return part, messageID, false
+}
+
+// SetDataModelLimits caps the snapshots future SetDataModel calls
+// accept. It must be set before tracking begins; the zero value leaves
+// models unlimited.
+func (m *SurfaceManager) SetDataModelLimits(limits DataModelLimits) {
+	m.limits = limits
+}
+
+// SetDataModel records the surface's current data model and returns the
+// dataModelUpdate messages for what changed since the previous call (see
+// DiffDataModel), so refreshing a large dataset resends only the paths
+// that moved. The first call for a surface emits the full model. On
+// error — including a model over the configured limits — the recorded
+// snapshot is unchanged.
+func (m *SurfaceManager) SetDataModel(surfaceID string, model map[string]any) ([]map[string]any, error) {
+	if err := m.limits.Check(model); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	previous := m.models[surfaceID]
+	m.mu.Unlock()
+	updates, err := DiffDataModel(surfaceID, previous, model)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	m.models[surfaceID] = copyDataModel(model)
+	m.mu.Unlock()
+	return updates, nil
+}
+
+// HandleAcks resolves the acknowledgments carried by a client message and
+// reports how many tracked messages they matched.
+func (m *SurfaceManager) HandleAcks(msg *a2a.Message) int {
+	matched := 0
+	for _, id := range SurfaceAcks(msg) {
+		if m.ack(id) {
+			matched++
+		}
+	}
+	return matched
+}
+
+// Pending returns the IDs of tracked messages not yet acknowledged, in
+// stable order.
+func (m *SurfaceManager) Pending() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.pending))
+	for id := range m.pending {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Close drops all tracked messages, remembered idempotent sends and
+// data-model snapshots without firing callbacks.
+func (m *SurfaceManager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, entry := range m.pending {
+		if entry.timer != nil {
+			entry.timer.Stop()
+		}
+		delete(m.pending, id)
+	}
+	m.emitted = map[string]emittedSurface{}
+	m.models = map[string]map[string]any{}
+}
+
+// ack removes one pending message and fires the ack callback.
+func (m *SurfaceManager) ack(id string) bool {
+	m.mu.Lock()
+	entry, ok := m.pending[id]
+	if ok {
+		if entry.timer != nil {
+			entry.timer.Stop()
+		}
+		delete(m.pending, id)
+	}
+	m.mu.Unlock()
+	if !ok {
+		return false
+	}
+	if m.onAck != nil {
+		m.onAck(id, entry.surfaceID)
+	}
+	return true
+}
+
+// expire removes one pending message after its deadline and fires the
+// timeout callback.
+func (m *SurfaceManager) expire(id string) {
+	m.mu.Lock()
+	entry, ok := m.pending[id]
+	if ok {
+		delete(m.pending, id)
+	}
+	m.mu.Unlock()
+	if !ok {
+		// Acked between the timer firing and the lock; nothing to report.
+		return
+	}
+	if m.onTimeout != nil {
+		m.onTimeout(id, entry.surfaceID)
+	}
+}
+
+// messageSurfaceID extracts the surface ID an A2UI message targets. Every
+// message type carries it one level under the message key.
+func messageSurfaceID(msg map[string]any) string {
+	for _, body := range msg {
+		if wrapper, ok := body.(map[string]any); ok {
+			if id, ok := wrapper["surfaceId"].(string); ok {
+				return id
+			}
+		}
+	}
+	return ""
+}
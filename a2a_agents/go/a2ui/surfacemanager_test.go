@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func TestSurfaceManagerAck(t *testing.T) {
+	m := NewSurfaceManager(0)
+	var acked []string
+	m.OnAck(func(messageID, surfaceID string) {
+		acked = append(acked, messageID+":"+surfaceID)
+	})
+
+	part, id := m.TrackPart(map[string]any{
+		"surfaceUpdate": map[string]any{"surfaceId": "main", "components": []any{}},
+	})
+	if !IsA2UIPart(part) {
+		t.Error("tracked part is not marked as A2UI content")
+	}
+	if got := part.Metadata[MessageIDKey]; got != id {
+		t.Errorf("part metadata ID = %v, want %q", got, id)
+	}
+	if got := m.Pending(); len(got) != 1 || got[0] != id {
+		t.Errorf("Pending = %v, want [%s]", got, id)
+	}
+
+	matched := m.HandleAcks(&a2a.Message{Metadata: map[string]any{
+		SurfaceAcksKey: []any{id, "unknown"},
+	}})
+	if matched != 1 {
+		t.Errorf("HandleAcks = %d, want 1", matched)
+	}
+	if len(acked) != 1 || acked[0] != id+":main" {
+		t.Errorf("ack callbacks = %v", acked)
+	}
+	if got := m.Pending(); len(got) != 0 {
+		t.Errorf("Pending after ack = %v", got)
+	}
+
+	// A second ack for the same ID is a no-op.
+	if m.HandleAcks(&a2a.Message{Metadata: map[string]any{SurfaceAcksKey: []any{id}}}) != 0 {
+		t.Error("duplicate ack matched")
+	}
+}
+
+func TestSurfaceManagerTimeout(t *testing.T) {
+	m := NewSurfaceManager(10 * time.Millisecond)
+	timedOut := make(chan string, 1)
+	m.OnTimeout(func(messageID, surfaceID string) {
+		timedOut <- surfaceID
+	})
+
+	m.TrackPart(map[string]any{
+		"beginRendering": map[string]any{"surfaceId": "slow", "root": "root"},
+	})
+	select {
+	case surfaceID := <-timedOut:
+		if surfaceID != "slow" {
+			t.Errorf("timed-out surface = %q, want slow", surfaceID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout callback never fired")
+	}
+	if got := m.Pending(); len(got) != 0 {
+		t.Errorf("Pending after timeout = %v", got)
+	}
+}
+
+func TestSurfaceManagerClose(t *testing.T) {
+	m := NewSurfaceManager(10 * time.Millisecond)
+	m.OnTimeout(func(messageID, surfaceID string) {
+		t.Errorf("timeout fired for %s after Close", messageID)
+	})
+	m.TrackPart(map[string]any{
+		"deleteSurface": map[string]any{"surfaceId": "main"},
+	})
+	m.Close()
+	if got := m.Pending(); len(got) != 0 {
+		t.Errorf("Pending after Close = %v", got)
+	}
+	time.Sleep(30 * time.Millisecond)
+}
+
+func TestSurfaceAcks(t *testing.T) {
+	if SurfaceAcks(nil) != nil {
+		t.Error("nil message should have no acks")
+	}
+	if SurfaceAcks(&a2a.Message{}) != nil {
+		t.Error("message without metadata should have no acks")
+	}
+	ids := SurfaceAcks(&a2a.Message{Metadata: map[string]any{
+		SurfaceAcksKey: []any{"a", 42, "b"},
+	}})
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Errorf("SurfaceAcks = %v, want [a b]", ids)
+	}
+}
+
+func TestSurfaceManagerIdempotency(t *testing.T) {
+	m := NewSurfaceManager(0)
+	msg := map[string]any{
+		"surfaceUpdate": map[string]any{"surfaceId": "main", "components": []any{}},
+	}
+
+	part, id, replayed := m.TrackPartIdempotent("req-1", msg)
+	if replayed {
+		t.Error("first send reported as replayed")
+	}
+	again, againID, replayed := m.TrackPartIdempotent("req-1", msg)
+	if !replayed || againID != id {
+		t.Errorf("retry: replayed = %v, id = %q, want %q", replayed, againID, id)
+	}
+	if again.Metadata[MessageIDKey] != part.Metadata[MessageIDKey] {
+		t.Error("retry produced a different part")
+	}
+	if got := m.Pending(); len(got) != 1 {
+		t.Errorf("Pending = %v, want the one original message", got)
+	}
+
+	// The replay survives acknowledgment of the original.
+	m.HandleAcks(&a2a.Message{Metadata: map[string]any{SurfaceAcksKey: []any{id}}})
+	if _, _, replayed := m.TrackPartIdempotent("req-1", msg); !replayed {
+		t.Error("retry after ack was not replayed")
+	}
+
+	// A different key is a fresh send, and empty keys are never cached.
+	if _, otherID, replayed := m.TrackPartIdempotent("req-2", msg); replayed || otherID == id {
+		t.Errorf("distinct key: replayed = %v, id = %q", replayed, otherID)
+	}
+	_, first, _ := m.TrackPartIdempotent("", msg)
+	_, second, _ := m.TrackPartIdempotent("", msg)
+	if first == second {
+		t.Error("empty key deduplicated")
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	if IdempotencyKey(nil) != "" || IdempotencyKey(&a2a.Message{}) != "" {
+		t.Error("missing metadata should yield an empty key")
+	}
+	msg := &a2a.Message{Metadata: map[string]any{IdempotencyKeyKey: "req-9"}}
+	if got := IdempotencyKey(msg); got != "req-9" {
+		t.Errorf("IdempotencyKey = %q, want req-9", got)
+	}
+}
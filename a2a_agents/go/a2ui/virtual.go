@@ -0,0 +1,153 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// RowsActionName is the userAction name the framework reserves for
+// virtualized row requests. A list component nearing the edge of its
+// loaded data sends {"surfaceId": ..., "startRow": ..., "rowCount": ...}
+// as the action context.
+const RowsActionName = "a2uiRequestRows"
+
+// defaultMaxWindow bounds one row request when no explicit cap is given.
+const defaultMaxWindow = 500
+
+// RowProvider returns total row count and the rows for one window,
+// [start, start+count), of a virtualized list. Each row is a flat map of
+// scalars. Windows past the end return the rows that exist.
+type RowProvider func(ctx context.Context, start, count int) (rows []map[string]any, total int, err error)
+
+// Virtualizer serves on-demand windows of row data to list components
+// that render far more rows than a data model should inline. Register
+// wires a surface's list to its provider; Window fills the initial rows
+// when the surface is built, and HandleAction answers the RowsActionName
+// userAction as the client scrolls. Rows land in the data model under
+// <path>/rows/<absolute row index> and the dataset size at
+// <path>/totalRows, so windows merge instead of overwriting each other.
+type Virtualizer struct {
+	maxWindow int
+
+	mu    sync.Mutex
+	lists map[string]virtualList
+}
+
+type virtualList struct {
+	path     string
+	provider RowProvider
+}
+
+// NewVirtualizer returns a virtualizer serving at most maxWindow rows
+// per request — a scroll cannot be turned into a wholesale download;
+// maxWindow <= 0 uses a reasonable default.
+func NewVirtualizer(maxWindow int) *Virtualizer {
+	if maxWindow <= 0 {
+		maxWindow = defaultMaxWindow
+	}
+	return &Virtualizer{maxWindow: maxWindow, lists: map[string]virtualList{}}
+}
+
+// Register wires the surface's list, rooted at the data-model path, to
+// its provider. A surface holds at most one virtualized list;
+// registering again replaces it.
+func (v *Virtualizer) Register(surfaceID, path string, provider RowProvider) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lists[surfaceID] = virtualList{path: path, provider: provider}
+}
+
+// Unregister drops the surface's list, e.g. when the surface is closed.
+func (v *Virtualizer) Unregister(surfaceID string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.lists, surfaceID)
+}
+
+// Window fetches count rows starting at start and returns the
+// dataModelUpdate messages writing them into the surface. Requests
+// beyond the per-request cap are clamped, not failed, so a client asking
+// for too much still makes progress.
+func (v *Virtualizer) Window(ctx context.Context, surfaceID string, start, count int) ([]map[string]any, error) {
+	v.mu.Lock()
+	list, ok := v.lists[surfaceID]
+	v.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("a2ui: surface %q has no virtualized list", surfaceID)
+	}
+	if start < 0 {
+		start = 0
+	}
+	if count > v.maxWindow {
+		count = v.maxWindow
+	}
+	rows, total, err := list.provider(ctx, start, count)
+	if err != nil {
+		return nil, fmt.Errorf("a2ui: fetching rows for surface %q: %w", surfaceID, err)
+	}
+	updates := make([]map[string]any, 0, len(rows)+1)
+	for i, row := range rows {
+		update, err := dataModelUpdate(surfaceID, fmt.Sprintf("%s/rows/%d", list.path, start+i), row)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, update)
+	}
+	totalUpdate, err := dataModelUpdate(surfaceID, list.path+"/totalRows", total)
+	if err != nil {
+		return nil, err
+	}
+	return append(updates, totalUpdate), nil
+}
+
+// HandleAction answers a row-request userAction. handled reports whether
+// the action was one: other actions, and row requests for surfaces with
+// no registered list, pass through for the executor to handle.
+func (v *Virtualizer) HandleAction(ctx context.Context, action map[string]any) (updates []map[string]any, handled bool, err error) {
+	if action == nil || action["name"] != RowsActionName {
+		return nil, false, nil
+	}
+	actionContext, _ := action["context"].(map[string]any)
+	surfaceID, _ := actionContext["surfaceId"].(string)
+	v.mu.Lock()
+	_, ok := v.lists[surfaceID]
+	v.mu.Unlock()
+	if !ok {
+		return nil, false, nil
+	}
+	start, ok := actionNumber(actionContext, "startRow")
+	if !ok {
+		return nil, true, fmt.Errorf("a2ui: row request for surface %q lacks a startRow", surfaceID)
+	}
+	count, ok := actionNumber(actionContext, "rowCount")
+	if !ok || count <= 0 {
+		return nil, true, fmt.Errorf("a2ui: row request for surface %q lacks a positive rowCount", surfaceID)
+	}
+	updates, err = v.Window(ctx, surfaceID, start, count)
+	return updates, true, err
+}
+
+// actionNumber reads an integer from a decoded action context, where
+// JSON numbers arrive as float64.
+func actionNumber(actionContext map[string]any, key string) (int, bool) {
+	n, ok := actionContext[key].(float64)
+	if !ok {
+		return 0, false
+	}
+	return int(n), true
+}
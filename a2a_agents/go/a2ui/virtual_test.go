@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// numberedRows serves a dataset of 1000 numbered rows.
+func numberedRows(ctx context.Context, start, count int) ([]map[string]any, int, error) {
+	const total = 1000
+	if start >= total {
+		return nil, total, nil
+	}
+	if start+count > total {
+		count = total - start
+	}
+	rows := make([]map[string]any, count)
+	for i := range rows {
+		rows[i] = map[string]any{"label": fmt.Sprintf("row %d", start+i)}
+	}
+	return rows, total, nil
+}
+
+func TestVirtualizerWindow(t *testing.T) {
+	v := NewVirtualizer(0)
+	v.Register("main", "/orders", numberedRows)
+
+	updates, err := v.Window(context.Background(), "main", 200, 3)
+	if err != nil {
+		t.Fatalf("Window: %v", err)
+	}
+	// Three rows plus totalRows.
+	if len(updates) != 4 {
+		t.Fatalf("got %d updates, want 4: %v", len(updates), updates)
+	}
+	// Rows keep their absolute indexes so windows merge.
+	first := updates[0]["dataModelUpdate"].(map[string]any)
+	if first["path"] != "/orders/rows" {
+		t.Errorf("row path = %v, want /orders/rows", first["path"])
+	}
+	entry := first["contents"].([]any)[0].(map[string]any)
+	if entry["key"] != "200" {
+		t.Errorf("first row key = %v, want 200", entry["key"])
+	}
+	total := updates[3]["dataModelUpdate"].(map[string]any)
+	entry = total["contents"].([]any)[0].(map[string]any)
+	if entry["key"] != "totalRows" || entry["valueNumber"] != 1000.0 {
+		t.Errorf("total entry = %v", entry)
+	}
+}
+
+func TestVirtualizerClampsWindow(t *testing.T) {
+	v := NewVirtualizer(10)
+	v.Register("main", "/orders", numberedRows)
+	updates, err := v.Window(context.Background(), "main", 0, 100000)
+	if err != nil {
+		t.Fatalf("Window: %v", err)
+	}
+	if len(updates) != 11 {
+		t.Errorf("got %d updates, want the 10-row cap plus totalRows", len(updates))
+	}
+}
+
+func TestVirtualizerHandleAction(t *testing.T) {
+	v := NewVirtualizer(0)
+	v.Register("main", "/orders", numberedRows)
+
+	updates, handled, err := v.HandleAction(context.Background(), map[string]any{
+		"name":    RowsActionName,
+		"context": map[string]any{"surfaceId": "main", "startRow": 200.0, "rowCount": 2.0},
+	})
+	if err != nil || !handled {
+		t.Fatalf("HandleAction: handled=%v err=%v", handled, err)
+	}
+	if len(updates) != 3 {
+		t.Errorf("got %d updates, want 3", len(updates))
+	}
+
+	// Malformed requests are handled but fail.
+	if _, handled, err := v.HandleAction(context.Background(), map[string]any{
+		"name":    RowsActionName,
+		"context": map[string]any{"surfaceId": "main"},
+	}); !handled || err == nil {
+		t.Errorf("malformed request: handled=%v err=%v", handled, err)
+	}
+
+	// Other actions and unknown surfaces pass through.
+	if _, handled, _ := v.HandleAction(context.Background(), map[string]any{"name": "submit"}); handled {
+		t.Error("non-row action was handled")
+	}
+	v.Unregister("main")
+	if _, handled, _ := v.HandleAction(context.Background(), map[string]any{
+		"name":    RowsActionName,
+		"context": map[string]any{"surfaceId": "main", "startRow": 0.0, "rowCount": 1.0},
+	}); handled {
+		t.Error("row request after Unregister was handled")
+	}
+}
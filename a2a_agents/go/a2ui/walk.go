@@ -0,0 +1,207 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import "errors"
+
+// SkipChildren, returned from an enter visit, prunes the component's
+// subtree from the walk. The component's own leave visit still runs.
+var SkipChildren = errors.New("a2ui: skip children")
+
+// ComponentNode is one component as the walker visits it. Props is the
+// live property map from the message, so mutating it edits the message
+// in place.
+type ComponentNode struct {
+	// SurfaceID identifies the surface the component belongs to.
+	SurfaceID string
+	// ID is the component's id within its surface.
+	ID string
+	// Type is the component's type name, e.g. "Text".
+	Type string
+	// Props is the component's property map. Mutations write through to
+	// the message being walked.
+	Props map[string]any
+	// Parent is the component that references this one, or nil for a
+	// root.
+	Parent *ComponentNode
+	// Depth is the number of ancestors above the node; roots are 0.
+	Depth int
+
+	wrapper map[string]any
+}
+
+// Replace swaps the component's type and properties in place, the way
+// DegradeToStandard rewrites custom components. The node's Type and
+// Props are updated to the new values.
+func (n *ComponentNode) Replace(typeName string, props map[string]any) {
+	component, _ := n.wrapper["component"].(map[string]any)
+	delete(component, n.Type)
+	component[typeName] = props
+	n.Type = typeName
+	n.Props = props
+}
+
+// VisitFunc is called once per component during a walk. Returning
+// SkipChildren from an enter visit prunes the subtree; any other
+// non-nil error aborts the walk and is returned to the caller.
+type VisitFunc func(node *ComponentNode) error
+
+// Walk visits every component of every surfaceUpdate in messages,
+// depth-first. enter runs before a component's children (pre-order) and
+// leave after them (post-order); either may be nil. Components no other
+// component references are the roots, visited in list order. Visitors
+// may mutate Props or call Replace; the differ, sanitizer and linter
+// build on this instead of re-implementing the recursion.
+func Walk(messages []map[string]any, enter, leave VisitFunc) error {
+	for _, msg := range messages {
+		update, _ := msg["surfaceUpdate"].(map[string]any)
+		if update == nil {
+			continue
+		}
+		if err := WalkSurface(update, enter, leave); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkSurface visits every component of a single surfaceUpdate payload,
+// with the same traversal and visitor contract as Walk.
+func WalkSurface(update map[string]any, enter, leave VisitFunc) error {
+	surfaceID, _ := update["surfaceId"].(string)
+	components, _ := update["components"].([]any)
+	byID := map[string]map[string]any{}
+	referenced := map[string]bool{}
+	var order []map[string]any
+	for _, entry := range components {
+		wrapper, _ := entry.(map[string]any)
+		if wrapper == nil {
+			continue
+		}
+		if id, _ := wrapper["id"].(string); id != "" {
+			byID[id] = wrapper
+		}
+		order = append(order, wrapper)
+		for _, value := range componentProperties(wrapper) {
+			props, _ := value.(map[string]any)
+			for _, child := range childComponentIDs(props) {
+				referenced[child] = true
+			}
+		}
+	}
+	for _, wrapper := range order {
+		id, _ := wrapper["id"].(string)
+		if referenced[id] {
+			continue
+		}
+		if err := walkComponent(surfaceID, wrapper, nil, byID, map[string]bool{}, enter, leave); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkComponent visits one component and recurses into its children.
+// visiting guards against reference cycles.
+func walkComponent(surfaceID string, wrapper map[string]any, parent *ComponentNode, byID map[string]map[string]any, visiting map[string]bool, enter, leave VisitFunc) error {
+	id, _ := wrapper["id"].(string)
+	if visiting[id] {
+		return nil
+	}
+	visiting[id] = true
+	defer delete(visiting, id)
+
+	node := &ComponentNode{SurfaceID: surfaceID, ID: id, wrapper: wrapper}
+	if parent != nil {
+		node.Parent = parent
+		node.Depth = parent.Depth + 1
+	}
+	for name, value := range componentProperties(wrapper) {
+		node.Type = name
+		node.Props, _ = value.(map[string]any)
+	}
+
+	skip := false
+	if enter != nil {
+		switch err := enter(node); {
+		case errors.Is(err, SkipChildren):
+			skip = true
+		case err != nil:
+			return err
+		}
+	}
+	if !skip {
+		for _, childID := range childComponentIDs(node.Props) {
+			child, ok := byID[childID]
+			if !ok {
+				continue
+			}
+			if err := walkComponent(surfaceID, child, node, byID, visiting, enter, leave); err != nil {
+				return err
+			}
+		}
+	}
+	if leave != nil {
+		if err := leave(node); err != nil && !errors.Is(err, SkipChildren) {
+			return err
+		}
+	}
+	return nil
+}
+
+// componentProperties unwraps a component list entry to its single
+// {typeName: props} map.
+func componentProperties(wrapper map[string]any) map[string]any {
+	component, _ := wrapper["component"].(map[string]any)
+	return component
+}
+
+// childComponentIDs collects the component IDs a property map references
+// as children: explicit child lists, template component IDs, and the
+// single-child properties of Button, Card, Modal and Tabs.
+func childComponentIDs(props map[string]any) []string {
+	var ids []string
+	for key, value := range props {
+		switch key {
+		case "child", "entryPointChild", "contentChild":
+			if id, ok := value.(string); ok && id != "" {
+				ids = append(ids, id)
+			}
+		case "children":
+			children, _ := value.(map[string]any)
+			if list, ok := children["explicitList"].([]any); ok {
+				for _, entry := range list {
+					if id, ok := entry.(string); ok && id != "" {
+						ids = append(ids, id)
+					}
+				}
+			}
+			if template, ok := children["template"].(map[string]any); ok {
+				if id, ok := template["componentId"].(string); ok && id != "" {
+					ids = append(ids, id)
+				}
+			}
+		case "tabItems":
+			items, _ := value.([]any)
+			for _, entry := range items {
+				item, _ := entry.(map[string]any)
+				if id, ok := item["child"].(string); ok && id != "" {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2ui
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// walkSurfaceUpdate builds a surfaceUpdate with a Column root holding a
+// Text and a Card that wraps a Button.
+func walkSurfaceUpdate() map[string]any {
+	return map[string]any{
+		"surfaceId": "main",
+		"components": []any{
+			map[string]any{"id": "root", "component": map[string]any{
+				"Column": map[string]any{
+					"children": map[string]any{"explicitList": []any{"title", "card"}},
+				},
+			}},
+			map[string]any{"id": "title", "component": map[string]any{
+				"Text": map[string]any{
+					"text": map[string]any{"literalString": "Hello"},
+				},
+			}},
+			map[string]any{"id": "card", "component": map[string]any{
+				"Card": map[string]any{"child": "ok"},
+			}},
+			map[string]any{"id": "ok", "component": map[string]any{
+				"Button": map[string]any{
+					"child":  "title",
+					"action": map[string]any{"name": "ok"},
+				},
+			}},
+		},
+	}
+}
+
+func TestWalkSurfaceOrder(t *testing.T) {
+	var entered, left []string
+	err := WalkSurface(walkSurfaceUpdate(),
+		func(n *ComponentNode) error {
+			entered = append(entered, n.ID)
+			return nil
+		},
+		func(n *ComponentNode) error {
+			left = append(left, n.ID)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("WalkSurface: %v", err)
+	}
+	wantEntered := []string{"root", "title", "card", "ok", "title"}
+	if !reflect.DeepEqual(entered, wantEntered) {
+		t.Errorf("pre-order = %v, want %v", entered, wantEntered)
+	}
+	wantLeft := []string{"title", "title", "ok", "card", "root"}
+	if !reflect.DeepEqual(left, wantLeft) {
+		t.Errorf("post-order = %v, want %v", left, wantLeft)
+	}
+}
+
+func TestWalkNodeDetails(t *testing.T) {
+	var buttonNode *ComponentNode
+	err := WalkSurface(walkSurfaceUpdate(), func(n *ComponentNode) error {
+		if n.ID == "ok" {
+			buttonNode = n
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("WalkSurface: %v", err)
+	}
+	if buttonNode == nil {
+		t.Fatal("button component was not visited")
+	}
+	if buttonNode.SurfaceID != "main" || buttonNode.Type != "Button" || buttonNode.Depth != 2 {
+		t.Errorf("node = %s/%s depth %d, want main/Button depth 2", buttonNode.SurfaceID, buttonNode.Type, buttonNode.Depth)
+	}
+	if buttonNode.Parent == nil || buttonNode.Parent.ID != "card" {
+		t.Errorf("parent = %v, want card", buttonNode.Parent)
+	}
+}
+
+func TestWalkSkipChildren(t *testing.T) {
+	var entered []string
+	err := WalkSurface(walkSurfaceUpdate(), func(n *ComponentNode) error {
+		entered = append(entered, n.ID)
+		if n.ID == "card" {
+			return SkipChildren
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("WalkSurface: %v", err)
+	}
+	want := []string{"root", "title", "card"}
+	if !reflect.DeepEqual(entered, want) {
+		t.Errorf("visited = %v, want %v", entered, want)
+	}
+}
+
+func TestWalkAbortsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	var entered []string
+	err := WalkSurface(walkSurfaceUpdate(), func(n *ComponentNode) error {
+		entered = append(entered, n.ID)
+		if n.ID == "title" {
+			return boom
+		}
+		return nil
+	}, nil)
+	if !errors.Is(err, boom) {
+		t.Fatalf("WalkSurface = %v, want the visitor's error", err)
+	}
+	if len(entered) != 2 {
+		t.Errorf("visited %v after the aborting visit", entered)
+	}
+}
+
+func TestWalkMutation(t *testing.T) {
+	update := walkSurfaceUpdate()
+	err := WalkSurface(update, func(n *ComponentNode) error {
+		if n.Type == "Text" {
+			n.Props["usageHint"] = "h1"
+		}
+		if n.Type == "Button" {
+			n.Replace("Text", map[string]any{
+				"text": map[string]any{"literalString": "unavailable"},
+			})
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("WalkSurface: %v", err)
+	}
+	components := update["components"].([]any)
+	title := components[1].(map[string]any)["component"].(map[string]any)["Text"].(map[string]any)
+	if title["usageHint"] != "h1" {
+		t.Error("Props mutation did not write through to the message")
+	}
+	button := components[3].(map[string]any)["component"].(map[string]any)
+	if _, ok := button["Button"]; ok {
+		t.Error("Replace left the old component type in place")
+	}
+	if _, ok := button["Text"]; !ok {
+		t.Error("Replace did not install the new component type")
+	}
+}
+
+func TestWalkMessagesAndCycles(t *testing.T) {
+	messages := []map[string]any{
+		{"beginRendering": map[string]any{"surfaceId": "main", "root": "root"}},
+		{"surfaceUpdate": walkSurfaceUpdate()},
+		// A reference cycle terminates instead of recursing forever.
+		{"surfaceUpdate": map[string]any{
+			"surfaceId": "loop",
+			"components": []any{
+				map[string]any{"id": "r", "component": map[string]any{
+					"Card": map[string]any{"child": "a"},
+				}},
+				map[string]any{"id": "a", "component": map[string]any{
+					"Card": map[string]any{"child": "b"},
+				}},
+				map[string]any{"id": "b", "component": map[string]any{
+					"Card": map[string]any{"child": "a"},
+				}},
+			},
+		}},
+	}
+	count := 0
+	err := Walk(messages, func(n *ComponentNode) error {
+		count++
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if count == 0 {
+		t.Error("Walk visited no components")
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package a2uitest provides helpers for testing A2UI agents: a stable
+// textual snapshot of surfaces and data models, and assertions that
+// produce readable diffs when a prompt or builder changes behavior.
+package a2uitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+// Snapshot renders A2UI messages as stable indented text: one line per
+// component with its type, id and sorted properties, nested by the
+// child references; data-model updates as sorted key = value lines.
+// Equal payloads always snapshot identically, so the output is safe to
+// lock into a test or a golden file.
+func Snapshot(messages []map[string]any) string {
+	var b strings.Builder
+	for _, msg := range messages {
+		switch {
+		case msg["beginRendering"] != nil:
+			begin, _ := msg["beginRendering"].(map[string]any)
+			fmt.Fprintf(&b, "begin %s root=%v\n", begin["surfaceId"], begin["root"])
+		case msg["surfaceUpdate"] != nil:
+			update, _ := msg["surfaceUpdate"].(map[string]any)
+			fmt.Fprintf(&b, "surface %s\n", update["surfaceId"])
+			a2ui.WalkSurface(update, func(n *a2ui.ComponentNode) error {
+				fmt.Fprintf(&b, "%s%s #%s%s\n", strings.Repeat("  ", n.Depth+1), n.Type, n.ID, snapshotProps(n.Props))
+				return nil
+			}, nil)
+		case msg["dataModelUpdate"] != nil:
+			update, _ := msg["dataModelUpdate"].(map[string]any)
+			fmt.Fprintf(&b, "data %s %s\n", update["surfaceId"], update["path"])
+			contents, _ := update["contents"].([]any)
+			for _, line := range snapshotContents(contents) {
+				fmt.Fprintf(&b, "  %s\n", line)
+			}
+		case msg["deleteSurface"] != nil:
+			del, _ := msg["deleteSurface"].(map[string]any)
+			fmt.Fprintf(&b, "delete %s\n", del["surfaceId"])
+		default:
+			fmt.Fprintf(&b, "message %s\n", compactJSON(msg))
+		}
+	}
+	return b.String()
+}
+
+// AssertSnapshot fails the test when the messages do not snapshot to
+// want, reporting the first differing line and the full snapshot so the
+// expectation can be updated by copying it. Both sides are dedented by
+// their common leading whitespace, so want can be an indented raw
+// string; relative indentation still has to match.
+func AssertSnapshot(t testing.TB, messages []map[string]any, want string) {
+	t.Helper()
+	got := Snapshot(messages)
+	gotLines := trimmedLines(got)
+	wantLines := trimmedLines(want)
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		g, w := "<end>", "<end>"
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			t.Errorf("snapshot mismatch at line %d:\nwant: %s\n got: %s\nfull snapshot:\n%s", i+1, w, g, got)
+			return
+		}
+	}
+}
+
+// snapshotProps renders a component's properties sorted by name,
+// skipping the pure child references the tree indentation already
+// shows.
+func snapshotProps(props map[string]any) string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		switch key {
+		case "child", "children", "entryPointChild", "contentChild":
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%s", key, compactJSON(props[key]))
+	}
+	return b.String()
+}
+
+// snapshotContents renders dataModelUpdate contents entries as sorted
+// "key = value" lines, decoding the typed value fields back to plain
+// values.
+func snapshotContents(contents []any) []string {
+	var lines []string
+	for _, entry := range contents {
+		m, _ := entry.(map[string]any)
+		if m == nil {
+			continue
+		}
+		key, _ := m["key"].(string)
+		lines = append(lines, fmt.Sprintf("%s = %s", key, compactJSON(entryValue(m))))
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// entryValue unwraps a contents entry's typed value field; valueMap
+// adjacency lists become plain maps.
+func entryValue(entry map[string]any) any {
+	for _, field := range []string{"valueString", "valueNumber", "valueBoolean"} {
+		if value, ok := entry[field]; ok {
+			return value
+		}
+	}
+	if list, ok := entry["valueMap"].([]any); ok {
+		value := map[string]any{}
+		for _, nested := range list {
+			m, _ := nested.(map[string]any)
+			if key, _ := m["key"].(string); key != "" {
+				value[key] = entryValue(m)
+			}
+		}
+		return value
+	}
+	return nil
+}
+
+// compactJSON renders a value as compact JSON; encoding/json sorts map
+// keys, which keeps the rendering stable.
+func compactJSON(value any) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(raw)
+}
+
+// trimmedLines splits a snapshot into lines, drops blank ones, strips
+// the common leading whitespace and trims line ends, so raw-string
+// expectations can be indented freely without losing the tree's
+// relative indentation.
+func trimmedLines(s string) []string {
+	// Raw strings in tests usually indent with tabs; the snapshot uses
+	// two spaces per level.
+	s = strings.ReplaceAll(s, "\t", "  ")
+	var lines []string
+	indent := -1
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+		leading := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || leading < indent {
+			indent = leading
+		}
+	}
+	for i, line := range lines {
+		lines[i] = line[indent:]
+	}
+	return lines
+}
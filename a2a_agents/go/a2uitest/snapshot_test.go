@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a2uitest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// snapshotMessages is a small surface with a data-model update.
+func snapshotMessages() []map[string]any {
+	return []map[string]any{
+		{"beginRendering": map[string]any{"surfaceId": "main", "root": "root"}},
+		{"surfaceUpdate": map[string]any{
+			"surfaceId": "main",
+			"components": []any{
+				map[string]any{"id": "root", "component": map[string]any{
+					"Column": map[string]any{
+						"children": map[string]any{"explicitList": []any{"title", "ok"}},
+					},
+				}},
+				map[string]any{"id": "title", "component": map[string]any{
+					"Text": map[string]any{
+						"text":      map[string]any{"literalString": "Hello"},
+						"usageHint": "h1",
+					},
+				}},
+				map[string]any{"id": "ok", "component": map[string]any{
+					"Button": map[string]any{
+						"child":  "oklabel",
+						"action": map[string]any{"name": "ok"},
+					},
+				}},
+				map[string]any{"id": "oklabel", "component": map[string]any{
+					"Text": map[string]any{
+						"text": map[string]any{"literalString": "OK"},
+					},
+				}},
+			},
+		}},
+		{"dataModelUpdate": map[string]any{
+			"surfaceId": "main",
+			"path":      "/",
+			"contents": []any{
+				map[string]any{"key": "count", "valueNumber": float64(3)},
+				map[string]any{"key": "user", "valueMap": []any{
+					map[string]any{"key": "name", "valueString": "Ada"},
+				}},
+			},
+		}},
+	}
+}
+
+func TestSnapshotStable(t *testing.T) {
+	first := Snapshot(snapshotMessages())
+	for i := 0; i < 10; i++ {
+		if got := Snapshot(snapshotMessages()); got != first {
+			t.Fatalf("snapshot is unstable:\n%s\nvs\n%s", first, got)
+		}
+	}
+	for _, want := range []string{
+		"begin main root=root",
+		"surface main",
+		`Text #title text={"literalString":"Hello"} usageHint="h1"`,
+		`Button #ok action={"name":"ok"}`,
+		"data main /",
+		"count = 3",
+		`user = {"name":"Ada"}`,
+	} {
+		if !strings.Contains(first, want) {
+			t.Errorf("snapshot is missing %q:\n%s", want, first)
+		}
+	}
+}
+
+func TestAssertSnapshot(t *testing.T) {
+	AssertSnapshot(t, snapshotMessages(), `
+		begin main root=root
+		surface main
+		  Column #root
+		    Text #title text={"literalString":"Hello"} usageHint="h1"
+		    Button #ok action={"name":"ok"}
+		      Text #oklabel text={"literalString":"OK"}
+		data main /
+		  count = 3
+		  user = {"name":"Ada"}
+	`)
+}
+
+func TestAssertSnapshotMismatch(t *testing.T) {
+	var probe mismatchProbe
+	AssertSnapshot(&probe, snapshotMessages(), "begin main root=other")
+	if !probe.failed {
+		t.Fatal("AssertSnapshot accepted a wrong snapshot")
+	}
+	if !strings.Contains(probe.message, "root=other") || !strings.Contains(probe.message, "root=root") {
+		t.Errorf("mismatch report does not show both sides:\n%s", probe.message)
+	}
+}
+
+// mismatchProbe records assertion failures instead of failing the test.
+type mismatchProbe struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (p *mismatchProbe) Helper() {}
+
+func (p *mismatchProbe) Errorf(format string, args ...any) {
+	p.failed = true
+	p.message = fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+func TestHandleSendA2UIAppliesAliases(t *testing.T) {
+	e, err := New(Config{
+		Schema: testSchema,
+		Aliases: a2ui.AliasMap{
+			"LineChart": {Target: "Chart", Props: map[string]any{"type": "line"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(8)
+
+	payload := `[{"surfaceUpdate": {"surfaceId": "s1", "components": [` +
+		`{"id": "c1", "component": {"LineChart": {}}}]}}]`
+	result := e.handleSendA2UI(context.Background(), rc, queue, map[string]any{a2uiJSONArgName: payload})
+	if result[toolErrorKey] != nil {
+		t.Fatalf("send tool error: %v", result[toolErrorKey])
+	}
+	queue.Close()
+
+	event, ok := queue.Read()
+	if !ok {
+		t.Fatal("no artifact emitted")
+	}
+	artifact := event.(*a2a.ArtifactUpdateEvent).Artifact
+	component := artifact.Parts[0].Data["surfaceUpdate"].(map[string]any)["components"].([]any)[0].(map[string]any)["component"].(map[string]any)
+	chart, ok := component["Chart"].(map[string]any)
+	if !ok {
+		t.Fatalf("emitted component not aliased: %v", component)
+	}
+	if chart["type"] != "line" {
+		t.Errorf("alias props missing: %v", chart)
+	}
+}
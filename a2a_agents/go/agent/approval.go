@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// ErrPendingNotFound is returned by Approve and Reject for unknown or
+// already-resolved payload IDs.
+var ErrPendingNotFound = errors.New("agent: pending payload not found")
+
+// PendingPayload is a validated payload parked in the approval gate,
+// awaiting a reviewer's decision.
+type PendingPayload struct {
+	// ID identifies the payload to Approve and Reject.
+	ID        string
+	TaskID    string
+	ContextID string
+	// Messages are the validated A2UI messages that would be emitted.
+	Messages []map[string]any
+	// Received is when the payload was parked.
+	Received time.Time
+}
+
+// ApprovalGate parks validated payloads until a human reviewer releases
+// them, for deployments that cannot ship model-generated UI without review.
+// Configure it via Config.Approval; the reviewer side lists Pending payloads
+// and calls Approve or Reject.
+type ApprovalGate struct {
+	mu      sync.Mutex
+	pending map[string]*pendingEntry
+}
+
+type pendingEntry struct {
+	payload *PendingPayload
+	event   *a2a.ArtifactUpdateEvent
+	queue   *a2a.EventQueue
+	onEmit  func() // runs after the payload is approved and emitted
+}
+
+// NewApprovalGate returns an empty gate.
+func NewApprovalGate() *ApprovalGate {
+	return &ApprovalGate{pending: map[string]*pendingEntry{}}
+}
+
+// park stores a payload and the artifact event that would have been emitted,
+// returning the pending ID.
+func (g *ApprovalGate) park(queue *a2a.EventQueue, event *a2a.ArtifactUpdateEvent, messages []map[string]any, onEmit func()) string {
+	id := a2a.NewID()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pending[id] = &pendingEntry{
+		payload: &PendingPayload{
+			ID:        id,
+			TaskID:    event.TaskID,
+			ContextID: event.ContextID,
+			Messages:  messages,
+			Received:  time.Now(),
+		},
+		event:  event,
+		queue:  queue,
+		onEmit: onEmit,
+	}
+	return id
+}
+
+// Pending lists parked payloads, oldest first.
+func (g *ApprovalGate) Pending() []*PendingPayload {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	payloads := make([]*PendingPayload, 0, len(g.pending))
+	for _, entry := range g.pending {
+		payloads = append(payloads, entry.payload)
+	}
+	sort.Slice(payloads, func(i, j int) bool { return payloads[i].Received.Before(payloads[j].Received) })
+	return payloads
+}
+
+// Approve releases a parked payload to its task's event queue. It fails if
+// the ID is unknown or the task's queue has already closed.
+func (g *ApprovalGate) Approve(id string) error {
+	entry, err := g.take(id)
+	if err != nil {
+		return err
+	}
+	if err := entry.queue.Write(entry.event); err != nil {
+		return fmt.Errorf("agent: emitting approved payload: %w", err)
+	}
+	if entry.onEmit != nil {
+		entry.onEmit()
+	}
+	return nil
+}
+
+// Reject discards a parked payload.
+func (g *ApprovalGate) Reject(id string) error {
+	_, err := g.take(id)
+	return err
+}
+
+func (g *ApprovalGate) take(id string) (*pendingEntry, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	entry, ok := g.pending[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPendingNotFound, id)
+	}
+	delete(g.pending, id)
+	return entry, nil
+}
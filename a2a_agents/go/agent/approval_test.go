@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+func drainEvents(queue *a2a.EventQueue) (artifacts int) {
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			return artifacts
+		}
+		if _, ok := event.(*a2a.ArtifactUpdateEvent); ok {
+			artifacts++
+		}
+	}
+}
+
+func TestApprovalGateParksAndReleases(t *testing.T) {
+	payload := `{"` + a2uiJSONArgName + `": "[{\"beginRendering\": {\"surfaceId\": \"s1\", \"root\": \"root\"}}]"}`
+	server, _ := fakeModelToolServer(t, payload)
+	gate := NewApprovalGate()
+	e, err := New(Config{
+		Client:   gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema:   testSchema,
+		Approval: gate,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	pending := gate.Pending()
+	if len(pending) != 1 {
+		t.Fatalf("got %d pending payloads, want 1", len(pending))
+	}
+	if pending[0].TaskID != "task1" || len(pending[0].Messages) != 1 {
+		t.Errorf("pending payload = %+v", pending[0])
+	}
+
+	if err := gate.Approve(pending[0].ID); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	queue.Close()
+	if got := drainEvents(queue); got != 1 {
+		t.Errorf("got %d artifacts after approval, want 1", got)
+	}
+	if len(gate.Pending()) != 0 {
+		t.Error("approved payload still pending")
+	}
+	if err := gate.Approve(pending[0].ID); !errors.Is(err, ErrPendingNotFound) {
+		t.Errorf("re-approving resolved payload: %v, want ErrPendingNotFound", err)
+	}
+}
+
+func TestApprovalGateReject(t *testing.T) {
+	payload := `{"` + a2uiJSONArgName + `": "[{\"beginRendering\": {\"surfaceId\": \"s1\", \"root\": \"root\"}}]"}`
+	server, _ := fakeModelToolServer(t, payload)
+	gate := NewApprovalGate()
+	e, err := New(Config{
+		Client:   gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema:   testSchema,
+		Approval: gate,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	pending := gate.Pending()
+	if len(pending) != 1 {
+		t.Fatalf("got %d pending payloads, want 1", len(pending))
+	}
+	if err := gate.Reject(pending[0].ID); err != nil {
+		t.Fatalf("Reject: %v", err)
+	}
+	queue.Close()
+	if got := drainEvents(queue); got != 0 {
+		t.Errorf("rejected payload still emitted %d artifacts", got)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// AuditRecord is one link in the audit chain: one emitted payload, bound to
+// its predecessor by hash so after-the-fact edits are detectable.
+type AuditRecord struct {
+	// Seq is the record's position in the chain, starting at 0.
+	Seq       int       `json:"seq"`
+	TaskID    string    `json:"taskId"`
+	ContextID string    `json:"contextId"`
+	Timestamp time.Time `json:"timestamp"`
+	// PayloadHash is the SHA-256 of the canonical JSON of the emitted
+	// messages.
+	PayloadHash string `json:"payloadHash"`
+	// PrevHash is the Hash of the previous record; "" for the first.
+	PrevHash string `json:"prevHash"`
+	// Hash chains this record to its predecessor.
+	Hash string `json:"hash"`
+}
+
+// AuditLog records each emitted payload in a tamper-evident hash chain, so
+// operators can later prove exactly what UI was shown in a session.
+// Configure it via Config.Audit.
+type AuditLog struct {
+	mu      sync.Mutex
+	records []AuditRecord
+	now     func() time.Time // injectable for tests
+}
+
+// NewAuditLog returns an empty audit log.
+func NewAuditLog() *AuditLog {
+	return &AuditLog{now: time.Now}
+}
+
+// record appends one emitted payload to the chain.
+func (l *AuditLog) record(taskID, contextID string, messages []map[string]any) {
+	h := sha256.New()
+	if err := json.NewEncoder(h).Encode(messages); err != nil {
+		// Messages round-tripped through encoding/json to get here; this is
+		// unreachable in practice.
+		fmt.Fprintf(h, "unmarshalable: %v", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	record := AuditRecord{
+		Seq:         len(l.records),
+		TaskID:      taskID,
+		ContextID:   contextID,
+		Timestamp:   l.now().UTC(),
+		PayloadHash: hex.EncodeToString(h.Sum(nil)),
+	}
+	if n := len(l.records); n > 0 {
+		record.PrevHash = l.records[n-1].Hash
+	}
+	record.Hash = chainHash(record)
+	l.records = append(l.records, record)
+}
+
+// Records returns a copy of the chain, oldest first.
+func (l *AuditLog) Records() []AuditRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]AuditRecord(nil), l.records...)
+}
+
+// ForTask returns the chain's records for one task, oldest first.
+func (l *AuditLog) ForTask(taskID string) []AuditRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var records []AuditRecord
+	for _, record := range l.records {
+		if record.TaskID == taskID {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// Verify recomputes the hash chain and reports the first record that does
+// not check out, or nil if the log is intact.
+func (l *AuditLog) Verify() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	prev := ""
+	for i, record := range l.records {
+		if record.Seq != i {
+			return fmt.Errorf("agent: audit record %d has sequence %d", i, record.Seq)
+		}
+		if record.PrevHash != prev {
+			return fmt.Errorf("agent: audit record %d does not chain to its predecessor", i)
+		}
+		if record.Hash != chainHash(record) {
+			return fmt.Errorf("agent: audit record %d hash mismatch", i)
+		}
+		prev = record.Hash
+	}
+	return nil
+}
+
+// chainHash binds a record's fields and its predecessor's hash.
+func chainHash(record AuditRecord) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\n%s\n%s\n%s\n%s\n%s\n",
+		record.Seq, record.TaskID, record.ContextID,
+		record.Timestamp.Format(time.RFC3339Nano), record.PayloadHash, record.PrevHash)
+	return hex.EncodeToString(h.Sum(nil))
+}
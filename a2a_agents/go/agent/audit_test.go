@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+func TestAuditLogChainsAndVerifies(t *testing.T) {
+	log := NewAuditLog()
+	m1 := []map[string]any{{"beginRendering": map[string]any{"surfaceId": "s1", "root": "r"}}}
+	m2 := []map[string]any{{"deleteSurface": map[string]any{"surfaceId": "s1"}}}
+	log.record("task1", "ctx1", m1)
+	log.record("task2", "ctx1", m2)
+
+	if err := log.Verify(); err != nil {
+		t.Fatalf("Verify on intact log: %v", err)
+	}
+	records := log.Records()
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if records[0].PrevHash != "" || records[1].PrevHash != records[0].Hash {
+		t.Error("records are not chained")
+	}
+	if got := log.ForTask("task2"); len(got) != 1 || got[0].Seq != 1 {
+		t.Errorf("ForTask(task2) = %v", got)
+	}
+}
+
+func TestAuditLogDetectsTampering(t *testing.T) {
+	log := NewAuditLog()
+	log.record("task1", "ctx1", []map[string]any{{"deleteSurface": map[string]any{"surfaceId": "s1"}}})
+	log.record("task1", "ctx1", []map[string]any{{"deleteSurface": map[string]any{"surfaceId": "s2"}}})
+	log.records[0].TaskID = "someone-else"
+	if err := log.Verify(); err == nil {
+		t.Fatal("tampered record passed verification")
+	}
+}
+
+func TestExecuteRecordsAudit(t *testing.T) {
+	payload := `{"` + a2uiJSONArgName + `": "[{\"beginRendering\": {\"surfaceId\": \"s1\", \"root\": \"root\"}}]"}`
+	server, _ := fakeModelToolServer(t, payload)
+	log := NewAuditLog()
+	e, err := New(Config{
+		Client: gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema: testSchema,
+		Audit:  log,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	queue.Close()
+	if got := log.ForTask("task1"); len(got) != 1 {
+		t.Fatalf("audit has %d records for task1, want 1", len(got))
+	}
+	if err := log.Verify(); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
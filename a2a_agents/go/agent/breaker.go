@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"sync"
+	"time"
+)
+
+// BreakerConfig tunes the circuit breaker around the LLM backend.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive backend failures that
+	// opens the circuit. Defaults to 5.
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a single probe
+	// call is allowed through. Defaults to 30 seconds.
+	Cooldown time.Duration
+}
+
+const (
+	defaultFailureThreshold = 5
+	defaultCooldown         = 30 * time.Second
+)
+
+// breakerUnavailableMessage is the user-facing explanation emitted when the
+// circuit is open and the request fails fast.
+const breakerUnavailableMessage = "The agent's language model backend is " +
+	"temporarily unavailable. Please try again in a moment."
+
+// circuitBreaker fails LLM calls fast while the backend is returning
+// sustained errors, instead of letting every request hang for the full
+// timeout.
+type circuitBreaker struct {
+	cfg BreakerConfig
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+	now      func() time.Time
+}
+
+func newCircuitBreaker(cfg BreakerConfig) *circuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = defaultCooldown
+	}
+	return &circuitBreaker{cfg: cfg, now: time.Now}
+}
+
+// allow reports whether a backend call may proceed. While the circuit is
+// open, only a single probe call per cooldown period is let through.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.cfg.FailureThreshold {
+		return true
+	}
+	if b.now().Sub(b.openedAt) < b.cfg.Cooldown || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// recordSuccess closes the circuit.
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+}
+
+// recordFailure counts a backend failure, opening (or re-opening) the
+// circuit at the threshold.
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.failures >= b.cfg.FailureThreshold {
+		b.openedAt = b.now()
+	}
+}
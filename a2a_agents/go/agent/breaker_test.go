@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	b := newCircuitBreaker(BreakerConfig{FailureThreshold: 3, Cooldown: time.Minute})
+	now := time.Unix(1000, 0)
+	b.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if !b.allow() {
+			t.Fatalf("breaker open after %d failures, threshold is 3", i)
+		}
+		b.recordFailure()
+	}
+	if b.allow() {
+		t.Fatal("breaker still closed after reaching the failure threshold")
+	}
+
+	// After the cooldown a single probe is allowed through.
+	now = now.Add(2 * time.Minute)
+	if !b.allow() {
+		t.Fatal("probe not allowed after cooldown")
+	}
+	if b.allow() {
+		t.Fatal("second concurrent probe allowed")
+	}
+
+	// A failing probe re-opens the circuit for another cooldown.
+	b.recordFailure()
+	if b.allow() {
+		t.Fatal("breaker closed after failed probe")
+	}
+
+	// A successful probe closes the circuit again.
+	now = now.Add(2 * time.Minute)
+	if !b.allow() {
+		t.Fatal("probe not allowed after second cooldown")
+	}
+	b.recordSuccess()
+	if !b.allow() {
+		t.Fatal("breaker open after successful probe")
+	}
+}
+
+func TestCircuitBreakerResetsOnSuccess(t *testing.T) {
+	b := newCircuitBreaker(BreakerConfig{FailureThreshold: 2, Cooldown: time.Minute})
+	b.recordFailure()
+	b.recordSuccess()
+	b.recordFailure()
+	if !b.allow() {
+		t.Fatal("non-consecutive failures opened the breaker")
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// schemaCache uploads the schema block and tool declarations as Gemini
+// cached content once, so they are referenced by handle per request instead
+// of re-sent in every prompt.
+type schemaCache struct {
+	once sync.Once
+	name string
+}
+
+// cachedContentName returns the cache handle for the schema block and tool
+// declarations, creating it on first use. It returns "" — meaning send the
+// block inline — when caching is disabled or the upload fails; a cache
+// failure must not take the agent down.
+func (e *Executor) cachedContentName(ctx context.Context) string {
+	if e.cfg.SchemaCacheTTL <= 0 {
+		return ""
+	}
+	e.cache.once.Do(func() {
+		cacher, ok := e.cfg.Client.(ContentCacher)
+		if !ok {
+			slog.Warn("schema block caching disabled: backend does not support cached content")
+			return
+		}
+		created, err := cacher.CreateCachedContent(ctx, &gemini.CachedContent{
+			SystemInstruction: &gemini.Content{Parts: []gemini.Part{{Text: a2ui.SchemaInstructionBlock(e.promptSchemaDoc)}}},
+			Tools:             []gemini.Tool{{FunctionDeclarations: e.declarations()}},
+			TTL:               fmt.Sprintf("%ds", int(e.cfg.SchemaCacheTTL.Seconds())),
+		})
+		if err != nil {
+			slog.Warn("schema block caching disabled: cache upload failed", "error", err)
+			return
+		}
+		slog.Info("schema block cached", "name", created.Name)
+		e.cache.name = created.Name
+	})
+	return e.cache.name
+}
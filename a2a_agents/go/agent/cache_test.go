@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+func TestExecuteUsesSchemaCache(t *testing.T) {
+	var creates int
+	var generates []gemini.GenerateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/cachedContents"):
+			creates++
+			var content gemini.CachedContent
+			if err := json.NewDecoder(r.Body).Decode(&content); err != nil {
+				t.Errorf("decoding cache request: %v", err)
+			}
+			if content.SystemInstruction == nil || len(content.Tools) == 0 {
+				t.Error("cache upload missing schema block or tools")
+			}
+			content.Name = "cachedContents/test"
+			json.NewEncoder(w).Encode(content)
+		default:
+			var req gemini.GenerateRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decoding model request: %v", err)
+			}
+			generates = append(generates, req)
+			json.NewEncoder(w).Encode(gemini.GenerateResponse{Candidates: []gemini.Candidate{{
+				Content: gemini.Content{Role: "model", Parts: []gemini.Part{{Text: "done"}}},
+			}}})
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	e, err := New(Config{
+		Client:         gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Instructions:   "be helpful",
+		Schema:         testSchema,
+		SchemaCacheTTL: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	for _, taskID := range []string{"task1", "task2"} {
+		queue := a2a.NewEventQueue(16)
+		if err := e.Execute(context.Background(), a2uiRequestContext(taskID), queue); err != nil {
+			t.Fatalf("Execute(%s): %v", taskID, err)
+		}
+		queue.Close()
+	}
+
+	if creates != 1 {
+		t.Errorf("cache created %d times, want once", creates)
+	}
+	if len(generates) != 2 {
+		t.Fatalf("model called %d times, want 2", len(generates))
+	}
+	for _, req := range generates {
+		if req.CachedContent != "cachedContents/test" {
+			t.Errorf("request cachedContent = %q, want handle", req.CachedContent)
+		}
+		if req.SystemInstruction != nil || len(req.Tools) != 0 {
+			t.Error("cached request repeated systemInstruction or tools")
+		}
+		if len(req.Contents) < 2 || !strings.Contains(req.Contents[0].Parts[0].Text, "be helpful") {
+			t.Error("instructions not carried as the leading user turn")
+		}
+	}
+}
+
+func TestExecuteFallsBackWhenCacheUploadFails(t *testing.T) {
+	var generates int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cachedContents") {
+			http.Error(w, "no caching for you", http.StatusBadRequest)
+			return
+		}
+		var req gemini.GenerateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding model request: %v", err)
+		}
+		generates++
+		if req.CachedContent != "" {
+			t.Error("request references a cache that failed to upload")
+		}
+		if req.SystemInstruction == nil {
+			t.Error("fallback request is missing the inline systemInstruction")
+		}
+		json.NewEncoder(w).Encode(gemini.GenerateResponse{Candidates: []gemini.Candidate{{
+			Content: gemini.Content{Role: "model", Parts: []gemini.Part{{Text: "done"}}},
+		}}})
+	}))
+	t.Cleanup(server.Close)
+
+	e, err := New(Config{
+		Client:         gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema:         testSchema,
+		SchemaCacheTTL: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if generates != 1 {
+		t.Errorf("model called %d times, want 1", generates)
+	}
+}
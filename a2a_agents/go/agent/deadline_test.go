@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+	"github.com/google/A2UI/a2a_agents/go/tools"
+)
+
+// slowLLM blocks until the request context expires.
+type slowLLM struct{}
+
+func (slowLLM) GenerateContent(ctx context.Context, req *gemini.GenerateRequest) (*gemini.GenerateResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (slowLLM) Model() string { return "slow-model" }
+
+func TestPhaseContextZeroIsPassThrough(t *testing.T) {
+	ctx := context.Background()
+	bounded, cancel := phaseContext(ctx, 0)
+	defer cancel()
+	if bounded != ctx {
+		t.Error("phaseContext(0) wrapped the context")
+	}
+	bounded, cancel = phaseContext(ctx, time.Minute)
+	defer cancel()
+	if _, ok := bounded.Deadline(); !ok {
+		t.Error("phaseContext(1m) set no deadline")
+	}
+}
+
+func TestExecuteFailsAtExecutionTimeout(t *testing.T) {
+	e, err := New(Config{
+		Client:           slowLLM{},
+		Schema:           testSchema,
+		ExecutionTimeout: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	queue := a2a.NewEventQueue(64)
+	err = e.Execute(context.Background(), a2uiRequestContext("task1"), queue)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Execute = %v, want DeadlineExceeded", err)
+	}
+	final := finalStatus(t, queue)
+	if final.Status.State != a2a.TaskStateFailed {
+		t.Errorf("final state = %s, want failed", final.Status.State)
+	}
+	if got := messageText(final.Status.Message); got != timeoutMessage {
+		t.Errorf("failure message = %q, want the timeout explanation", got)
+	}
+}
+
+func TestExecuteFailsAtLLMTimeout(t *testing.T) {
+	e, err := New(Config{
+		Client:     slowLLM{},
+		Schema:     testSchema,
+		LLMTimeout: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	queue := a2a.NewEventQueue(64)
+	err = e.Execute(context.Background(), a2uiRequestContext("task1"), queue)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Execute = %v, want DeadlineExceeded", err)
+	}
+	final := finalStatus(t, queue)
+	if got := messageText(final.Status.Message); got != timeoutMessage {
+		t.Errorf("failure message = %q, want the timeout explanation", got)
+	}
+}
+
+// stuckTool blocks until its context expires, like a tool wedged on a
+// backend that never answers.
+type stuckTool struct{}
+
+func (stuckTool) Declaration() *tools.Declaration {
+	return &tools.Declaration{Name: "stuck", Description: "test tool"}
+}
+
+func (stuckTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestToolTimeoutReportedToModel(t *testing.T) {
+	llm := &fakeLLM{responses: []*gemini.GenerateResponse{
+		callResponse("stuck"),
+		textResponse("Carrying on without it."),
+	}}
+	e, err := New(Config{
+		Client:      llm,
+		Schema:      testSchema,
+		Tools:       []tools.Tool{stuckTool{}},
+		ToolTimeout: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	queue := a2a.NewEventQueue(64)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	// The deadline surfaces as a tool error for the model, not a task
+	// failure.
+	feedback := llm.requests[1].Contents
+	last := feedback[len(feedback)-1]
+	response := last.Parts[0].FunctionResponse.Response
+	msg, _ := response[toolErrorKey].(string)
+	if !strings.Contains(msg, "deadline") {
+		t.Errorf("tool response = %v, want an error naming the deadline", response)
+	}
+	final := finalStatus(t, queue)
+	if final.Status.State != a2a.TaskStateCompleted {
+		t.Errorf("final state = %s, want completed", final.Status.State)
+	}
+}
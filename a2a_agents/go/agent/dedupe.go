@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"sync"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// deduper tracks content hashes of A2UI payloads already emitted per task,
+// so identical surfaces sent twice by the model — common after tool-error
+// retries — are suppressed instead of rendered again.
+type deduper struct {
+	mu     sync.Mutex
+	byTask map[string]map[string]bool
+}
+
+func newDeduper() *deduper {
+	return &deduper{byTask: map[string]map[string]bool{}}
+}
+
+// filter returns the payloads not yet emitted for the task, records their
+// hashes, and reports how many duplicates were dropped.
+func (d *deduper) filter(taskID string, payloads []map[string]any) (fresh []map[string]any, duplicates int) {
+	indexes, duplicates := d.filterIndex(taskID, payloads)
+	for _, i := range indexes {
+		fresh = append(fresh, payloads[i])
+	}
+	return fresh, duplicates
+}
+
+// filterIndex is filter returning the indexes of the fresh payloads, for
+// callers that carry parallel per-payload state such as raw JSON
+// segments.
+func (d *deduper) filterIndex(taskID string, payloads []map[string]any) (indexes []int, duplicates int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	seen := d.byTask[taskID]
+	if seen == nil {
+		seen = map[string]bool{}
+		d.byTask[taskID] = seen
+	}
+	for i, payload := range payloads {
+		hash, err := payloadHash(payload)
+		if err != nil {
+			// Without a hash the payload cannot be matched against
+			// earlier sends; pass it through rather than risk dropping
+			// fresh content.
+			slog.Warn("payload hash failed, skipping dedupe", "error", err)
+			indexes = append(indexes, i)
+			continue
+		}
+		if seen[hash] {
+			duplicates++
+			continue
+		}
+		seen[hash] = true
+		indexes = append(indexes, i)
+	}
+	return indexes, duplicates
+}
+
+// forget drops the hash set for a task once it reaches a final state.
+func (d *deduper) forget(taskID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.byTask, taskID)
+}
+
+// payloadHash returns a stable content hash for a payload. The default
+// codec sorts map keys, so equal payloads hash equally regardless of
+// insertion order; hashes only need to agree within one process, so any
+// deterministic codec works. The payload is encoded straight into the
+// hasher — large dashboards would otherwise allocate a full JSON copy
+// per call just to hash it. The default codec cannot fail on a payload
+// that came from its own Unmarshal, but a codec installed via
+// a2a.SetCodec can, so the error is surfaced rather than swallowed.
+func payloadHash(payload map[string]any) (string, error) {
+	h := sha256.New()
+	if err := a2a.ActiveCodec().Encode(h, payload); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func TestDeduperFiltersRepeatedPayloads(t *testing.T) {
+	d := newDeduper()
+	p1 := map[string]any{"beginRendering": map[string]any{"surfaceId": "s1", "root": "root"}}
+	p2 := map[string]any{"deleteSurface": map[string]any{"surfaceId": "s1"}}
+
+	fresh, dupes := d.filter("task1", []map[string]any{p1, p2})
+	if len(fresh) != 2 || dupes != 0 {
+		t.Fatalf("first filter = %d fresh, %d dupes; want 2, 0", len(fresh), dupes)
+	}
+	fresh, dupes = d.filter("task1", []map[string]any{p1, p2})
+	if len(fresh) != 0 || dupes != 2 {
+		t.Fatalf("second filter = %d fresh, %d dupes; want 0, 2", len(fresh), dupes)
+	}
+	// A different task is tracked independently.
+	fresh, _ = d.filter("task2", []map[string]any{p1})
+	if len(fresh) != 1 {
+		t.Fatalf("other task filter = %d fresh, want 1", len(fresh))
+	}
+	// Forgetting a task resets its hashes.
+	d.forget("task1")
+	fresh, _ = d.filter("task1", []map[string]any{p1})
+	if len(fresh) != 1 {
+		t.Fatalf("filter after forget = %d fresh, want 1", len(fresh))
+	}
+}
+
+func TestDeduperIgnoresKeyOrder(t *testing.T) {
+	d := newDeduper()
+	a := map[string]any{"beginRendering": map[string]any{"surfaceId": "s1", "root": "r"}}
+	b := map[string]any{"beginRendering": map[string]any{"root": "r", "surfaceId": "s1"}}
+	d.filter("t", []map[string]any{a})
+	fresh, dupes := d.filter("t", []map[string]any{b})
+	if len(fresh) != 0 || dupes != 1 {
+		t.Fatalf("reordered payload not deduplicated: %d fresh, %d dupes", len(fresh), dupes)
+	}
+}
+
+// testSchema accepts any A2UI-shaped message object.
+var testSchema = map[string]any{"type": "object"}
+
+func testExecutor(t *testing.T) *Executor {
+	t.Helper()
+	e, err := New(Config{Schema: testSchema})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return e
+}
+
+func TestHandleSendA2UISuppressesDuplicates(t *testing.T) {
+	e := testExecutor(t)
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(8)
+	args := map[string]any{
+		a2uiJSONArgName: `[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`,
+	}
+
+	resp := e.handleSendA2UI(context.Background(), rc, queue, args)
+	if _, ok := resp[toolErrorKey]; ok {
+		t.Fatalf("first send failed: %v", resp)
+	}
+	event, ok := queue.Read()
+	if !ok {
+		t.Fatal("no artifact event emitted")
+	}
+	artifact, ok := event.(*a2a.ArtifactUpdateEvent)
+	if !ok || len(artifact.Artifact.Parts) != 1 {
+		t.Fatalf("unexpected event %#v", event)
+	}
+
+	resp = e.handleSendA2UI(context.Background(), rc, queue, args)
+	if _, ok := resp[toolErrorKey]; ok {
+		t.Fatalf("duplicate send errored: %v", resp)
+	}
+	if got := resp[duplicateCountKey]; got != 1 {
+		t.Errorf("duplicate count = %v, want 1", got)
+	}
+	queue.Close()
+	if extra, ok := queue.Read(); ok {
+		t.Errorf("duplicate payload emitted a second artifact: %#v", extra)
+	}
+}
+
+func TestHandleSendA2UIRejectsInvalidJSON(t *testing.T) {
+	e := testExecutor(t)
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(8)
+	resp := e.handleSendA2UI(context.Background(), rc, queue, map[string]any{a2uiJSONArgName: "{not json"})
+	if _, ok := resp[toolErrorKey]; !ok {
+		t.Fatalf("malformed payload accepted: %v", resp)
+	}
+}
+
+func TestHandleSendA2UIWrapsSingleObject(t *testing.T) {
+	e := testExecutor(t)
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(8)
+	resp := e.handleSendA2UI(context.Background(), rc, queue, map[string]any{
+		a2uiJSONArgName: `{"deleteSurface": {"surfaceId": "s1"}}`,
+	})
+	validated, ok := resp[validatedA2UIJSONKey].([]any)
+	if !ok || len(validated) != 1 {
+		raw, _ := json.Marshal(resp)
+		t.Fatalf("single object not wrapped: %s", raw)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+// DevLoader re-reads prompt inputs from disk on every request instead of
+// using the strings baked in at startup, and logs a line diff whenever
+// the content changes, so prompt authors can iterate against a live agent
+// without restarting it. It is a development tool; production agents
+// should bake their prompts in. A nil DevLoader loads nothing.
+type DevLoader struct {
+	instructionsPath string
+	examplesDir      string
+	catalog          *a2ui.Catalog
+
+	mu           sync.Mutex
+	instructions string
+	library      *a2ui.ExampleLibrary
+}
+
+// NewDevLoader builds a loader for an instructions file and an example
+// directory; either path may be empty to disable that input. The catalog
+// validates reloaded examples, as at startup.
+func NewDevLoader(instructionsPath, examplesDir string, catalog *a2ui.Catalog) *DevLoader {
+	return &DevLoader{
+		instructionsPath: instructionsPath,
+		examplesDir:      examplesDir,
+		catalog:          catalog,
+	}
+}
+
+// Instructions re-reads the instructions file. On a read error — e.g. the
+// editor is mid-save — the last successfully read content is returned, so
+// a request never sees a half-written prompt.
+func (l *DevLoader) Instructions() string {
+	if l == nil || l.instructionsPath == "" {
+		return ""
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	raw, err := os.ReadFile(l.instructionsPath)
+	if err != nil {
+		slog.Warn("instructions reload failed, keeping previous version",
+			"path", l.instructionsPath, "error", err)
+		return l.instructions
+	}
+	text := string(raw)
+	if text != l.instructions {
+		logDiff("instructions", l.instructions, text)
+		l.instructions = text
+	}
+	return text
+}
+
+// Library reloads and revalidates the example directory. On a load or
+// validation error the last good library is returned, so a broken example
+// degrades to stale instead of failing requests.
+func (l *DevLoader) Library() *a2ui.ExampleLibrary {
+	if l == nil || l.examplesDir == "" {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	library, err := a2ui.LoadExampleLibrary(l.examplesDir, l.catalog)
+	if err != nil {
+		slog.Warn("example reload failed, keeping previous library",
+			"dir", l.examplesDir, "error", err)
+		return l.library
+	}
+	if l.library != nil {
+		logExampleDiff(l.library, library)
+	}
+	l.library = library
+	return library
+}
+
+// logDiff logs the lines removed from and added to a reloaded input. It
+// is a set diff, not a positional one — enough to see what changed in a
+// prompt without pulling in a real diff implementation.
+func logDiff(what, before, after string) {
+	removed := missingLines(before, after)
+	added := missingLines(after, before)
+	if len(removed) == 0 && len(added) == 0 {
+		return
+	}
+	var b strings.Builder
+	for _, line := range removed {
+		b.WriteString("- " + line + "\n")
+	}
+	for _, line := range added {
+		b.WriteString("+ " + line + "\n")
+	}
+	slog.Info("reloaded "+what, "diff", b.String())
+}
+
+// missingLines returns the lines of a that do not appear in b, respecting
+// multiplicity.
+func missingLines(a, b string) []string {
+	counts := map[string]int{}
+	for _, line := range strings.Split(b, "\n") {
+		counts[line]++
+	}
+	var missing []string
+	for _, line := range strings.Split(a, "\n") {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		missing = append(missing, line)
+	}
+	return missing
+}
+
+// logExampleDiff logs examples whose payload changed between reloads, and
+// ones that appeared or disappeared.
+func logExampleDiff(before, after *a2ui.ExampleLibrary) {
+	seen := map[string]bool{}
+	for _, example := range before.Examples() {
+		seen[example.Name] = true
+		current := after.Get(example.Name)
+		switch {
+		case current == nil:
+			slog.Info("example removed", "name", example.Name)
+		case string(current.Payload) != string(example.Payload):
+			slog.Info("example changed", "name", example.Name, "path", current.Path)
+		}
+	}
+	for _, example := range after.Examples() {
+		if !seen[example.Name] {
+			slog.Info("example added", "name", example.Name, "path", example.Path)
+		}
+	}
+}
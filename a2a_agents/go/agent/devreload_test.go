@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDevLoaderReloadsInstructions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "instructions.txt")
+	if err := os.WriteFile(path, []byte("version one"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loader := NewDevLoader(path, "", nil)
+	if got := loader.Instructions(); got != "version one" {
+		t.Fatalf("Instructions = %q", got)
+	}
+	if err := os.WriteFile(path, []byte("version two"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := loader.Instructions(); got != "version two" {
+		t.Errorf("Instructions after edit = %q, want %q", got, "version two")
+	}
+	// A read error keeps the last good content.
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := loader.Instructions(); got != "version two" {
+		t.Errorf("Instructions after delete = %q, want last good version", got)
+	}
+}
+
+func TestDevLoaderNil(t *testing.T) {
+	var loader *DevLoader
+	if got := loader.Instructions(); got != "" {
+		t.Errorf("nil loader Instructions = %q", got)
+	}
+	if lib := loader.Library(); lib != nil {
+		t.Errorf("nil loader Library = %v", lib)
+	}
+}
+
+func TestMissingLines(t *testing.T) {
+	removed := missingLines("a\nb\nb\nc", "a\nb\nc")
+	if !reflect.DeepEqual(removed, []string{"b"}) {
+		t.Errorf("missingLines = %v, want duplicate-aware [b]", removed)
+	}
+	if extra := missingLines("a\nb", "a\nb"); extra != nil {
+		t.Errorf("missingLines on equal input = %v", extra)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+func TestExecuteDryRunEmitsPreviewArtifact(t *testing.T) {
+	payload := `{"` + a2uiJSONArgName + `": "[{\"beginRendering\": {\"surfaceId\": \"s1\", \"root\": \"root\"}}]"}`
+	server, _ := fakeModelToolServer(t, payload)
+	e, err := New(Config{
+		Client: gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema: testSchema,
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	queue.Close()
+
+	var preview bool
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		if ev, ok := event.(*a2a.ArtifactUpdateEvent); ok {
+			if ev.Artifact.Name == A2UIArtifactName {
+				t.Error("dry run emitted a renderable a2ui artifact")
+			}
+			if ev.Artifact.Name == PreviewArtifactName && ev.Artifact.Metadata[DryRunMetadataKey] == true {
+				preview = true
+			}
+		}
+	}
+	if !preview {
+		t.Error("dry run did not emit a tagged preview artifact")
+	}
+}
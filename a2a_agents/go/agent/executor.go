@@ -0,0 +1,542 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package agent provides the reusable A2UI agent executor: it drives the
+// Gemini tool-calling loop, validates A2UI payloads the model produces via
+// the send tool, and emits them as A2A artifacts.
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+	"github.com/google/A2UI/a2a_agents/go/tools"
+)
+
+// Mode selects how the executor obtains A2UI payloads from the model.
+type Mode int
+
+const (
+	// ModeToolCalling exposes the A2UI send tool to the model alongside the
+	// agent's data tools. This is the default.
+	ModeToolCalling Mode = iota
+	// ModeStructuredOutput requests the payload as JSON structured output
+	// instead of a tool call, with the same validation pipeline.
+	ModeStructuredOutput
+)
+
+// Config configures an Executor.
+type Config struct {
+	// Client is the LLM backend used for generation. *gemini.Client is
+	// the usual choice; any LLMClient implementation works, including
+	// adapters for other providers and test fakes.
+	Client LLMClient
+	// Instructions is the agent's system instruction. The A2UI schema block
+	// is appended to it automatically.
+	Instructions string
+	// InstructionsFunc, when set, builds the system instruction per request
+	// from the user's query text, e.g. to select only the few-shot examples
+	// relevant to the query. It takes precedence over Instructions.
+	InstructionsFunc func(query string) string
+	// SessionInstructionsFunc, when set, builds the system instruction per
+	// request from the full session — the query plus client preferences
+	// such as theme, locale and timezone — so templates can adapt to the
+	// client. It takes precedence over InstructionsFunc.
+	SessionInstructionsFunc func(session *Session) string
+	// Schema is the A2UI message schema injected into the prompt and used
+	// to validate payloads from the model.
+	Schema map[string]any
+	// Tools are the agent's data tools, offered to the model alongside the
+	// A2UI send tool.
+	Tools []tools.Tool
+	// Breaker tunes the circuit breaker around the LLM backend.
+	Breaker BreakerConfig
+	// Mode selects tool calling (default) or structured output generation.
+	Mode Mode
+	// ConstrainDecoding, in structured output mode, passes a reduced form of
+	// the A2UI schema as the model's responseSchema so generation is
+	// constrained server-side.
+	ConstrainDecoding bool
+	// ResponseSchemaMaxBytes bounds the reduced responseSchema to fit
+	// provider limits; 0 means unlimited.
+	ResponseSchemaMaxBytes int
+	// SchemaCacheTTL, when positive, uploads the schema block and tool
+	// declarations as provider-side cached content with this lifetime, so
+	// each request references them by handle instead of re-sending them.
+	// Zero disables caching.
+	SchemaCacheTTL time.Duration
+	// MinifyPromptSchema strips annotation keywords (descriptions, examples,
+	// titles) from the schema embedded in the prompt. Validation always uses
+	// the full schema.
+	MinifyPromptSchema bool
+	// PromptComponents, when non-empty, restricts the schema embedded in the
+	// prompt to these components — the set this agent's skill can possibly
+	// use. Validation always uses the full schema.
+	PromptComponents []string
+	// Experiment, when set, routes each session to one of several
+	// instruction variants and tags emitted artifacts with the variant ID,
+	// so prompting strategies can be compared. Nil disables experimentation.
+	Experiment *Experiment
+	// DryRun emits validated payloads as preview artifacts (named
+	// PreviewArtifactName and tagged with DryRunMetadataKey) instead of
+	// renderable UI, so developers and evaluators can inspect what would be
+	// rendered without a client acting on it.
+	DryRun bool
+	// Approval, when set, parks validated payloads in the gate instead of
+	// emitting them; only payloads a reviewer approves reach the client.
+	Approval *ApprovalGate
+	// Policies are evaluated in order on every outgoing payload after schema
+	// validation; any of them can deny or transform it.
+	Policies []PayloadPolicy
+	// StrictProperties closes every object schema that declares properties
+	// (adding additionalProperties: false where unset) so payloads with
+	// hallucinated props are rejected instead of passed through to
+	// clients.
+	StrictProperties bool
+	// Lint, when set, runs warning-level checks on schema-valid payloads:
+	// deprecated properties and soft limits. Warnings are logged and
+	// reported back to the model; whether they block sending is the
+	// config's policy.
+	Lint *LintConfig
+	// Aliases rewrites retired component type names in payloads before
+	// validation and emission, typically taken from the catalog's alias
+	// registrations, so model prompts keep working across catalog renames.
+	Aliases a2ui.AliasMap
+	// CatalogID identifies the component catalog Schema was merged from. It
+	// is informational, passed to payload policies.
+	CatalogID string
+	// Audit, when set, records every emitted payload in a tamper-evident
+	// hash chain.
+	Audit *AuditLog
+	// ValidationTimeout bounds schema validation of a single payload, so a
+	// pathological payload aborts with an error instead of blocking the
+	// executor goroutine. Zero means no deadline beyond the request's own
+	// context.
+	ValidationTimeout time.Duration
+	// ExecutionTimeout bounds one whole Execute call — every model turn
+	// and tool invocation together. A request over the deadline fails with
+	// a clear timeout status instead of riding whatever HTTP or client
+	// timeout happens to fire first. Zero means no deadline beyond the
+	// request's own context.
+	ExecutionTimeout time.Duration
+	// LLMTimeout bounds each individual model call. Zero means no
+	// per-call deadline.
+	LLMTimeout time.Duration
+	// ToolTimeout bounds each data-tool invocation. A tool over the
+	// deadline is reported to the model as a tool error so it can carry
+	// on without the result. Zero means no per-call deadline.
+	ToolTimeout time.Duration
+	// Governor bounds concurrent LLM-backed executions; overflow queues up
+	// to a max wait and is rejected with a busy status when the queue is
+	// full. A zero value disables the governor.
+	Governor GovernorConfig
+	// HeartbeatInterval emits a lightweight working status update at this
+	// interval while a request runs, so streaming clients and
+	// intermediaries do not time out an idle connection during slow
+	// generations. Zero disables heartbeats.
+	HeartbeatInterval time.Duration
+	// DevReload, when set, re-reads the loader's instructions file on
+	// every request and uses it as the system instruction, taking
+	// precedence over the baked-in sources while the file has content.
+	// Development only; see DevLoader.
+	DevReload *DevLoader
+	// MaxToolTurns caps the model turns one request may take in the
+	// tool-calling loop. Zero applies a built-in default; negative
+	// disables the cap. A request over the cap fails with a clear status
+	// instead of burning quota.
+	MaxToolTurns int
+	// MaxRepeatedToolCalls is how many consecutive identical tool-call
+	// turns are tolerated before the request is failed as stuck. Zero
+	// applies a built-in default; negative disables the check.
+	MaxRepeatedToolCalls int
+	// Plugins receive the executor's lifecycle events — session
+	// preparation, schema loading, payload validation, surface emission
+	// and task completion — so observability and experimentation features
+	// attach without modifying the executor. Nil disables plugins.
+	Plugins *PluginSet
+	// Log controls how much of the merged schema and of payloads reaches
+	// the log. The zero value logs them truncated and at debug level
+	// only.
+	Log LogConfig
+}
+
+// Executor is a reusable a2a.AgentExecutor for A2UI agents.
+type Executor struct {
+	cfg             Config
+	schema          *jsonschema.Schema // the array-wrapped payload schema
+	schemaDoc       map[string]any
+	promptSchemaDoc map[string]any // the schema embedded in prompts
+	responseSchema  map[string]any // reduced schema for constrained decoding
+	dedupe          *deduper
+	breaker         *circuitBreaker
+	governor        *governor
+	cache           schemaCache
+}
+
+// New builds an Executor, compiling the configured A2UI schema.
+func New(cfg Config) (*Executor, error) {
+	wrapped, err := a2ui.WrapAsJSONArray(cfg.Schema)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.StrictProperties {
+		wrapped, err = a2ui.StrictSchema(wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("agent: closing schema for strict mode: %w", err)
+		}
+	}
+	compiled, err := jsonschema.Compile(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("agent: compiling A2UI schema: %w", err)
+	}
+	e := &Executor{
+		cfg:             cfg,
+		schema:          compiled,
+		schemaDoc:       wrapped,
+		promptSchemaDoc: wrapped,
+		dedupe:          newDeduper(),
+		breaker:         newCircuitBreaker(cfg.Breaker),
+		governor:        newGovernor(cfg.Governor),
+	}
+	if len(cfg.PromptComponents) > 0 {
+		subset, err := a2ui.SubsetSchemaComponents(cfg.Schema, cfg.PromptComponents)
+		if err != nil {
+			return nil, fmt.Errorf("agent: subsetting prompt schema: %w", err)
+		}
+		if e.promptSchemaDoc, err = a2ui.WrapAsJSONArray(subset); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.MinifyPromptSchema {
+		e.promptSchemaDoc, err = a2ui.MinifySchema(e.promptSchemaDoc, a2ui.MinifyOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("agent: minifying prompt schema: %w", err)
+		}
+	}
+	if cfg.ConstrainDecoding {
+		e.responseSchema, err = a2ui.ReduceForResponseSchema(wrapped,
+			a2ui.ReduceOptions{MaxBytes: cfg.ResponseSchemaMaxBytes})
+		if err != nil {
+			return nil, fmt.Errorf("agent: reducing response schema: %w", err)
+		}
+	}
+	cfg.Plugins.schemaLoaded(wrapped)
+	if raw, err := json.Marshal(wrapped); err == nil {
+		// The merged schema runs to hundreds of KB; info level carries its
+		// size only, the contents stay behind debug.
+		slog.Info("A2UI schema compiled", "bytes", len(raw))
+		e.logLarge("A2UI schema contents", "schema", string(raw))
+	}
+	return e, nil
+}
+
+// Execute runs the tool-calling loop for one request, publishing status and
+// artifact events on the queue and leaving the task in a final state.
+func (e *Executor) Execute(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue) error {
+	var reqErr *a2ui.RequireError
+	if err := a2ui.Require(rc, e.requiredCatalogIDs()...); errors.As(err, &reqErr) {
+		return e.fail(rc, queue, reqErr.UserMessage)
+	}
+	ctx, cancelDeadline := phaseContext(ctx, e.cfg.ExecutionTimeout)
+	defer cancelDeadline()
+	if !e.governor.acquire(ctx) {
+		slog.Warn("execution rejected by governor", "task", rc.TaskID)
+		return e.status(rc, queue, a2a.TaskStateRejected, agentMessage(rc, busyMessage), true)
+	}
+	defer e.governor.release()
+	defer e.dedupe.forget(rc.TaskID)
+
+	if err := e.status(rc, queue, a2a.TaskStateWorking, nil, false); err != nil {
+		return err
+	}
+	stopHeartbeat := e.startHeartbeat(rc, queue)
+	defer stopHeartbeat()
+
+	if e.cfg.Mode == ModeStructuredOutput {
+		return e.executeStructured(ctx, rc, queue)
+	}
+
+	session := PrepareSession(rc)
+	e.cfg.Plugins.sessionPrepare(session)
+	req := &gemini.GenerateRequest{
+		Contents: []gemini.Content{{Role: gemini.RoleUser, Parts: userParts(session)}},
+	}
+	if name := e.cachedContentName(ctx); name != "" {
+		// The cache carries the schema block and tool declarations; they must
+		// not be repeated in the request, so the per-request instructions
+		// ride along as the leading user turn instead.
+		req.CachedContent = name
+		req.Contents = append([]gemini.Content{
+			{Role: gemini.RoleUser, Parts: []gemini.Part{{Text: e.instructions(session)}}},
+		}, req.Contents...)
+	} else {
+		req.SystemInstruction = e.systemInstruction(session)
+		req.Tools = []gemini.Tool{{FunctionDeclarations: e.declarations()}}
+	}
+
+	guard := newLoopGuard(e.cfg)
+	for {
+		if guard.turn() {
+			slog.Warn("tool loop hit its turn limit", "task", rc.TaskID, "turns", guard.turns-1)
+			if failErr := e.fail(rc, queue, loopLimitMessage); failErr != nil {
+				return failErr
+			}
+			return ErrToolLoopLimit
+		}
+		resp, err := e.generateStreaming(ctx, rc, queue, req)
+		if err != nil {
+			slog.Error("LLM call failed", "task", rc.TaskID, "error", err)
+			reason := "The agent failed to generate a response."
+			if err == errBreakerOpen {
+				reason = breakerUnavailableMessage
+			} else if errors.Is(err, context.DeadlineExceeded) {
+				reason = timeoutMessage
+			}
+			if failErr := e.fail(rc, queue, reason); failErr != nil {
+				return failErr
+			}
+			return err
+		}
+		calls := resp.FunctionCalls()
+		if len(calls) == 0 {
+			var msg *a2a.Message
+			if text := resp.Text(); text != "" {
+				msg = agentMessage(rc, text)
+			}
+			return e.status(rc, queue, a2a.TaskStateCompleted, msg, true)
+		}
+		if guard.record(calls) {
+			slog.Warn("tool loop is repeating identical calls", "task", rc.TaskID, "repeats", guard.repeats-1)
+			if failErr := e.fail(rc, queue, repeatedCallMessage); failErr != nil {
+				return failErr
+			}
+			return ErrToolLoopStuck
+		}
+
+		req.Contents = append(req.Contents, resp.Candidates[0].Content)
+		var responses []gemini.Part
+		for _, call := range calls {
+			result := e.dispatch(ctx, rc, queue, call)
+			responses = append(responses, gemini.Part{
+				FunctionResponse: &gemini.FunctionResponse{Name: call.Name, Response: result},
+			})
+		}
+		req.Contents = append(req.Contents, gemini.Content{Role: gemini.RoleUser, Parts: responses})
+		if queue.Closed() {
+			// A stalled or abandoned stream closed the queue mid-task; there
+			// is no one left to deliver to, so stop the tool loop instead of
+			// burning further model calls.
+			slog.Warn("event queue closed mid-task, aborting", "task", rc.TaskID)
+			return fmt.Errorf("agent: delivering events for task %s: %w", rc.TaskID, a2a.ErrQueueClosed)
+		}
+	}
+}
+
+// Cancel transitions the task to canceled.
+func (e *Executor) Cancel(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue) error {
+	e.dedupe.forget(rc.TaskID)
+	return e.status(rc, queue, a2a.TaskStateCanceled, nil, true)
+}
+
+// errBreakerOpen is returned by generate while the circuit is open.
+var errBreakerOpen = errors.New("agent: LLM circuit breaker is open")
+
+// generate calls the LLM backend through the circuit breaker, failing fast
+// while the backend is returning sustained errors.
+func (e *Executor) generate(ctx context.Context, req *gemini.GenerateRequest) (*gemini.GenerateResponse, error) {
+	if !e.breaker.allow() {
+		return nil, errBreakerOpen
+	}
+	ctx, cancel := phaseContext(ctx, e.cfg.LLMTimeout)
+	defer cancel()
+	resp, err := e.cfg.Client.GenerateContent(ctx, req)
+	if err != nil {
+		e.breaker.recordFailure()
+		return nil, err
+	}
+	e.breaker.recordSuccess()
+	return resp, nil
+}
+
+// dispatch routes one function call to the send tool or a configured data
+// tool and returns the function response payload for the model.
+func (e *Executor) dispatch(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue, call *gemini.FunctionCall) map[string]any {
+	if call.Name == SendToolName {
+		return e.handleSendA2UI(ctx, rc, queue, call.Args)
+	}
+	for _, tool := range e.cfg.Tools {
+		if tool.Declaration().Name != call.Name {
+			continue
+		}
+		args, err := tools.ResolveArgs(tool.Declaration(), call.Args)
+		if err != nil {
+			slog.Warn("tool call has invalid arguments", "tool", call.Name, "error", err)
+			return argumentErrorResponse(err)
+		}
+		toolCtx, cancel := phaseContext(ctx, e.cfg.ToolTimeout)
+		result, parts, err := e.invokeTool(toolCtx, rc, queue, tool, args)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				err = fmt.Errorf("tool %q exceeded its %s deadline", call.Name, e.cfg.ToolTimeout)
+			}
+			slog.Warn("tool failed", "tool", call.Name, "error", err)
+			return map[string]any{toolErrorKey: err.Error()}
+		}
+		if err := tools.ValidateResult(tool.Declaration(), result); err != nil {
+			slog.Warn("tool returned a malformed result", "tool", call.Name, "error", err)
+			return map[string]any{toolErrorKey: err.Error()}
+		}
+		if len(parts) > 0 {
+			if err := e.emitToolParts(rc, queue, parts); err != nil {
+				slog.Warn("tool result delivery failed", "tool", call.Name, "error", err)
+				return map[string]any{toolErrorKey: err.Error()}
+			}
+		}
+		return result
+	}
+	return map[string]any{toolErrorKey: fmt.Sprintf("unknown tool %q", call.Name)}
+}
+
+// declarations returns the function declarations offered to the model.
+func (e *Executor) declarations() []*tools.Declaration {
+	decls := []*tools.Declaration{sendToolDeclaration()}
+	for _, tool := range e.cfg.Tools {
+		decls = append(decls, tool.Declaration())
+	}
+	return decls
+}
+
+// instructions returns the instructions for one request, without the schema
+// block: the session's experiment variant when one applies, otherwise the
+// configured defaults.
+func (e *Executor) instructions(session *Session) string {
+	if text := e.cfg.DevReload.Instructions(); text != "" {
+		return text
+	}
+	if variant := e.cfg.Experiment.variant(session.ContextID); variant != nil {
+		if variant.InstructionsFunc != nil {
+			return variant.InstructionsFunc(session.Query)
+		}
+		if variant.Instructions != "" {
+			return variant.Instructions
+		}
+	}
+	if e.cfg.SessionInstructionsFunc != nil {
+		return e.cfg.SessionInstructionsFunc(session)
+	}
+	if e.cfg.InstructionsFunc != nil {
+		return e.cfg.InstructionsFunc(session.Query)
+	}
+	return e.cfg.Instructions
+}
+
+// userParts builds the user turn: the query, plus the client's data-model
+// snapshot when one was attached, so the model can reason about what the
+// user is currently seeing (filters they changed, rows they expanded)
+// rather than only what they typed.
+func userParts(session *Session) []gemini.Part {
+	parts := []gemini.Part{{Text: session.Query}}
+	if len(session.DataModelState) > 0 {
+		encoded, err := json.Marshal(session.DataModelState)
+		if err != nil {
+			slog.Warn("dropping unencodable data-model state", "error", err)
+			return parts
+		}
+		parts = append(parts, gemini.Part{
+			Text: "Current data model of the client's active surfaces, keyed by surface ID:\n" + string(encoded),
+		})
+	}
+	return parts
+}
+
+// systemInstruction combines the request's instructions with the A2UI
+// schema block the send tool refers to.
+func (e *Executor) systemInstruction(session *Session) *gemini.Content {
+	text := e.instructions(session) + "\n" + a2ui.SchemaInstructionBlock(e.promptSchemaDoc)
+	return &gemini.Content{Parts: []gemini.Part{{Text: text}}}
+}
+
+// status publishes a status update event.
+func (e *Executor) status(rc *a2a.RequestContext, queue *a2a.EventQueue, state a2a.TaskState, msg *a2a.Message, final bool) error {
+	err := queue.Write(&a2a.StatusUpdateEvent{
+		TaskID:    rc.TaskID,
+		ContextID: rc.ContextID,
+		Status:    a2a.NewTaskStatus(state, msg),
+		Final:     final,
+	})
+	if final && err == nil {
+		e.cfg.Plugins.taskComplete(rc, state)
+	}
+	return err
+}
+
+// requiredCatalogIDs names the catalogs a client must support to render
+// this agent's surfaces, when the config identifies one.
+func (e *Executor) requiredCatalogIDs() []string {
+	if e.cfg.CatalogID == "" {
+		return nil
+	}
+	return []string{e.cfg.CatalogID}
+}
+
+// fail publishes a final failed status carrying a user-facing explanation.
+func (e *Executor) fail(rc *a2a.RequestContext, queue *a2a.EventQueue, reason string) error {
+	return e.status(rc, queue, a2a.TaskStateFailed, agentMessage(rc, reason), true)
+}
+
+// agentMessage builds an agent-role text message for the task.
+func agentMessage(rc *a2a.RequestContext, text string) *a2a.Message {
+	return &a2a.Message{
+		MessageID: a2a.NewID(),
+		Role:      a2a.RoleAgent,
+		Parts:     []a2a.Part{a2a.NewTextPart(text)},
+		ContextID: rc.ContextID,
+		TaskID:    rc.TaskID,
+	}
+}
+
+// messageText concatenates the text parts of a message.
+func messageText(msg *a2a.Message) string {
+	if msg == nil {
+		return ""
+	}
+	var out string
+	for _, part := range msg.Parts {
+		if part.Kind == "text" {
+			out += part.Text
+		}
+	}
+	return out
+}
+
+// validatePayload checks a payload list against the compiled schema,
+// honoring the request context and the configured validation deadline so
+// pathological payloads abort instead of blocking the executor goroutine.
+func (e *Executor) validatePayload(ctx context.Context, list []any) error {
+	if e.cfg.ValidationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.cfg.ValidationTimeout)
+		defer cancel()
+	}
+	return e.schema.ValidateContext(ctx, list)
+}
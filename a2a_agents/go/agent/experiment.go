@@ -0,0 +1,64 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import "hash/fnv"
+
+// VariantMetadataKey is the artifact metadata key carrying the experiment
+// variant ID on emitted payloads.
+const VariantMetadataKey = "experiment_variant"
+
+// Variant is one arm of a prompting experiment: an alternative set of
+// instructions whose emitted payloads are tagged with the variant ID.
+type Variant struct {
+	// ID tags emitted artifacts so evaluation can attribute them to this arm.
+	ID string
+	// Instructions and InstructionsFunc override the executor's configured
+	// instructions for sessions routed to this arm, with the same precedence
+	// as Config.Instructions and Config.InstructionsFunc.
+	Instructions     string
+	InstructionsFunc func(query string) string
+}
+
+// Experiment routes each session to one of several instruction variants.
+type Experiment struct {
+	// Variants are the experiment arms. An empty experiment assigns nothing.
+	Variants []Variant
+	// Assign picks the variant index for a session's context ID; out-of-range
+	// results are reduced modulo len(Variants). When nil, sessions are
+	// assigned by a stable hash of the context ID, so a session stays on the
+	// same arm across requests.
+	Assign func(contextID string) int
+}
+
+// variant returns the arm for a session, or nil when the experiment has no
+// variants.
+func (x *Experiment) variant(contextID string) *Variant {
+	if x == nil || len(x.Variants) == 0 {
+		return nil
+	}
+	var i int
+	if x.Assign != nil {
+		i = x.Assign(contextID)
+	} else {
+		h := fnv.New32a()
+		h.Write([]byte(contextID))
+		i = int(h.Sum32())
+	}
+	if i < 0 {
+		i = -i
+	}
+	return &x.Variants[i%len(x.Variants)]
+}
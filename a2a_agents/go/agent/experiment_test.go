@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// fakeModelToolServer answers the first generateContent call with a send
+// tool call carrying the given args, then completes with plain text.
+func fakeModelToolServer(t *testing.T, argsJSON string) (*httptest.Server, *[]gemini.GenerateRequest) {
+	t.Helper()
+	var args map[string]any
+	if err := json.Unmarshal([]byte(argsJSON), &args); err != nil {
+		t.Fatal(err)
+	}
+	var requests []gemini.GenerateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req gemini.GenerateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding model request: %v", err)
+		}
+		requests = append(requests, req)
+		part := gemini.Part{Text: "done"}
+		if len(requests) == 1 {
+			part = gemini.Part{FunctionCall: &gemini.FunctionCall{Name: SendToolName, Args: args}}
+		}
+		resp := gemini.GenerateResponse{Candidates: []gemini.Candidate{{
+			Content: gemini.Content{Role: "model", Parts: []gemini.Part{part}},
+		}}}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("encoding model response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestExperimentVariantAssignment(t *testing.T) {
+	x := &Experiment{Variants: []Variant{{ID: "a"}, {ID: "b"}}}
+	// Hash assignment is stable per context ID.
+	first := x.variant("ctx1")
+	for i := 0; i < 10; i++ {
+		if got := x.variant("ctx1"); got.ID != first.ID {
+			t.Fatalf("variant for ctx1 changed from %q to %q", first.ID, got.ID)
+		}
+	}
+	// A custom Assign wins, with out-of-range values reduced.
+	x.Assign = func(contextID string) int { return 3 }
+	if got := x.variant("ctx1"); got.ID != "b" {
+		t.Errorf("Assign(3) chose %q, want b", got.ID)
+	}
+	// Nil and empty experiments assign nothing.
+	if (&Experiment{}).variant("ctx1") != nil || (*Experiment)(nil).variant("ctx1") != nil {
+		t.Error("empty experiment assigned a variant")
+	}
+}
+
+func TestExecuteTagsArtifactsWithVariant(t *testing.T) {
+	payload := `{"` + a2uiJSONArgName + `": "[{\"beginRendering\": {\"surfaceId\": \"s1\", \"root\": \"root\"}}]"}`
+	server, requests := fakeModelToolServer(t, payload)
+	e, err := New(Config{
+		Client:       gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Instructions: "default instructions",
+		Schema:       testSchema,
+		Experiment: &Experiment{
+			Variants: []Variant{{ID: "terse", Instructions: "be terse"}},
+			Assign:   func(contextID string) int { return 0 },
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	queue.Close()
+
+	var tagged bool
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		if ev, ok := event.(*a2a.ArtifactUpdateEvent); ok {
+			if ev.Artifact.Metadata[VariantMetadataKey] == "terse" {
+				tagged = true
+			}
+		}
+	}
+	if !tagged {
+		t.Error("emitted artifact is not tagged with the variant ID")
+	}
+	if sys := (*requests)[0].SystemInstruction; sys == nil ||
+		!strings.Contains(sys.Parts[0].Text, "be terse") {
+		t.Error("variant instructions were not used for the session")
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// GovernorConfig tunes the execution governor bounding concurrent
+// LLM-backed executions, protecting the model quota and memory under
+// load.
+type GovernorConfig struct {
+	// MaxConcurrent is the number of executions allowed to run at once.
+	// Zero disables the governor.
+	MaxConcurrent int
+	// MaxQueued is the number of executions allowed to wait for a slot;
+	// arrivals beyond it are rejected as busy immediately. Defaults to
+	// MaxConcurrent.
+	MaxQueued int
+	// MaxWait is how long a queued execution waits for a slot before it is
+	// rejected as busy. Defaults to 10 seconds.
+	MaxWait time.Duration
+}
+
+const defaultGovernorMaxWait = 10 * time.Second
+
+// busyMessage is the user-facing explanation emitted when the governor
+// rejects an execution.
+const busyMessage = "The agent is handling too many requests right now. " +
+	"Please try again in a moment."
+
+// governor bounds concurrent executions with a slot semaphore and a
+// bounded wait queue. A nil governor admits everything.
+type governor struct {
+	cfg   GovernorConfig
+	slots chan struct{}
+
+	mu      sync.Mutex
+	waiting int
+}
+
+// newGovernor returns nil when the config leaves the governor disabled.
+func newGovernor(cfg GovernorConfig) *governor {
+	if cfg.MaxConcurrent <= 0 {
+		return nil
+	}
+	if cfg.MaxQueued <= 0 {
+		cfg.MaxQueued = cfg.MaxConcurrent
+	}
+	if cfg.MaxWait <= 0 {
+		cfg.MaxWait = defaultGovernorMaxWait
+	}
+	return &governor{cfg: cfg, slots: make(chan struct{}, cfg.MaxConcurrent)}
+}
+
+// acquire obtains an execution slot, queueing for up to MaxWait when all
+// slots are busy. It reports false — the caller should emit a busy status
+// — when the wait queue is full, the wait budget runs out, or the context
+// is canceled first.
+func (g *governor) acquire(ctx context.Context) bool {
+	if g == nil {
+		return true
+	}
+	select {
+	case g.slots <- struct{}{}:
+		return true
+	default:
+	}
+	g.mu.Lock()
+	if g.waiting >= g.cfg.MaxQueued {
+		g.mu.Unlock()
+		return false
+	}
+	g.waiting++
+	g.mu.Unlock()
+	defer func() {
+		g.mu.Lock()
+		g.waiting--
+		g.mu.Unlock()
+	}()
+	timer := time.NewTimer(g.cfg.MaxWait)
+	defer timer.Stop()
+	select {
+	case g.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees the slot obtained by acquire.
+func (g *governor) release() {
+	if g == nil {
+		return
+	}
+	<-g.slots
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGovernorBoundsConcurrency(t *testing.T) {
+	g := newGovernor(GovernorConfig{MaxConcurrent: 2, MaxWait: 10 * time.Millisecond})
+	ctx := context.Background()
+	if !g.acquire(ctx) || !g.acquire(ctx) {
+		t.Fatal("governor rejected executions under the limit")
+	}
+	if g.acquire(ctx) {
+		t.Fatal("third concurrent execution admitted past MaxConcurrent=2")
+	}
+	g.release()
+	if !g.acquire(ctx) {
+		t.Fatal("execution rejected after a slot was released")
+	}
+}
+
+func TestGovernorQueuesUntilSlotFrees(t *testing.T) {
+	g := newGovernor(GovernorConfig{MaxConcurrent: 1, MaxWait: time.Second})
+	ctx := context.Background()
+	if !g.acquire(ctx) {
+		t.Fatal("first execution rejected")
+	}
+	admitted := make(chan bool)
+	go func() { admitted <- g.acquire(ctx) }()
+	time.Sleep(5 * time.Millisecond) // let the waiter queue up
+	g.release()
+	select {
+	case ok := <-admitted:
+		if !ok {
+			t.Fatal("queued execution rejected although a slot freed in time")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queued execution never admitted")
+	}
+}
+
+func TestGovernorRejectsWhenQueueFull(t *testing.T) {
+	g := newGovernor(GovernorConfig{MaxConcurrent: 1, MaxQueued: 1, MaxWait: time.Second})
+	ctx := context.Background()
+	if !g.acquire(ctx) {
+		t.Fatal("first execution rejected")
+	}
+	go g.acquire(ctx) // fills the single queue slot
+	time.Sleep(5 * time.Millisecond)
+	if g.acquire(ctx) {
+		t.Fatal("execution admitted past a full wait queue")
+	}
+}
+
+func TestGovernorHonorsContext(t *testing.T) {
+	g := newGovernor(GovernorConfig{MaxConcurrent: 1, MaxWait: time.Minute})
+	if !g.acquire(context.Background()) {
+		t.Fatal("first execution rejected")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if g.acquire(ctx) {
+		t.Fatal("canceled execution admitted")
+	}
+}
+
+func TestGovernorDisabled(t *testing.T) {
+	var g *governor // Config with MaxConcurrent=0 yields a nil governor
+	for i := 0; i < 10; i++ {
+		if !g.acquire(context.Background()) {
+			t.Fatal("disabled governor rejected an execution")
+		}
+	}
+	g.release()
+}
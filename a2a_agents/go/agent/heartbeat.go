@@ -0,0 +1,52 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// startHeartbeat emits a non-final working status update every
+// HeartbeatInterval until the returned stop function is called, keeping
+// otherwise-idle streaming connections alive through long LLM and tool
+// calls. With heartbeats disabled it returns a no-op stop.
+func (e *Executor) startHeartbeat(rc *a2a.RequestContext, queue *a2a.EventQueue) (stop func()) {
+	interval := e.cfg.HeartbeatInterval
+	if interval <= 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := e.status(rc, queue, a2a.TaskStateWorking, nil, false); err != nil {
+					// The queue is closed or stalled; the tool loop will
+					// notice and abort on its own.
+					return
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func TestHeartbeat(t *testing.T) {
+	e, err := New(Config{Schema: testSchema, HeartbeatInterval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2uiRequestContext("task1")
+	queue := a2a.NewEventQueue(64)
+	stop := e.startHeartbeat(rc, queue)
+
+	// The first read blocks until a heartbeat fires.
+	event, ok := queue.Read()
+	if !ok {
+		t.Fatal("no heartbeat arrived")
+	}
+	stop()
+	stop() // stopping twice is safe
+
+	queue.Close()
+	for {
+		status, ok := event.(*a2a.StatusUpdateEvent)
+		if !ok {
+			t.Fatalf("heartbeat is a %T, want StatusUpdateEvent", event)
+		}
+		if status.Status.State != a2a.TaskStateWorking || status.Final {
+			t.Errorf("heartbeat = %+v, want non-final working", status)
+		}
+		if status.TaskID != rc.TaskID {
+			t.Errorf("heartbeat task = %q, want %q", status.TaskID, rc.TaskID)
+		}
+		event, ok = queue.Read()
+		if !ok {
+			return
+		}
+	}
+}
+
+func TestHeartbeatDisabled(t *testing.T) {
+	e, err := New(Config{Schema: testSchema})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	queue := a2a.NewEventQueue(4)
+	stop := e.startHeartbeat(a2uiRequestContext("task1"), queue)
+	defer stop()
+
+	time.Sleep(15 * time.Millisecond)
+	queue.Close()
+	if event, ok := queue.Read(); ok {
+		t.Errorf("disabled heartbeat emitted %+v", event)
+	}
+}
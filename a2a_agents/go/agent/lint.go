@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+// LintConfig enables warning-level validation findings on outgoing
+// payloads, separate from the schema errors that always reject. Starting
+// with Block false and tightening later gives operators a gradual path to
+// stricter rules.
+type LintConfig struct {
+	// Options are the checks to run.
+	Options a2ui.LintOptions
+	// Block rejects payloads that have warnings instead of sending them
+	// with the warnings logged.
+	Block bool
+}
+
+// lint runs the configured warning-level checks on a schema-valid
+// payload. It returns the warnings to report to the model, or an error
+// when the config says warnings block sending.
+func (e *Executor) lint(taskID string, messages []map[string]any) ([]string, error) {
+	if e.cfg.Lint == nil {
+		return nil, nil
+	}
+	warnings := a2ui.Lint(e.cfg.Schema, messages, e.cfg.Lint.Options)
+	if len(warnings) == 0 {
+		return nil, nil
+	}
+	rendered := make([]string, len(warnings))
+	for i, warning := range warnings {
+		rendered[i] = warning.String()
+	}
+	if e.cfg.Lint.Block {
+		return nil, fmt.Errorf("payload has validation warnings and warnings are configured to block: %s",
+			strings.Join(rendered, "; "))
+	}
+	for _, warning := range rendered {
+		slog.Warn("A2UI payload warning", "task", taskID, "warning", warning)
+	}
+	return rendered, nil
+}
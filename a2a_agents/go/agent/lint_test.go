@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+// lintTestPayload is one surfaceUpdate with two components, tripping a
+// MaxComponentsPerUpdate of 1.
+const lintTestPayload = `[{"surfaceUpdate": {"surfaceId": "s1", "components": [` +
+	`{"id": "a", "component": {"Text": {}}}, {"id": "b", "component": {"Text": {}}}]}}]`
+
+func lintTestExecutor(t *testing.T, block bool) *Executor {
+	t.Helper()
+	e, err := New(Config{
+		Schema: testSchema,
+		Lint: &LintConfig{
+			Options: a2ui.LintOptions{MaxComponentsPerUpdate: 1},
+			Block:   block,
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return e
+}
+
+func TestLintWarningsReported(t *testing.T) {
+	e := lintTestExecutor(t, false)
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(8)
+
+	result := e.handleSendA2UI(context.Background(), rc, queue, map[string]any{a2uiJSONArgName: lintTestPayload})
+	if result[toolErrorKey] != nil {
+		t.Fatalf("warnings blocked sending without Block: %v", result[toolErrorKey])
+	}
+	warnings, _ := result[warningsKey].([]string)
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %v, want 1", result[warningsKey])
+	}
+	queue.Close()
+	if _, ok := queue.Read(); !ok {
+		t.Error("payload with warnings was not emitted")
+	}
+}
+
+func TestLintWarningsBlock(t *testing.T) {
+	e := lintTestExecutor(t, true)
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(8)
+
+	result := e.handleSendA2UI(context.Background(), rc, queue, map[string]any{a2uiJSONArgName: lintTestPayload})
+	if result[toolErrorKey] == nil {
+		t.Fatal("blocking lint config let a payload with warnings through")
+	}
+	queue.Close()
+	if _, ok := queue.Read(); ok {
+		t.Error("blocked payload was still emitted")
+	}
+}
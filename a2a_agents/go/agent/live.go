@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// ExecuteLive runs a continuous bidirectional session instead of the
+// per-turn generate loop, so voice and other always-on sessions can emit
+// A2UI surfaces mid-conversation. Artifacts are written to the event queue
+// as the model produces them rather than when a turn completes; model text
+// is surfaced as non-final working status updates. The call returns when the
+// session ends or the context is canceled.
+func (e *Executor) ExecuteLive(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue, session *gemini.LiveSession) error {
+	var reqErr *a2ui.RequireError
+	if err := a2ui.Require(rc, e.requiredCatalogIDs()...); errors.As(err, &reqErr) {
+		return e.fail(rc, queue, reqErr.UserMessage)
+	}
+	if !e.governor.acquire(ctx) {
+		return e.status(rc, queue, a2a.TaskStateRejected, agentMessage(rc, busyMessage), true)
+	}
+	defer e.governor.release()
+	defer e.dedupe.forget(rc.TaskID)
+
+	prepared := PrepareSession(rc)
+	e.cfg.Plugins.sessionPrepare(prepared)
+	setup := &gemini.LiveSetup{
+		SystemInstruction: e.systemInstruction(prepared),
+		Tools:             []gemini.Tool{{FunctionDeclarations: e.declarations()}},
+	}
+	if e.cfg.Client != nil {
+		setup.Model = e.cfg.Client.Model()
+	}
+	if err := session.Setup(ctx, setup); err != nil {
+		if failErr := e.fail(rc, queue, "The agent could not start a live session."); failErr != nil {
+			return failErr
+		}
+		return err
+	}
+	if err := e.status(rc, queue, a2a.TaskStateWorking, nil, false); err != nil {
+		return err
+	}
+	stopHeartbeat := e.startHeartbeat(rc, queue)
+	defer stopHeartbeat()
+
+	for {
+		msg, err := session.Receive(ctx)
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return e.status(rc, queue, a2a.TaskStateCompleted, nil, true)
+			}
+			slog.Error("live session receive failed", "task", rc.TaskID, "error", err)
+			if failErr := e.fail(rc, queue, "The live session ended unexpectedly."); failErr != nil {
+				return failErr
+			}
+			return err
+		}
+		switch {
+		case msg.ToolCall != nil:
+			var responses []*gemini.FunctionResponse
+			for _, call := range msg.ToolCall.FunctionCalls {
+				result := e.dispatch(ctx, rc, queue, call)
+				responses = append(responses, &gemini.FunctionResponse{Name: call.Name, Response: result})
+			}
+			if err := session.SendToolResponse(ctx, &gemini.LiveToolResponse{FunctionResponses: responses}); err != nil {
+				slog.Error("live tool response failed", "task", rc.TaskID, "error", err)
+				return err
+			}
+		case msg.ServerContent != nil && msg.ServerContent.ModelTurn != nil:
+			var text string
+			for _, part := range msg.ServerContent.ModelTurn.Parts {
+				text += part.Text
+			}
+			if text != "" {
+				if err := e.status(rc, queue, a2a.TaskStateWorking, agentMessage(rc, text), false); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// fakeTransport scripts server messages and records client messages.
+type fakeTransport struct {
+	incoming chan []byte
+	sent     [][]byte
+}
+
+func (f *fakeTransport) Send(_ context.Context, msg []byte) error {
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func (f *fakeTransport) Receive(ctx context.Context) ([]byte, error) {
+	msg, ok := <-f.incoming
+	if !ok {
+		return nil, io.EOF
+	}
+	return msg, nil
+}
+
+func (f *fakeTransport) Close() error { return nil }
+
+func TestExecuteLiveEmitsArtifactsMidSession(t *testing.T) {
+	e := testExecutor(t)
+	transport := &fakeTransport{incoming: make(chan []byte, 4)}
+
+	toolCall := map[string]any{"toolCall": map[string]any{"functionCalls": []any{
+		map[string]any{"name": SendToolName, "args": map[string]any{
+			a2uiJSONArgName: `[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`,
+		}},
+	}}}
+	raw, _ := json.Marshal(toolCall)
+	transport.incoming <- raw
+	close(transport.incoming)
+
+	rc := a2a.NewRequestContext(&a2a.Message{Extensions: []string{
+		"https://a2ui.org/a2a-extension/a2ui/v0.8"}}, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(16)
+	if err := e.ExecuteLive(context.Background(), rc, queue, gemini.NewLiveSession(transport)); err != nil {
+		t.Fatalf("ExecuteLive: %v", err)
+	}
+	queue.Close()
+
+	var sawArtifact, sawFinal bool
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		switch ev := event.(type) {
+		case *a2a.ArtifactUpdateEvent:
+			sawArtifact = true
+		case *a2a.StatusUpdateEvent:
+			if ev.Final && ev.Status.State == a2a.TaskStateCompleted {
+				sawFinal = true
+			}
+		}
+	}
+	if !sawArtifact {
+		t.Error("no artifact emitted during the live session")
+	}
+	if !sawFinal {
+		t.Error("session end did not complete the task")
+	}
+
+	// The first client message is the setup, the second the tool response.
+	if len(transport.sent) != 2 {
+		t.Fatalf("sent %d client messages, want 2", len(transport.sent))
+	}
+	var second map[string]any
+	if err := json.Unmarshal(transport.sent[1], &second); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := second["toolResponse"]; !ok {
+		t.Errorf("second client message is not a tool response: %s", transport.sent[1])
+	}
+}
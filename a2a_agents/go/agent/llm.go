@@ -0,0 +1,41 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// LLMClient is the generation backend the executor depends on: one
+// model turn per call, carrying the tool-call round trip in the request
+// contents. The request and response shapes follow the Gemini API;
+// adapters for other providers translate to and from them, and test
+// fakes implement the interface directly. *gemini.Client is the default
+// implementation.
+type LLMClient interface {
+	// GenerateContent runs one model turn.
+	GenerateContent(ctx context.Context, req *gemini.GenerateRequest) (*gemini.GenerateResponse, error)
+	// Model names the backing model, e.g. for live session setup.
+	Model() string
+}
+
+// ContentCacher is the optional LLMClient extension for provider-side
+// cached content. Backends without it fall back to sending the schema
+// block inline; see Config.SchemaCacheTTL.
+type ContentCacher interface {
+	CreateCachedContent(ctx context.Context, content *gemini.CachedContent) (*gemini.CachedContent, error)
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// The Gemini client is the default LLMClient and supports content
+// caching.
+var (
+	_ LLMClient     = (*gemini.Client)(nil)
+	_ ContentCacher = (*gemini.Client)(nil)
+)
+
+// fakeLLM is an in-process LLMClient returning scripted responses, the
+// kind of test fake the interface exists for.
+type fakeLLM struct {
+	responses []*gemini.GenerateResponse
+	requests  []*gemini.GenerateRequest
+}
+
+func (f *fakeLLM) GenerateContent(ctx context.Context, req *gemini.GenerateRequest) (*gemini.GenerateResponse, error) {
+	f.requests = append(f.requests, req)
+	resp := f.responses[0]
+	if len(f.responses) > 1 {
+		f.responses = f.responses[1:]
+	}
+	return resp, nil
+}
+
+func (f *fakeLLM) Model() string { return "fake-model" }
+
+func TestExecutorWithFakeLLM(t *testing.T) {
+	llm := &fakeLLM{responses: []*gemini.GenerateResponse{{
+		Candidates: []gemini.Candidate{{Content: gemini.Content{
+			Role:  "model",
+			Parts: []gemini.Part{{Text: "All done."}},
+		}}},
+	}}}
+	e, err := New(Config{Client: llm, Schema: testSchema})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	queue.Close()
+
+	if len(llm.requests) != 1 {
+		t.Fatalf("fake backend saw %d requests, want 1", len(llm.requests))
+	}
+	var final bool
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		if ev, ok := event.(*a2a.StatusUpdateEvent); ok && ev.Final {
+			if ev.Status.State != a2a.TaskStateCompleted {
+				t.Errorf("final state = %s, want completed", ev.Status.State)
+			}
+			final = true
+		}
+	}
+	if !final {
+		t.Error("no final status event was published")
+	}
+}
+
+func TestSchemaCacheRequiresContentCacher(t *testing.T) {
+	// A backend without ContentCacher silently falls back to inline
+	// schema blocks instead of failing the request.
+	llm := &fakeLLM{responses: []*gemini.GenerateResponse{{}}}
+	e, err := New(Config{Client: llm, Schema: testSchema, SchemaCacheTTL: 1})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if name := e.cachedContentName(context.Background()); name != "" {
+		t.Errorf("cachedContentName = %q, want empty for a non-caching backend", name)
+	}
+}
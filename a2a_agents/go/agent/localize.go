@@ -0,0 +1,67 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import "strings"
+
+// LocalizedText picks the entry for a BCP 47 locale from a map of
+// locale-keyed texts: the full tag first ("de-DE"), then the bare
+// language ("de"), then fallback. Matching is case-insensitive. It serves
+// instruction templates and per-locale example snippets alike.
+func LocalizedText(byLocale map[string]string, locale, fallback string) string {
+	if locale == "" || len(byLocale) == 0 {
+		return fallback
+	}
+	normalized := strings.ToLower(locale)
+	language, _, _ := strings.Cut(normalized, "-")
+	languageMatch := ""
+	for key, text := range byLocale {
+		switch strings.ToLower(key) {
+		case normalized:
+			return text
+		case language:
+			languageMatch = text
+		}
+	}
+	if languageMatch != "" {
+		return languageMatch
+	}
+	return fallback
+}
+
+// LocaleInstructions selects the system instruction per client locale, so
+// an agent serving non-English users prompts for correctly localized
+// titles and labels instead of always English. Keys are BCP 47 tags
+// ("de-DE") or bare languages ("de"); a session's locale matches its full
+// tag first, then its language, then falls back to Default.
+type LocaleInstructions struct {
+	// Default is used when the client sent no locale or nothing matches.
+	Default string
+	// ByLocale maps locale tags or language subtags to instructions.
+	ByLocale map[string]string
+}
+
+// Instructions is a Config.SessionInstructionsFunc selecting by the
+// session's locale:
+//
+//	cfg.SessionInstructionsFunc = localized.Instructions
+func (l LocaleInstructions) Instructions(session *Session) string {
+	return l.For(session.Locale)
+}
+
+// For returns the instructions for one locale.
+func (l LocaleInstructions) For(locale string) string {
+	return LocalizedText(l.ByLocale, locale, l.Default)
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+func TestLocalizedText(t *testing.T) {
+	byLocale := map[string]string{
+		"de-DE": "Sie benutzen deutsches Deutsch.",
+		"de":    "Antworte auf Deutsch.",
+		"fr":    "Réponds en français.",
+	}
+	cases := []struct{ locale, want string }{
+		{"de-DE", "Sie benutzen deutsches Deutsch."},
+		{"de-AT", "Antworte auf Deutsch."},
+		{"DE", "Antworte auf Deutsch."},
+		{"fr-CA", "Réponds en français."},
+		{"ja-JP", "fallback"},
+		{"", "fallback"},
+	}
+	for _, c := range cases {
+		if got := LocalizedText(byLocale, c.locale, "fallback"); got != c.want {
+			t.Errorf("LocalizedText(%q) = %q, want %q", c.locale, got, c.want)
+		}
+	}
+}
+
+func TestLocaleInstructions(t *testing.T) {
+	localized := LocaleInstructions{
+		Default: "Use English titles and labels.",
+		ByLocale: map[string]string{
+			"de": "Verwende deutsche Titel und Beschriftungen.",
+		},
+	}
+	if got := localized.For("de-CH"); got != localized.ByLocale["de"] {
+		t.Errorf("For(de-CH) = %q", got)
+	}
+	if got := localized.For("ko"); got != localized.Default {
+		t.Errorf("For(ko) = %q, want the default", got)
+	}
+}
+
+func TestLocaleInstructionsAsSessionFunc(t *testing.T) {
+	localized := LocaleInstructions{
+		Default:  "English instructions.",
+		ByLocale: map[string]string{"de": "Deutsche Anweisungen."},
+	}
+	e, err := New(Config{Schema: testSchema, SessionInstructionsFunc: localized.Instructions})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	msg := &a2a.Message{Parts: []a2a.Part{a2a.NewTextPart("hallo")}}
+	a2ui.SetClientCapability(msg, a2ui.LocaleKey, "de-DE")
+	session := PrepareSession(a2a.NewRequestContext(msg, "task1", "ctx1", nil))
+	if got := e.instructions(session); got != "Deutsche Anweisungen." {
+		t.Errorf("instructions = %q, want the German template", got)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// defaultMaxLoggedBytes bounds how much of a payload or schema one log
+// line may carry when LogConfig does not say otherwise.
+const defaultMaxLoggedBytes = 2048
+
+// LogConfig controls how much of the large values the executor handles —
+// the merged schema, full payloads — reaches the log. By default those are
+// logged truncated and at debug level only, so a production info-level log
+// stays readable; Full turns on complete capture for debugging.
+type LogConfig struct {
+	// Full logs complete payloads and the complete merged schema, still
+	// at debug level, instead of truncating them.
+	Full bool
+	// MaxLoggedBytes is where truncation cuts a logged value. Zero
+	// applies a built-in default; negative disables truncation like Full.
+	MaxLoggedBytes int
+}
+
+// limit resolves the configured truncation point.
+func (c LogConfig) limit() int {
+	if c.Full || c.MaxLoggedBytes < 0 {
+		return -1
+	}
+	if c.MaxLoggedBytes == 0 {
+		return defaultMaxLoggedBytes
+	}
+	return c.MaxLoggedBytes
+}
+
+// truncateForLog cuts s at limit bytes, marking the cut with the original
+// size so the reader knows capture was partial. A negative limit disables
+// truncation.
+func truncateForLog(s string, limit int) string {
+	if limit < 0 || len(s) <= limit {
+		return s
+	}
+	return fmt.Sprintf("%s... (%d of %d bytes)", s[:limit], limit, len(s))
+}
+
+// logLarge writes one debug line carrying a large value, truncated per the
+// config. Nothing is logged above debug level, so the value never floods
+// an info-level production log.
+func (e *Executor) logLarge(msg, key, value string, args ...any) {
+	if !slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	args = append(args, key, truncateForLog(value, e.cfg.Log.limit()))
+	slog.Debug(msg, args...)
+}
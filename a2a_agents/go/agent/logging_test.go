@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// captureLog routes slog to a buffer at the given level for one test.
+func captureLog(t *testing.T, level slog.Level) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: level})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestTruncateForLog(t *testing.T) {
+	if got := truncateForLog("short", 100); got != "short" {
+		t.Errorf("short value truncated: %q", got)
+	}
+	got := truncateForLog(strings.Repeat("x", 100), 10)
+	if !strings.HasPrefix(got, "xxxxxxxxxx...") || !strings.Contains(got, "10 of 100 bytes") {
+		t.Errorf("truncated value = %q", got)
+	}
+	if got := truncateForLog(strings.Repeat("x", 100), -1); len(got) != 100 {
+		t.Errorf("negative limit truncated to %d bytes", len(got))
+	}
+}
+
+func TestLogConfigLimit(t *testing.T) {
+	if got := (LogConfig{}).limit(); got != defaultMaxLoggedBytes {
+		t.Errorf("zero config limit = %d, want the default", got)
+	}
+	if got := (LogConfig{MaxLoggedBytes: 64}).limit(); got != 64 {
+		t.Errorf("explicit limit = %d, want 64", got)
+	}
+	if got := (LogConfig{Full: true}).limit(); got != -1 {
+		t.Errorf("full capture limit = %d, want unlimited", got)
+	}
+	if got := (LogConfig{MaxLoggedBytes: -1}).limit(); got != -1 {
+		t.Errorf("negative limit = %d, want unlimited", got)
+	}
+}
+
+func TestSchemaNotLoggedAtInfo(t *testing.T) {
+	buf := captureLog(t, slog.LevelInfo)
+	if _, err := New(Config{Schema: testSchema}); err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "A2UI schema compiled") {
+		t.Error("schema size line missing at info level")
+	}
+	if strings.Contains(out, "schema=") {
+		t.Errorf("schema contents leaked into the info log:\n%s", out)
+	}
+}
+
+func TestSchemaLoggedTruncatedAtDebug(t *testing.T) {
+	buf := captureLog(t, slog.LevelDebug)
+	if _, err := New(Config{Schema: testSchema, Log: LogConfig{MaxLoggedBytes: 16}}); err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "A2UI schema contents") {
+		t.Fatalf("schema contents missing at debug level:\n%s", out)
+	}
+	if !strings.Contains(out, "bytes)") {
+		t.Errorf("schema contents not truncated:\n%s", out)
+	}
+}
+
+func TestSchemaLoggedFullyUnderFullCapture(t *testing.T) {
+	buf := captureLog(t, slog.LevelDebug)
+	if _, err := New(Config{Schema: testSchema, Log: LogConfig{Full: true}}); err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "A2UI schema contents") || strings.Contains(out, "bytes)") {
+		t.Errorf("full capture did not log the complete schema:\n%s", out)
+	}
+}
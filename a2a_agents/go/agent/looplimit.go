@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"errors"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// defaultMaxToolTurns caps model turns per request when the config does
+// not set its own limit, so a confused model cannot spin against the
+// validator forever.
+const defaultMaxToolTurns = 32
+
+// defaultMaxRepeatedCalls is how many consecutive identical tool-call
+// turns are tolerated before the loop is treated as stuck.
+const defaultMaxRepeatedCalls = 3
+
+// loopLimitMessage is the user-facing explanation emitted when a request
+// exhausts its tool-loop turns.
+const loopLimitMessage = "The agent could not finish the request within " +
+	"the allowed number of steps. Please try again or simplify the request."
+
+// repeatedCallMessage is the user-facing explanation emitted when the
+// model keeps issuing the same tool calls without progress.
+const repeatedCallMessage = "The agent stopped after repeating the same " +
+	"step without making progress. Please try rephrasing the request."
+
+// ErrToolLoopLimit is returned by Execute and RunToolLoop when the turn
+// cap is hit.
+var ErrToolLoopLimit = errors.New("agent: tool loop exceeded its turn limit")
+
+// ErrToolLoopStuck is returned by Execute and RunToolLoop when the
+// model repeats the same tool calls too many times in a row.
+var ErrToolLoopStuck = errors.New("agent: tool loop repeated the same calls without progress")
+
+// loopGuard tracks turns and consecutive identical tool-call turns for
+// one request.
+type loopGuard struct {
+	maxTurns   int
+	maxRepeats int
+	turns      int
+	lastKey    string
+	repeats    int
+}
+
+// newLoopGuard builds a guard from the config, applying the defaults.
+// A negative config value disables the corresponding check.
+func newLoopGuard(cfg Config) *loopGuard {
+	g := &loopGuard{maxTurns: cfg.MaxToolTurns, maxRepeats: cfg.MaxRepeatedToolCalls}
+	if g.maxTurns == 0 {
+		g.maxTurns = defaultMaxToolTurns
+	}
+	if g.maxRepeats == 0 {
+		g.maxRepeats = defaultMaxRepeatedCalls
+	}
+	return g
+}
+
+// turn records one model turn and reports whether the cap is exhausted.
+func (g *loopGuard) turn() bool {
+	g.turns++
+	return g.maxTurns > 0 && g.turns > g.maxTurns
+}
+
+// record notes a turn's tool calls and reports whether the same calls
+// have now repeated too many times in a row.
+func (g *loopGuard) record(calls []*gemini.FunctionCall) bool {
+	key := callsFingerprint(calls)
+	if key == g.lastKey {
+		g.repeats++
+	} else {
+		g.lastKey = key
+		g.repeats = 1
+	}
+	return g.maxRepeats > 0 && g.repeats > g.maxRepeats
+}
+
+// callsFingerprint canonically fingerprints a turn's calls, so argument
+// maps compare by value rather than key order.
+func callsFingerprint(calls []*gemini.FunctionCall) string {
+	summary := make([]map[string]any, len(calls))
+	for i, call := range calls {
+		summary[i] = map[string]any{"name": call.Name, "args": call.Args}
+	}
+	fp, err := a2ui.Fingerprint(summary)
+	if err != nil {
+		return ""
+	}
+	return fp
+}
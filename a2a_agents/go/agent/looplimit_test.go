@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// finalStatus drains the queue and returns the final status update.
+func finalStatus(t *testing.T, queue *a2a.EventQueue) *a2a.StatusUpdateEvent {
+	t.Helper()
+	queue.Close()
+	var final *a2a.StatusUpdateEvent
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		if ev, ok := event.(*a2a.StatusUpdateEvent); ok && ev.Final {
+			final = ev
+		}
+	}
+	if final == nil {
+		t.Fatal("no final status event was published")
+	}
+	return final
+}
+
+func TestExecuteFailsWhenLoopRepeats(t *testing.T) {
+	// The fake keeps returning the identical tool call; the guard must
+	// fail the task instead of spinning.
+	llm := &fakeLLM{responses: []*gemini.GenerateResponse{callResponse("spin")}}
+	e, err := New(Config{Client: llm, Schema: testSchema})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	queue := a2a.NewEventQueue(64)
+	err = e.Execute(context.Background(), a2uiRequestContext("task1"), queue)
+	if !errors.Is(err, ErrToolLoopStuck) {
+		t.Fatalf("Execute = %v, want ErrToolLoopStuck", err)
+	}
+	final := finalStatus(t, queue)
+	if final.Status.State != a2a.TaskStateFailed {
+		t.Errorf("final state = %s, want failed", final.Status.State)
+	}
+	if got := messageText(final.Status.Message); got != repeatedCallMessage {
+		t.Errorf("failure message = %q, want the repeated-call explanation", got)
+	}
+	if len(llm.requests) > defaultMaxRepeatedCalls+1 {
+		t.Errorf("backend saw %d requests before the guard tripped", len(llm.requests))
+	}
+}
+
+func TestExecuteFailsAtTurnLimit(t *testing.T) {
+	llm := &fakeLLM{responses: []*gemini.GenerateResponse{callResponse("spin")}}
+	e, err := New(Config{
+		Client:               llm,
+		Schema:               testSchema,
+		MaxToolTurns:         2,
+		MaxRepeatedToolCalls: -1,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	queue := a2a.NewEventQueue(64)
+	err = e.Execute(context.Background(), a2uiRequestContext("task1"), queue)
+	if !errors.Is(err, ErrToolLoopLimit) {
+		t.Fatalf("Execute = %v, want ErrToolLoopLimit", err)
+	}
+	if len(llm.requests) != 2 {
+		t.Errorf("backend saw %d requests, want exactly the turn cap", len(llm.requests))
+	}
+	final := finalStatus(t, queue)
+	if got := messageText(final.Status.Message); got != loopLimitMessage {
+		t.Errorf("failure message = %q, want the turn-limit explanation", got)
+	}
+}
+
+func TestLoopGuardResetsOnNewCalls(t *testing.T) {
+	guard := newLoopGuard(Config{})
+	same := []*gemini.FunctionCall{{Name: "a", Args: map[string]any{"x": 1.0}}}
+	other := []*gemini.FunctionCall{{Name: "b"}}
+	for i := 0; i < defaultMaxRepeatedCalls; i++ {
+		if guard.record(same) {
+			t.Fatalf("guard tripped after %d identical turns", i+1)
+		}
+	}
+	if guard.record(other) {
+		t.Fatal("guard tripped on a different call")
+	}
+	if guard.record(same) {
+		t.Fatal("guard did not reset its count after different calls")
+	}
+}
+
+func TestLoopGuardIgnoresArgOrder(t *testing.T) {
+	guard := newLoopGuard(Config{MaxRepeatedToolCalls: 1})
+	first := []*gemini.FunctionCall{{Name: "a", Args: map[string]any{"x": 1.0, "y": 2.0}}}
+	second := []*gemini.FunctionCall{{Name: "a", Args: map[string]any{"y": 2.0, "x": 1.0}}}
+	guard.record(first)
+	if !guard.record(second) {
+		t.Error("calls differing only in arg order were not treated as identical")
+	}
+}
+
+func TestRunToolLoopMaxTurns(t *testing.T) {
+	llm := &fakeLLM{responses: []*gemini.GenerateResponse{callResponse("spin")}}
+	_, err := RunToolLoop(context.Background(), llm, nil, ToolLoopOptions{
+		MaxTurns:         3,
+		MaxRepeatedCalls: -1,
+	})
+	if !errors.Is(err, ErrToolLoopLimit) {
+		t.Fatalf("RunToolLoop = %v, want ErrToolLoopLimit", err)
+	}
+	if len(llm.requests) != 3 {
+		t.Errorf("backend saw %d requests, want exactly the turn cap", len(llm.requests))
+	}
+}
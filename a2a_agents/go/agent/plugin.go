@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"sync"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// Plugin is an extension attached to the executor's request lifecycle.
+// A plugin implements this base interface plus any of the On* hook
+// interfaces below; the executor calls the hooks a plugin implements
+// and ignores the rest. Hooks observe — payload transformation belongs
+// to PayloadPolicy.
+type Plugin interface {
+	// PluginName identifies the plugin in logs and diagnostics.
+	PluginName() string
+}
+
+// SessionPreparePlugin observes each prepared session before generation
+// starts, e.g. to tag experiments or record client preferences.
+type SessionPreparePlugin interface {
+	Plugin
+	OnSessionPrepare(session *Session)
+}
+
+// SchemaLoadedPlugin observes the compiled A2UI schema once, when the
+// executor is built.
+type SchemaLoadedPlugin interface {
+	Plugin
+	OnSchemaLoaded(schema map[string]any)
+}
+
+// PayloadValidatedPlugin observes every payload that passed schema
+// validation, before dedup, policies and emission.
+type PayloadValidatedPlugin interface {
+	Plugin
+	OnPayloadValidated(rc *a2a.RequestContext, messages []map[string]any)
+}
+
+// SurfaceEmittedPlugin observes the messages actually emitted to the
+// client as an A2UI artifact. Payloads parked in the approval gate are
+// not reported until a reviewer releases them.
+type SurfaceEmittedPlugin interface {
+	Plugin
+	OnSurfaceEmitted(rc *a2a.RequestContext, messages []map[string]any)
+}
+
+// TaskCompletePlugin observes each task reaching a final state.
+type TaskCompletePlugin interface {
+	Plugin
+	OnTaskComplete(rc *a2a.RequestContext, state a2a.TaskState)
+}
+
+// PluginSet registers plugins and fans the executor's lifecycle events
+// out to them, in registration order. A nil set is valid and does
+// nothing, so configs without plugins pay no cost.
+type PluginSet struct {
+	mu      sync.Mutex
+	plugins []Plugin
+}
+
+// NewPluginSet returns an empty plugin set.
+func NewPluginSet() *PluginSet {
+	return &PluginSet{}
+}
+
+// Register adds a plugin. Plugins must be registered before the set is
+// shared with a running executor.
+func (s *PluginSet) Register(p Plugin) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plugins = append(s.plugins, p)
+}
+
+// snapshot returns the registered plugins.
+func (s *PluginSet) snapshot() []Plugin {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.plugins
+}
+
+func (s *PluginSet) sessionPrepare(session *Session) {
+	for _, p := range s.snapshot() {
+		if hook, ok := p.(SessionPreparePlugin); ok {
+			hook.OnSessionPrepare(session)
+		}
+	}
+}
+
+func (s *PluginSet) schemaLoaded(schema map[string]any) {
+	for _, p := range s.snapshot() {
+		if hook, ok := p.(SchemaLoadedPlugin); ok {
+			hook.OnSchemaLoaded(schema)
+		}
+	}
+}
+
+func (s *PluginSet) payloadValidated(rc *a2a.RequestContext, messages []map[string]any) {
+	for _, p := range s.snapshot() {
+		if hook, ok := p.(PayloadValidatedPlugin); ok {
+			hook.OnPayloadValidated(rc, messages)
+		}
+	}
+}
+
+func (s *PluginSet) surfaceEmitted(rc *a2a.RequestContext, messages []map[string]any) {
+	for _, p := range s.snapshot() {
+		if hook, ok := p.(SurfaceEmittedPlugin); ok {
+			hook.OnSurfaceEmitted(rc, messages)
+		}
+	}
+}
+
+func (s *PluginSet) taskComplete(rc *a2a.RequestContext, state a2a.TaskState) {
+	for _, p := range s.snapshot() {
+		if hook, ok := p.(TaskCompletePlugin); ok {
+			hook.OnTaskComplete(rc, state)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// recordingPlugin implements every hook and records the calls.
+type recordingPlugin struct {
+	sessions  []*Session
+	schemas   int
+	validated [][]map[string]any
+	emitted   [][]map[string]any
+	completed []a2a.TaskState
+}
+
+func (p *recordingPlugin) PluginName() string { return "recording" }
+
+func (p *recordingPlugin) OnSessionPrepare(session *Session) {
+	p.sessions = append(p.sessions, session)
+}
+
+func (p *recordingPlugin) OnSchemaLoaded(schema map[string]any) { p.schemas++ }
+
+func (p *recordingPlugin) OnPayloadValidated(rc *a2a.RequestContext, messages []map[string]any) {
+	p.validated = append(p.validated, messages)
+}
+
+func (p *recordingPlugin) OnSurfaceEmitted(rc *a2a.RequestContext, messages []map[string]any) {
+	p.emitted = append(p.emitted, messages)
+}
+
+func (p *recordingPlugin) OnTaskComplete(rc *a2a.RequestContext, state a2a.TaskState) {
+	p.completed = append(p.completed, state)
+}
+
+// namedPlugin implements only the base interface; the executor must
+// skip it without calling anything.
+type namedPlugin struct{}
+
+func (namedPlugin) PluginName() string { return "named" }
+
+func TestPluginLifecycle(t *testing.T) {
+	payload := `{"` + a2uiJSONArgName + `": "[{\"beginRendering\": {\"surfaceId\": \"s1\", \"root\": \"root\"}}]"}`
+	server, _ := fakeModelToolServer(t, payload)
+	plugin := &recordingPlugin{}
+	plugins := NewPluginSet()
+	plugins.Register(plugin)
+	plugins.Register(namedPlugin{})
+	e, err := New(Config{
+		Client:  gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema:  testSchema,
+		Plugins: plugins,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if plugin.schemas != 1 {
+		t.Errorf("OnSchemaLoaded ran %d times, want once at construction", plugin.schemas)
+	}
+
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	queue.Close()
+
+	if len(plugin.sessions) != 1 {
+		t.Errorf("OnSessionPrepare ran %d times, want 1", len(plugin.sessions))
+	}
+	if len(plugin.validated) != 1 || len(plugin.validated[0]) != 1 {
+		t.Errorf("OnPayloadValidated calls = %v, want the one payload", plugin.validated)
+	}
+	if len(plugin.emitted) != 1 || len(plugin.emitted[0]) != 1 {
+		t.Errorf("OnSurfaceEmitted calls = %v, want the one payload", plugin.emitted)
+	}
+	if len(plugin.completed) != 1 || plugin.completed[0] != a2a.TaskStateCompleted {
+		t.Errorf("OnTaskComplete states = %v, want [completed]", plugin.completed)
+	}
+}
+
+func TestPluginApprovalDefersEmission(t *testing.T) {
+	payload := `{"` + a2uiJSONArgName + `": "[{\"beginRendering\": {\"surfaceId\": \"s1\", \"root\": \"root\"}}]"}`
+	server, _ := fakeModelToolServer(t, payload)
+	plugin := &recordingPlugin{}
+	plugins := NewPluginSet()
+	plugins.Register(plugin)
+	gate := NewApprovalGate()
+	e, err := New(Config{
+		Client:   gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema:   testSchema,
+		Approval: gate,
+		Plugins:  plugins,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(plugin.emitted) != 0 {
+		t.Fatalf("OnSurfaceEmitted ran for a parked payload: %v", plugin.emitted)
+	}
+	pending := gate.Pending()
+	if len(pending) != 1 {
+		t.Fatalf("Pending = %v, want one parked payload", pending)
+	}
+	if err := gate.Approve(pending[0].ID); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if len(plugin.emitted) != 1 {
+		t.Errorf("OnSurfaceEmitted ran %d times after approval, want 1", len(plugin.emitted))
+	}
+}
+
+func TestNilPluginSet(t *testing.T) {
+	var s *PluginSet
+	// All fan-outs must be safe on a nil set.
+	s.sessionPrepare(&Session{})
+	s.schemaLoaded(nil)
+	s.payloadValidated(nil, nil)
+	s.surfaceEmitted(nil, nil)
+	s.taskComplete(nil, a2a.TaskStateCompleted)
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// PayloadContext gives a policy the facts about one outgoing payload.
+type PayloadContext struct {
+	TaskID    string
+	ContextID string
+	// CatalogID identifies the component catalog the agent's schema was
+	// merged from, as configured in Config.CatalogID.
+	CatalogID string
+	// Components are the component type names used across the payload's
+	// surfaceUpdate messages, sorted and deduplicated.
+	Components []string
+	// Messages are the schema-validated A2UI messages about to be emitted,
+	// including any dataModelUpdate contents.
+	Messages []map[string]any
+}
+
+// PayloadPolicy is an organization-level rule evaluated on every outgoing
+// payload after schema validation. Evaluate returns the messages to emit —
+// unchanged to allow, rewritten to transform — or an error to deny the
+// payload. Transformed messages are re-validated before emission.
+type PayloadPolicy interface {
+	Evaluate(ctx context.Context, pc *PayloadContext) ([]map[string]any, error)
+}
+
+// applyPolicies runs the configured policies in order over the messages and
+// re-validates the result, since a policy may have rewritten it.
+func (e *Executor) applyPolicies(ctx context.Context, taskID, contextID string, messages []map[string]any) ([]map[string]any, error) {
+	for _, policy := range e.cfg.Policies {
+		pc := &PayloadContext{
+			TaskID:     taskID,
+			ContextID:  contextID,
+			CatalogID:  e.cfg.CatalogID,
+			Components: componentTypes(messages),
+			Messages:   messages,
+		}
+		result, err := policy.Evaluate(ctx, pc)
+		if err != nil {
+			return nil, fmt.Errorf("payload denied by policy: %w", err)
+		}
+		messages = result
+	}
+	if len(messages) > 0 {
+		list := make([]any, len(messages))
+		for i, msg := range messages {
+			list[i] = msg
+		}
+		if err := e.validatePayload(ctx, list); err != nil {
+			return nil, fmt.Errorf("policy-transformed payload does not conform to the A2UI schema: %w", err)
+		}
+	}
+	return messages, nil
+}
+
+// componentTypes collects the component type names used by the payload's
+// surfaceUpdate messages.
+func componentTypes(messages []map[string]any) []string {
+	seen := map[string]bool{}
+	for _, msg := range messages {
+		update, ok := msg["surfaceUpdate"].(map[string]any)
+		if !ok {
+			continue
+		}
+		components, _ := update["components"].([]any)
+		for _, entry := range components {
+			wrapper, ok := entry.(map[string]any)
+			if !ok {
+				continue
+			}
+			component, _ := wrapper["component"].(map[string]any)
+			for name := range component {
+				seen[name] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
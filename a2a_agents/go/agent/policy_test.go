@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// policyFunc adapts a function to PayloadPolicy.
+type policyFunc func(ctx context.Context, pc *PayloadContext) ([]map[string]any, error)
+
+func (f policyFunc) Evaluate(ctx context.Context, pc *PayloadContext) ([]map[string]any, error) {
+	return f(ctx, pc)
+}
+
+func TestComponentTypes(t *testing.T) {
+	messages := []map[string]any{
+		{"beginRendering": map[string]any{"surfaceId": "s1", "root": "root"}},
+		{"surfaceUpdate": map[string]any{"surfaceId": "s1", "components": []any{
+			map[string]any{"id": "root", "component": map[string]any{"Column": map[string]any{}}},
+			map[string]any{"id": "t", "component": map[string]any{"Text": map[string]any{}}},
+			map[string]any{"id": "t2", "component": map[string]any{"Text": map[string]any{}}},
+		}}},
+	}
+	if got, want := componentTypes(messages), []string{"Column", "Text"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("componentTypes = %v, want %v", got, want)
+	}
+}
+
+func TestPolicyDeniesPayload(t *testing.T) {
+	payload := `{"` + a2uiJSONArgName + `": "[{\"beginRendering\": {\"surfaceId\": \"s1\", \"root\": \"root\"}}]"}`
+	server, _ := fakeModelToolServer(t, payload)
+	var sawCatalog string
+	deny := policyFunc(func(ctx context.Context, pc *PayloadContext) ([]map[string]any, error) {
+		sawCatalog = pc.CatalogID
+		return nil, errors.New("no UI after hours")
+	})
+	e, err := New(Config{
+		Client:    gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema:    testSchema,
+		Policies:  []PayloadPolicy{deny},
+		CatalogID: "https://example.com/catalog.json",
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	queue.Close()
+	if got := drainEvents(queue); got != 0 {
+		t.Errorf("denied payload emitted %d artifacts", got)
+	}
+	if sawCatalog != "https://example.com/catalog.json" {
+		t.Errorf("policy saw catalog %q", sawCatalog)
+	}
+}
+
+func TestPolicyTransformsPayload(t *testing.T) {
+	rewrite := policyFunc(func(ctx context.Context, pc *PayloadContext) ([]map[string]any, error) {
+		out := append([]map[string]any{}, pc.Messages...)
+		out[0] = map[string]any{"beginRendering": map[string]any{"surfaceId": "rewritten", "root": "root"}}
+		return out, nil
+	})
+	e, err := New(Config{Schema: testSchema, Policies: []PayloadPolicy{rewrite}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2uiRequestContext("task1")
+	queue := a2a.NewEventQueue(16)
+	raw := `[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`
+	if _, _, _, _, _, err := e.emitPayload(context.Background(), rc, queue, raw); err != nil {
+		t.Fatalf("emitPayload: %v", err)
+	}
+	queue.Close()
+	event, _ := queue.Read()
+	artifact := event.(*a2a.ArtifactUpdateEvent).Artifact
+	raw2, err := json.Marshal(artifact.Parts[0].Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw2), "rewritten") {
+		t.Errorf("transformed payload not emitted: %s", raw2)
+	}
+}
+
+func TestPolicyTransformIsRevalidated(t *testing.T) {
+	breakIt := policyFunc(func(ctx context.Context, pc *PayloadContext) ([]map[string]any, error) {
+		return []map[string]any{{"beginRendering": "not an object"}}, nil
+	})
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"beginRendering": map[string]any{"type": "object"},
+		},
+	}
+	e, err := New(Config{Schema: schema, Policies: []PayloadPolicy{breakIt}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2uiRequestContext("task1")
+	queue := a2a.NewEventQueue(16)
+	raw := `[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`
+	if _, _, _, _, _, err := e.emitPayload(context.Background(), rc, queue, raw); err == nil {
+		t.Fatal("invalid transformed payload was not rejected")
+	}
+}
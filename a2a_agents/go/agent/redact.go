@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// redactedPlaceholder replaces a credential wherever redaction strikes.
+const redactedPlaceholder = "[REDACTED]"
+
+// secretKeyNames are header and argument names whose values are always
+// credentials, matched case-insensitively with "-" and "_" ignored.
+var secretKeyNames = map[string]bool{
+	"authorization":      true,
+	"proxyauthorization": true,
+	"cookie":             true,
+	"setcookie":          true,
+	"xgoogapikey":        true,
+	"xapikey":            true,
+	"apikey":             true,
+	"token":              true,
+	"accesstoken":        true,
+	"refreshtoken":       true,
+	"secret":             true,
+	"clientsecret":       true,
+	"password":           true,
+	"passwd":             true,
+}
+
+// redactPatterns match credentials embedded in free text: auth scheme
+// prefixes, well-known API key shapes, and key=value assignments with a
+// secret-sounding name.
+var redactPatterns = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]{8,}`), "$1 " + redactedPlaceholder},
+	{regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`), redactedPlaceholder},
+	{regexp.MustCompile(`\bsk-[A-Za-z0-9]{16,}\b`), redactedPlaceholder},
+	{regexp.MustCompile(`(?i)\b(api[_-]?key|access[_-]?token|refresh[_-]?token|token|secret|password|passwd)(["']?\s*[:=]\s*["']?)[^\s"',;&]+`),
+		"$1$2" + redactedPlaceholder},
+}
+
+// isSecretKey reports whether a header or argument name denotes a
+// credential.
+func isSecretKey(key string) bool {
+	normalized := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+	return secretKeyNames[normalized]
+}
+
+// RedactString masks credentials embedded in free text — bearer and basic
+// auth values, API keys, and secret-named key=value assignments — so error
+// strings and messages are safe to log.
+func RedactString(s string) string {
+	for _, p := range redactPatterns {
+		s = p.re.ReplaceAllString(s, p.replacement)
+	}
+	return s
+}
+
+// RedactArgs returns a copy of tool arguments safe to log: values under
+// secret-named keys are replaced wholesale, string values are run through
+// RedactString, and nested maps and lists are handled recursively. The
+// input is never modified.
+func RedactArgs(args map[string]any) map[string]any {
+	if args == nil {
+		return nil
+	}
+	out := make(map[string]any, len(args))
+	for key, value := range args {
+		if isSecretKey(key) {
+			out[key] = redactedPlaceholder
+			continue
+		}
+		out[key] = redactValue(value)
+	}
+	return out
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return RedactString(v)
+	case map[string]any:
+		return RedactArgs(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i] = redactValue(elem)
+		}
+		return out
+	}
+	return value
+}
+
+// RedactHeaders returns a copy of request headers safe to log, with the
+// values of credential-bearing headers replaced.
+func RedactHeaders(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	out := make(http.Header, len(h))
+	for key, values := range h {
+		if isSecretKey(key) {
+			out[key] = []string{redactedPlaceholder}
+			continue
+		}
+		copied := make([]string, len(values))
+		for i, value := range values {
+			copied[i] = RedactString(value)
+		}
+		out[key] = copied
+	}
+	return out
+}
+
+// NewRedactingHandler wraps a slog handler so every record passes through
+// redaction — the message, string attributes, error attributes, and
+// map-valued attributes such as logged tool arguments. Installing it as
+// the default handler applies the layer to all library and sample logging
+// at once.
+func NewRedactingHandler(inner slog.Handler) slog.Handler {
+	return redactingHandler{inner: inner}
+}
+
+type redactingHandler struct {
+	inner slog.Handler
+}
+
+func (h redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h redactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	clean := slog.NewRecord(r.Time, r.Level, RedactString(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		clean.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.inner.Handle(ctx, clean)
+}
+
+func (h redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	cleaned := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		cleaned[i] = redactAttr(a)
+	}
+	return redactingHandler{inner: h.inner.WithAttrs(cleaned)}
+}
+
+func (h redactingHandler) WithGroup(name string) slog.Handler {
+	return redactingHandler{inner: h.inner.WithGroup(name)}
+}
+
+// redactAttr redacts one attribute: secret-named keys are masked
+// wholesale, other values per their kind.
+func redactAttr(a slog.Attr) slog.Attr {
+	if isSecretKey(a.Key) {
+		return slog.String(a.Key, redactedPlaceholder)
+	}
+	value := a.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, RedactString(value.String()))
+	case slog.KindGroup:
+		members := value.Group()
+		cleaned := make([]any, 0, len(members))
+		for _, member := range members {
+			cleaned = append(cleaned, redactAttr(member))
+		}
+		return slog.Group(a.Key, cleaned...)
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case map[string]any:
+			return slog.Any(a.Key, RedactArgs(v))
+		case http.Header:
+			return slog.Any(a.Key, RedactHeaders(v))
+		case error:
+			return slog.String(a.Key, RedactString(v.Error()))
+		}
+	}
+	return a
+}
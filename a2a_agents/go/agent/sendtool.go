@@ -0,0 +1,257 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/tools"
+)
+
+// SendToolName is the function the model calls to send A2UI JSON to the
+// client.
+const SendToolName = "send_a2ui_json_to_client"
+
+// A2UIArtifactName is the artifact name clients key on to render A2UI
+// payloads.
+const A2UIArtifactName = "a2ui"
+
+// PreviewArtifactName names artifacts emitted in dry-run mode; clients must
+// not render them.
+const PreviewArtifactName = "a2ui_preview"
+
+// DryRunMetadataKey is the artifact metadata key marking dry-run payloads.
+const DryRunMetadataKey = "dry_run"
+
+const (
+	a2uiJSONArgName      = "a2ui_json"
+	validatedA2UIJSONKey = "validated_a2ui_json"
+	duplicateCountKey    = "duplicate_payloads_skipped"
+	pendingApprovalKey   = "pending_approval_id"
+	warningsKey          = "validation_warnings"
+	payloadErrorsKey     = "payload_errors"
+	toolErrorKey         = "error"
+)
+
+// sendToolDeclaration describes the A2UI send tool to the model.
+func sendToolDeclaration() *tools.Declaration {
+	return &tools.Declaration{
+		Name: SendToolName,
+		Description: "Sends A2UI JSON to the client to render rich UI for the user." +
+			" This tool can be called multiple times in the same call to render" +
+			" multiple UI surfaces. Args: " + a2uiJSONArgName + ": Valid A2UI JSON" +
+			" Schema to send to the client. The A2UI JSON Schema definition is" +
+			" between " + a2ui.SchemaBlockBegin + " and " + a2ui.SchemaBlockEnd +
+			" in the system instructions.",
+		Parameters: &tools.Schema{
+			Type: "object",
+			Properties: map[string]*tools.Schema{
+				a2uiJSONArgName: {
+					Type:        "string",
+					Description: "valid A2UI JSON Schema to send to the client.",
+				},
+			},
+			Required: []string{a2uiJSONArgName},
+		},
+	}
+}
+
+// handleSendA2UI validates the payload the model produced, suppresses
+// payloads already emitted for this task, emits the rest as an A2UI artifact
+// and returns the function response for the model.
+func (e *Executor) handleSendA2UI(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue, args map[string]any) map[string]any {
+	raw, _ := args[a2uiJSONArgName].(string)
+	if raw == "" {
+		return sendToolError(fmt.Errorf("missing required arg %s", a2uiJSONArgName))
+	}
+	list, duplicates, pendingID, warnings, payloadErrors, err := e.emitPayload(ctx, rc, queue, raw)
+	if err != nil {
+		return sendToolError(err)
+	}
+	response := map[string]any{validatedA2UIJSONKey: list}
+	if duplicates > 0 {
+		response[duplicateCountKey] = duplicates
+	}
+	if pendingID != "" {
+		response[pendingApprovalKey] = pendingID
+	}
+	if len(warnings) > 0 {
+		response[warningsKey] = warnings
+	}
+	if len(payloadErrors) > 0 {
+		response[payloadErrorsKey] = payloadErrors
+	}
+	return response
+}
+
+// emitPayload parses and validates a raw A2UI payload string, suppresses
+// payloads already emitted for this task, and emits the rest as an A2UI
+// artifact — or parks them in the approval gate when one is configured,
+// returning the pending ID. When only some of several payloads validate,
+// the valid ones are emitted and the rest come back as per-payload
+// errors, so one bad surface does not sink its siblings. It is shared by
+// the send tool and the structured-output mode.
+func (e *Executor) emitPayload(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue, raw string) (validated []any, duplicates int, pendingID string, warnings, payloadErrors []string, err error) {
+	var payload any
+	if err := a2a.ActiveCodec().Unmarshal([]byte(raw), &payload); err != nil {
+		return nil, 0, "", nil, nil, fmt.Errorf("parsing %s: %w", a2uiJSONArgName, err)
+	}
+	list, ok := payload.([]any)
+	if !ok {
+		// Auto-wrap a single object in a list.
+		slog.Info("received a single JSON object, wrapping in a list for validation")
+		list = []any{payload}
+	}
+	messages := make([]map[string]any, len(list))
+	for i, elem := range list {
+		messages[i], ok = elem.(map[string]any)
+		if !ok {
+			return nil, 0, "", nil, nil, fmt.Errorf("payload element %d is not an object", i)
+		}
+	}
+	if len(e.cfg.Aliases) > 0 {
+		messages, err = a2ui.ApplyAliases(messages, e.cfg.Aliases)
+		if err != nil {
+			return nil, 0, "", nil, nil, err
+		}
+		list = make([]any, len(messages))
+		for i, msg := range messages {
+			list[i] = msg
+		}
+	}
+	if err := e.validatePayload(ctx, list); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return nil, 0, "", nil, nil, fmt.Errorf("validating payload: %w", err)
+		}
+		// Re-validate each payload alone: emit the valid ones and report
+		// the rest individually, instead of rejecting the whole argument.
+		var kept []map[string]any
+		for i, msg := range messages {
+			verr := e.validatePayload(ctx, []any{msg})
+			if verr == nil {
+				kept = append(kept, msg)
+				continue
+			}
+			if errors.Is(verr, context.DeadlineExceeded) || errors.Is(verr, context.Canceled) {
+				return nil, 0, "", nil, nil, fmt.Errorf("validating payload: %w", verr)
+			}
+			payloadErrors = append(payloadErrors,
+				fmt.Sprintf("payload %d does not conform to the A2UI schema; correct it and resend:\n%s",
+					i, a2ui.RenderCorrections(verr, []map[string]any{msg})))
+		}
+		if len(kept) == 0 {
+			return nil, 0, "", nil, nil, fmt.Errorf("payload does not conform to the A2UI schema; correct it and resend:\n%s",
+				a2ui.RenderCorrections(err, messages))
+		}
+		slog.Info("emitting partially valid payload", "task", rc.TaskID,
+			"valid", len(kept), "rejected", len(payloadErrors))
+		messages = kept
+		list = make([]any, len(messages))
+		for i, msg := range messages {
+			list[i] = msg
+		}
+	}
+	warnings, err = e.lint(rc.TaskID, messages)
+	if err != nil {
+		return nil, 0, "", nil, payloadErrors, err
+	}
+	e.cfg.Plugins.payloadValidated(rc, messages)
+	e.logLarge("validated A2UI payload", "payload", raw, "task", rc.TaskID)
+
+	// Once the payload validates, its messages can pass through as the
+	// model's verbatim JSON — a re-marshal would perturb number formatting
+	// and key order, complicating payload hashing and golden tests.
+	// Aliasing and policies rewrite messages, so those paths re-marshal.
+	var segments []json.RawMessage
+	if len(e.cfg.Aliases) == 0 && len(e.cfg.Policies) == 0 {
+		if split, err := a2ui.SplitRawMessages([]byte(raw)); err == nil && len(split) == len(messages) {
+			segments = split
+		}
+	}
+
+	freshIndexes, duplicates := e.dedupe.filterIndex(rc.TaskID, messages)
+	if duplicates > 0 {
+		slog.Info("suppressed duplicate A2UI payloads", "task", rc.TaskID, "count", duplicates)
+	}
+	fresh := make([]map[string]any, 0, len(freshIndexes))
+	for _, i := range freshIndexes {
+		fresh = append(fresh, messages[i])
+	}
+	if len(fresh) > 0 && len(e.cfg.Policies) > 0 {
+		fresh, err = e.applyPolicies(ctx, rc.TaskID, rc.ContextID, fresh)
+		if err != nil {
+			return nil, 0, "", nil, payloadErrors, err
+		}
+	}
+	if len(fresh) > 0 {
+		parts := make([]a2a.Part, len(fresh))
+		for i, msg := range fresh {
+			if segments != nil && i < len(freshIndexes) {
+				parts[i] = a2ui.NewRawPart(segments[freshIndexes[i]])
+				continue
+			}
+			parts[i] = a2ui.NewPart(msg)
+		}
+		artifact := a2a.Artifact{
+			ArtifactID: a2a.NewID(),
+			Name:       A2UIArtifactName,
+			Parts:      parts,
+		}
+		metadata := map[string]any{}
+		if variant := e.cfg.Experiment.variant(rc.ContextID); variant != nil {
+			metadata[VariantMetadataKey] = variant.ID
+		}
+		if e.cfg.DryRun {
+			artifact.Name = PreviewArtifactName
+			metadata[DryRunMetadataKey] = true
+		}
+		if len(metadata) > 0 {
+			artifact.Metadata = metadata
+		}
+		event := &a2a.ArtifactUpdateEvent{
+			TaskID:    rc.TaskID,
+			ContextID: rc.ContextID,
+			Artifact:  artifact,
+		}
+		audit := func() {
+			if e.cfg.Audit != nil {
+				e.cfg.Audit.record(rc.TaskID, rc.ContextID, fresh)
+			}
+			e.cfg.Plugins.surfaceEmitted(rc, fresh)
+		}
+		if e.cfg.Approval != nil {
+			pendingID = e.cfg.Approval.park(queue, event, fresh, audit)
+			slog.Info("parked A2UI payload for review", "task", rc.TaskID, "pending", pendingID)
+		} else {
+			if err := queue.Write(event); err != nil {
+				return nil, 0, "", nil, payloadErrors, fmt.Errorf("emitting A2UI artifact: %w", err)
+			}
+			audit()
+		}
+	}
+	return list, duplicates, pendingID, warnings, payloadErrors, nil
+}
+
+func sendToolError(err error) map[string]any {
+	msg := fmt.Sprintf("Failed to call A2UI tool %s: %v", SendToolName, err)
+	slog.Error(msg)
+	return map[string]any{toolErrorKey: msg}
+}
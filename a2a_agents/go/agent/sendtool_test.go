@@ -0,0 +1,187 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+func TestHandleSendA2UIRawPassThrough(t *testing.T) {
+	e := testExecutor(t)
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(8)
+	// 1.50 and the key order below only survive if the validated payload is
+	// forwarded byte-for-byte instead of re-marshaled.
+	payload := `[{"dataModelUpdate": {"surfaceId": "s1", "contents": [{"key": "x", "valueNumber": 1.50}]}}]`
+	resp := e.handleSendA2UI(context.Background(), rc, queue, map[string]any{a2uiJSONArgName: payload})
+	if _, ok := resp[toolErrorKey]; ok {
+		t.Fatalf("send failed: %v", resp)
+	}
+	event, ok := queue.Read()
+	if !ok {
+		t.Fatal("no artifact event emitted")
+	}
+	artifact := event.(*a2a.ArtifactUpdateEvent).Artifact
+	if len(artifact.Parts) != 1 {
+		t.Fatalf("got %d parts, want 1", len(artifact.Parts))
+	}
+	if artifact.Parts[0].RawData == nil {
+		t.Fatal("part does not carry raw JSON")
+	}
+	out, err := json.Marshal(artifact.Parts[0])
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(out), "1.50") {
+		t.Errorf("number formatting perturbed:\n%s", out)
+	}
+}
+
+func TestHandleSendA2UIRemarshalsWithPolicies(t *testing.T) {
+	redact := policyFunc(func(ctx context.Context, pc *PayloadContext) ([]map[string]any, error) {
+		for _, msg := range pc.Messages {
+			update, ok := msg["dataModelUpdate"].(map[string]any)
+			if !ok {
+				continue
+			}
+			contents, _ := update["contents"].([]any)
+			for _, entry := range contents {
+				if value, ok := entry.(map[string]any); ok {
+					value["valueString"] = "[redacted]"
+				}
+			}
+		}
+		return pc.Messages, nil
+	})
+	e, err := New(Config{Schema: testSchema, Policies: []PayloadPolicy{redact}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(8)
+	payload := `[{"dataModelUpdate": {"surfaceId": "s1", "contents": [{"key": "secret", "valueString": "hunter2"}]}}]`
+	resp := e.handleSendA2UI(context.Background(), rc, queue, map[string]any{a2uiJSONArgName: payload})
+	if _, ok := resp[toolErrorKey]; ok {
+		t.Fatalf("send failed: %v", resp)
+	}
+	event, ok := queue.Read()
+	if !ok {
+		t.Fatal("no artifact event emitted")
+	}
+	artifact := event.(*a2a.ArtifactUpdateEvent).Artifact
+	if artifact.Parts[0].RawData != nil {
+		t.Error("policy-rewritten payload passed through raw")
+	}
+	out, _ := json.Marshal(artifact.Parts[0])
+	if strings.Contains(string(out), "hunter2") {
+		t.Errorf("policy did not apply to emitted part:\n%s", out)
+	}
+}
+
+func TestHandleSendA2UIPartialFailure(t *testing.T) {
+	// A schema accepting only deleteSurface messages, so the middle
+	// payload below fails while its siblings validate.
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           map[string]any{"deleteSurface": map[string]any{"type": "object"}},
+		"required":             []any{"deleteSurface"},
+		"additionalProperties": false,
+	}
+	e, err := New(Config{Schema: schema})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(8)
+	payload := `[
+		{"deleteSurface": {"surfaceId": "s1"}},
+		{"bogus": {"surfaceId": "s2"}},
+		{"deleteSurface": {"surfaceId": "s3"}}
+	]`
+	resp := e.handleSendA2UI(context.Background(), rc, queue, map[string]any{a2uiJSONArgName: payload})
+	if msg, ok := resp[toolErrorKey]; ok {
+		t.Fatalf("partially valid payload rejected wholesale: %v", msg)
+	}
+	if validated, _ := resp[validatedA2UIJSONKey].([]any); len(validated) != 2 {
+		t.Errorf("validated = %v, want the two valid payloads", validated)
+	}
+	perPayload, _ := resp[payloadErrorsKey].([]string)
+	if len(perPayload) != 1 || !strings.Contains(perPayload[0], "payload 1") {
+		t.Errorf("payload errors = %v, want one error naming payload 1", perPayload)
+	}
+
+	event, ok := queue.Read()
+	if !ok {
+		t.Fatal("no artifact event emitted for the valid payloads")
+	}
+	artifact := event.(*a2a.ArtifactUpdateEvent).Artifact
+	if len(artifact.Parts) != 2 {
+		t.Errorf("artifact carries %d parts, want the 2 valid payloads", len(artifact.Parts))
+	}
+}
+
+func TestHandleSendA2UIAllInvalid(t *testing.T) {
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           map[string]any{"deleteSurface": map[string]any{"type": "object"}},
+		"required":             []any{"deleteSurface"},
+		"additionalProperties": false,
+	}
+	e, err := New(Config{Schema: schema})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(8)
+	resp := e.handleSendA2UI(context.Background(), rc, queue, map[string]any{
+		a2uiJSONArgName: `[{"bogus": {}}, {"alsoBogus": {}}]`,
+	})
+	if _, ok := resp[toolErrorKey]; !ok {
+		t.Fatalf("fully invalid payload did not error: %v", resp)
+	}
+	queue.Close()
+	if _, ok := queue.Read(); ok {
+		t.Error("an artifact was emitted for a fully invalid payload")
+	}
+}
+
+func TestEmitPayloadCanceledContext(t *testing.T) {
+	// ValidationTimeout composes with the request context; a canceled
+	// request aborts validation the same way an expired deadline does.
+	e, err := New(Config{Schema: testSchema, ValidationTimeout: time.Minute})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2a.NewRequestContext(nil, "task1", "ctx1", nil)
+	queue := a2a.NewEventQueue(8)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp := e.handleSendA2UI(ctx, rc, queue, map[string]any{
+		a2uiJSONArgName: `[{"deleteSurface": {"surfaceId": "s1"}}]`,
+	})
+	msg, ok := resp[toolErrorKey].(string)
+	if !ok {
+		t.Fatalf("canceled request did not error: %v", resp)
+	}
+	if !strings.Contains(msg, "canceled") {
+		t.Errorf("error does not mention cancellation: %s", msg)
+	}
+}
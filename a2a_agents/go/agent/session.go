@@ -0,0 +1,79 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"strings"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+// Session is the per-request context handed to instruction templates and
+// payload builders: the user's query plus the client preferences sent in
+// the message's A2UI capabilities. Preference fields are empty when the
+// client sent no hint.
+type Session struct {
+	// TaskID and ContextID identify the request.
+	TaskID    string
+	ContextID string
+	// Query is the user's message text.
+	Query string
+	// Theme is the client's theme preference, a2ui.ThemeLight or
+	// a2ui.ThemeDark.
+	Theme string
+	// Locale is the client's BCP 47 locale tag, e.g. "de-DE".
+	Locale string
+	// TimeZone is the client's IANA timezone name, e.g. "Europe/Berlin".
+	TimeZone string
+	// Viewport is the client's viewport hint, nil when the client sent
+	// none.
+	Viewport *a2ui.Viewport
+	// DataModelState is the client's snapshot of each active surface's
+	// data model, keyed by surface ID, nil when the client sent none.
+	DataModelState map[string]map[string]any
+}
+
+// compactViewportWidth is the width in CSS pixels below which a viewport
+// is treated as compact (phones and narrow embeds).
+const compactViewportWidth = 600
+
+// PrepareSession builds the session for one request from the request
+// context and the client capabilities on its message.
+func PrepareSession(rc *a2a.RequestContext) *Session {
+	return &Session{
+		TaskID:         rc.TaskID,
+		ContextID:      rc.ContextID,
+		Query:          messageText(rc.Message),
+		Theme:          strings.ToLower(a2ui.ClientTheme(rc.Message)),
+		Locale:         a2ui.ClientLocale(rc.Message),
+		TimeZone:       a2ui.ClientTimeZone(rc.Message),
+		Viewport:       a2ui.ClientViewport(rc.Message),
+		DataModelState: a2ui.ClientDataModelState(rc.Message),
+	}
+}
+
+// Compact reports whether the client's viewport calls for a compact
+// layout — single column, smaller charts — rather than a desktop
+// dashboard.
+func (s *Session) Compact() bool {
+	return s.Viewport != nil && s.Viewport.Width > 0 && s.Viewport.Width < compactViewportWidth
+}
+
+// Dark reports whether the client asked for the dark theme, so builders
+// can pick theme-appropriate colors without string comparisons.
+func (s *Session) Dark() bool {
+	return s.Theme == a2ui.ThemeDark
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+func TestPrepareSession(t *testing.T) {
+	rc := a2uiRequestContext("task1")
+	rc.Message.Metadata = map[string]any{
+		a2ui.ClientCapabilitiesKey: map[string]any{
+			a2ui.ThemeKey:    "Dark",
+			a2ui.LocaleKey:   "de-DE",
+			a2ui.TimeZoneKey: "Europe/Berlin",
+		},
+	}
+	session := PrepareSession(rc)
+	if session.TaskID != "task1" || session.Query == "" {
+		t.Errorf("session = %+v", session)
+	}
+	if session.Theme != a2ui.ThemeDark || !session.Dark() {
+		t.Errorf("theme = %q, Dark = %v", session.Theme, session.Dark())
+	}
+	if session.Locale != "de-DE" || session.TimeZone != "Europe/Berlin" {
+		t.Errorf("locale/timezone = %q/%q", session.Locale, session.TimeZone)
+	}
+
+	// Without capabilities every preference is empty.
+	bare := PrepareSession(a2uiRequestContext("task2"))
+	if bare.Theme != "" || bare.Locale != "" || bare.TimeZone != "" || bare.Dark() {
+		t.Errorf("bare session has preferences: %+v", bare)
+	}
+	if bare.Viewport != nil || bare.Compact() {
+		t.Errorf("bare session has a viewport: %+v", bare.Viewport)
+	}
+}
+
+func TestSessionCompact(t *testing.T) {
+	tests := []struct {
+		name     string
+		viewport map[string]any
+		want     bool
+	}{
+		{"phone", map[string]any{"width": float64(390), "height": float64(844), "density": 3.0}, true},
+		{"desktop", map[string]any{"width": float64(1440), "height": float64(900)}, false},
+		{"no width", map[string]any{"density": 2.0}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := a2uiRequestContext("task1")
+			rc.Message.Metadata = map[string]any{
+				a2ui.ClientCapabilitiesKey: map[string]any{a2ui.ViewportKey: tt.viewport},
+			}
+			session := PrepareSession(rc)
+			if session.Viewport == nil {
+				t.Fatal("viewport not extracted")
+			}
+			if got := session.Compact(); got != tt.want {
+				t.Errorf("Compact() = %v, want %v (viewport %+v)", got, tt.want, session.Viewport)
+			}
+		})
+	}
+}
+
+func TestDataModelStateInPrompt(t *testing.T) {
+	server, requests := fakeModelServer(t, "done")
+	e, err := New(Config{
+		Client: gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema: testSchema,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2uiRequestContext("task1")
+	rc.Message.Metadata = map[string]any{
+		a2ui.DataModelStateKey: map[string]any{
+			"main": map[string]any{"filter": "last_30_days"},
+		},
+	}
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), rc, queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	queue.Close()
+	if len(*requests) == 0 {
+		t.Fatal("no model requests")
+	}
+	parts := (*requests)[0].Contents[0].Parts
+	if len(parts) != 2 {
+		t.Fatalf("user turn has %d parts, want query plus snapshot", len(parts))
+	}
+	if !strings.Contains(parts[1].Text, "last_30_days") || !strings.Contains(parts[1].Text, "surface ID") {
+		t.Errorf("snapshot part = %q", parts[1].Text)
+	}
+
+	// Without a snapshot the user turn is just the query.
+	session := PrepareSession(a2uiRequestContext("task2"))
+	if session.DataModelState != nil {
+		t.Errorf("bare session has data-model state: %+v", session.DataModelState)
+	}
+	if got := userParts(session); len(got) != 1 {
+		t.Errorf("bare user turn has %d parts, want 1", len(got))
+	}
+}
+
+func TestSessionInstructionsFunc(t *testing.T) {
+	server, requests := fakeModelServer(t, "done")
+	e, err := New(Config{
+		Client: gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema: testSchema,
+		SessionInstructionsFunc: func(session *Session) string {
+			if session.Dark() {
+				return "use dark colors"
+			}
+			return "use light colors"
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2uiRequestContext("task1")
+	rc.Message.Metadata = map[string]any{
+		a2ui.ClientCapabilitiesKey: map[string]any{a2ui.ThemeKey: a2ui.ThemeDark},
+	}
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), rc, queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	queue.Close()
+	if len(*requests) == 0 {
+		t.Fatal("no model requests")
+	}
+	system := (*requests)[0].SystemInstruction
+	if system == nil || !strings.Contains(system.Parts[0].Text, "use dark colors") {
+		t.Errorf("system instruction did not use the session: %+v", system)
+	}
+}
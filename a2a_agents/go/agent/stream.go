@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// StreamingLLMClient is the optional LLMClient extension for backends
+// that can stream a model turn. GenerateContentStream behaves like
+// GenerateContent but additionally delivers the raw function-call
+// argument text through onArgs as it is generated, identified by the
+// called function's name. Chunks carry the arguments JSON verbatim and
+// may split it anywhere, including mid-escape.
+//
+// When the backend implements it, the executor validates and emits each
+// A2UI message from the send tool's payload as its closing brace
+// streams in — the leading beginRendering reaches the client while the
+// model is still generating the much larger surfaceUpdate behind it.
+// Backends without it fall back to emitting after the full call.
+type StreamingLLMClient interface {
+	GenerateContentStream(ctx context.Context, req *gemini.GenerateRequest, onArgs func(call, chunk string)) (*gemini.GenerateResponse, error)
+}
+
+// generateStreaming runs one model turn, early-emitting streamed send-tool
+// messages when the backend supports it and falling back to generate
+// otherwise. Early emission goes through the same validation, dedupe and
+// policy path as the completed call; the dedupe ledger then suppresses
+// re-emission when the finished call is dispatched, so each message
+// reaches the client exactly once. Messages that fail to parse or
+// validate mid-stream are left for the completed call to report.
+func (e *Executor) generateStreaming(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue, req *gemini.GenerateRequest) (*gemini.GenerateResponse, error) {
+	client, ok := e.cfg.Client.(StreamingLLMClient)
+	if !ok || e.cfg.Approval != nil {
+		// Early emission would park each streamed message as its own
+		// pending approval; keep review to one entry per send.
+		return e.generate(ctx, req)
+	}
+	if !e.breaker.allow() {
+		return nil, errBreakerOpen
+	}
+	genCtx, cancel := phaseContext(ctx, e.cfg.LLMTimeout)
+	defer cancel()
+	parser := a2ui.NewArgsStreamParser(a2uiJSONArgName)
+	resp, err := client.GenerateContentStream(genCtx, req, func(call, chunk string) {
+		if call != SendToolName {
+			return
+		}
+		msgs, err := parser.Feed(chunk)
+		if err != nil {
+			slog.Debug("streamed arguments did not parse", "task", rc.TaskID, "error", err)
+			return
+		}
+		for _, msg := range msgs {
+			if _, _, _, _, _, err := e.emitPayload(ctx, rc, queue, string(msg)); err != nil {
+				slog.Debug("streamed message held for the completed call", "task", rc.TaskID, "error", err)
+			}
+		}
+	})
+	if err != nil {
+		e.breaker.recordFailure()
+		return nil, err
+	}
+	e.breaker.recordSuccess()
+	return resp, nil
+}
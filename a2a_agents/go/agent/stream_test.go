@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// streamingFakeLLM streams each response's function-call arguments in
+// fixed-size chunks before returning the completed response.
+type streamingFakeLLM struct {
+	fakeLLM
+	chunkSize int
+	streams   int
+}
+
+func (f *streamingFakeLLM) GenerateContentStream(ctx context.Context, req *gemini.GenerateRequest, onArgs func(call, chunk string)) (*gemini.GenerateResponse, error) {
+	f.streams++
+	for _, call := range f.responses[0].FunctionCalls() {
+		raw, err := json.Marshal(call.Args)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(raw); i += f.chunkSize {
+			end := i + f.chunkSize
+			if end > len(raw) {
+				end = len(raw)
+			}
+			onArgs(call.Name, string(raw[i:end]))
+		}
+	}
+	return f.GenerateContent(ctx, req)
+}
+
+func sendResponse(payload string) *gemini.GenerateResponse {
+	return &gemini.GenerateResponse{Candidates: []gemini.Candidate{{
+		Content: gemini.Content{Role: gemini.RoleModel, Parts: []gemini.Part{{
+			FunctionCall: &gemini.FunctionCall{
+				Name: SendToolName,
+				Args: map[string]any{a2uiJSONArgName: payload},
+			},
+		}}},
+	}}}
+}
+
+func TestExecuteEmitsStreamedMessagesEarly(t *testing.T) {
+	payload := `[{"beginRendering": {"surfaceId": "s1", "root": "root"}},` +
+		` {"surfaceUpdate": {"surfaceId": "s1", "components": [{"id": "root"}]}}]`
+	llm := &streamingFakeLLM{
+		fakeLLM:   fakeLLM{responses: []*gemini.GenerateResponse{sendResponse(payload), textResponse("Done.")}},
+		chunkSize: 7,
+	}
+	e, err := New(Config{Client: llm, Schema: testSchema})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	queue.Close()
+	if llm.streams == 0 {
+		t.Fatal("executor never used the streaming backend")
+	}
+
+	// Each message ships in its own artifact as its closing brace streams
+	// in; the blocking path would emit one artifact with both parts.
+	var artifacts []*a2a.ArtifactUpdateEvent
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		if artifact, ok := event.(*a2a.ArtifactUpdateEvent); ok {
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	if len(artifacts) != 2 {
+		t.Fatalf("got %d artifact events, want one per streamed message", len(artifacts))
+	}
+	for i, artifact := range artifacts {
+		if len(artifact.Artifact.Parts) != 1 {
+			t.Errorf("artifact %d carries %d parts, want 1", i, len(artifact.Artifact.Parts))
+		}
+	}
+
+	// The completed call finds both messages already emitted, so the
+	// model's function response reports them as suppressed duplicates.
+	feedback := llm.requests[len(llm.requests)-1].Contents
+	last := feedback[len(feedback)-1]
+	response := last.Parts[0].FunctionResponse.Response
+	if response[duplicateCountKey] != 2 {
+		t.Errorf("duplicate count = %v, want 2", response[duplicateCountKey])
+	}
+}
+
+func TestGenerateStreamingSkipsApprovalGate(t *testing.T) {
+	payload := `[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`
+	llm := &streamingFakeLLM{
+		fakeLLM:   fakeLLM{responses: []*gemini.GenerateResponse{sendResponse(payload), textResponse("Done.")}},
+		chunkSize: 5,
+	}
+	gate := NewApprovalGate()
+	e, err := New(Config{Client: llm, Schema: testSchema, Approval: gate})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if llm.streams != 0 {
+		t.Errorf("streamed %d turns with an approval gate configured, want the blocking path", llm.streams)
+	}
+	if pending := gate.Pending(); len(pending) != 1 {
+		t.Errorf("got %d pending approvals, want the one parked send", len(pending))
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// structuredMaxAttempts bounds validation-correction retries in structured
+// output mode.
+const structuredMaxAttempts = 3
+
+// structuredInstruction tells the model to answer with the payload directly
+// instead of calling the send tool.
+const structuredInstruction = "Respond with a JSON array of A2UI messages " +
+	"conforming to the schema below. Respond with the JSON only; do not " +
+	"wrap it in markdown or add commentary."
+
+// executeStructured obtains the A2UI payload as JSON structured output
+// (responseMimeType application/json) instead of a tool call, then runs it
+// through the same validation and emission pipeline. Some models produce
+// better-formed JSON this way. Data tools are not available in this mode,
+// since JSON response mode and function calling are mutually exclusive.
+func (e *Executor) executeStructured(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue) error {
+	session := PrepareSession(rc)
+	e.cfg.Plugins.sessionPrepare(session)
+	query := session.Query
+	system := e.systemInstruction(session)
+	system.Parts = append([]gemini.Part{{Text: structuredInstruction}}, system.Parts...)
+	req := &gemini.GenerateRequest{
+		Contents:          []gemini.Content{{Role: gemini.RoleUser, Parts: []gemini.Part{{Text: query}}}},
+		SystemInstruction: system,
+		GenerationConfig: &gemini.GenerationConfig{
+			ResponseMIMEType: "application/json",
+			ResponseSchema:   e.responseSchema,
+		},
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < structuredMaxAttempts; attempt++ {
+		resp, err := e.generate(ctx, req)
+		if err != nil {
+			slog.Error("LLM call failed", "task", rc.TaskID, "error", err)
+			reason := "The agent failed to generate a response."
+			if err == errBreakerOpen {
+				reason = breakerUnavailableMessage
+			} else if errors.Is(err, context.DeadlineExceeded) {
+				reason = timeoutMessage
+			}
+			if failErr := e.fail(rc, queue, reason); failErr != nil {
+				return failErr
+			}
+			return err
+		}
+		raw := resp.Text()
+		if _, _, _, _, _, err := e.emitPayload(ctx, rc, queue, raw); err == nil {
+			return e.status(rc, queue, a2a.TaskStateCompleted, nil, true)
+		} else {
+			lastErr = err
+			slog.Warn("structured payload failed validation", "task", rc.TaskID,
+				"attempt", attempt+1, "error", err)
+			req.Contents = append(req.Contents,
+				resp.Candidates[0].Content,
+				gemini.Content{Role: gemini.RoleUser, Parts: []gemini.Part{{Text: fmt.Sprintf(
+					"The previous response was invalid: %v. Respond again with the full corrected JSON array.", err)}}},
+			)
+		}
+	}
+	if failErr := e.fail(rc, queue, "The agent could not produce a valid UI payload."); failErr != nil {
+		return failErr
+	}
+	return fmt.Errorf("agent: structured output failed validation after %d attempts: %w",
+		structuredMaxAttempts, lastErr)
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+)
+
+// fakeModelServer serves canned generateContent responses in order.
+func fakeModelServer(t *testing.T, texts ...string) (*httptest.Server, *[]gemini.GenerateRequest) {
+	t.Helper()
+	var requests []gemini.GenerateRequest
+	i := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req gemini.GenerateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding model request: %v", err)
+		}
+		requests = append(requests, req)
+		text := texts[len(texts)-1]
+		if i < len(texts) {
+			text = texts[i]
+			i++
+		}
+		resp := gemini.GenerateResponse{Candidates: []gemini.Candidate{{
+			Content: gemini.Content{Role: "model", Parts: []gemini.Part{{Text: text}}},
+		}}}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("encoding model response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func a2uiRequestContext(taskID string) *a2a.RequestContext {
+	msg := &a2a.Message{
+		MessageID:  "m1",
+		Role:       a2a.RoleUser,
+		Parts:      []a2a.Part{a2a.NewTextPart("show me a chart")},
+		Extensions: []string{"https://a2ui.org/a2a-extension/a2ui/v0.8"},
+	}
+	return a2a.NewRequestContext(msg, taskID, "ctx1", nil)
+}
+
+func TestExecuteStructuredOutput(t *testing.T) {
+	server, requests := fakeModelServer(t,
+		`[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`)
+	e, err := New(Config{
+		Client: gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema: testSchema,
+		Mode:   ModeStructuredOutput,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	queue.Close()
+
+	var sawArtifact, sawCompleted bool
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		switch ev := event.(type) {
+		case *a2a.ArtifactUpdateEvent:
+			sawArtifact = true
+		case *a2a.StatusUpdateEvent:
+			if ev.Final && ev.Status.State == a2a.TaskStateCompleted {
+				sawCompleted = true
+			}
+		}
+	}
+	if !sawArtifact || !sawCompleted {
+		t.Errorf("artifact=%v completed=%v, want both", sawArtifact, sawCompleted)
+	}
+
+	req := (*requests)[0]
+	if req.GenerationConfig == nil || req.GenerationConfig.ResponseMIMEType != "application/json" {
+		t.Error("structured mode did not request application/json output")
+	}
+	if len(req.Tools) != 0 {
+		t.Error("structured mode offered tools to the model")
+	}
+}
+
+func TestExecuteStructuredOutputRetriesOnInvalidPayload(t *testing.T) {
+	server, requests := fakeModelServer(t,
+		`not json at all`,
+		`[{"beginRendering": {"surfaceId": "s1", "root": "root"}}]`)
+	e, err := New(Config{
+		Client: gemini.NewClient("key", "test-model", gemini.WithBaseURL(server.URL)),
+		Schema: testSchema,
+		Mode:   ModeStructuredOutput,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	queue := a2a.NewEventQueue(16)
+	if err := e.Execute(context.Background(), a2uiRequestContext("task1"), queue); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(*requests) != 2 {
+		t.Fatalf("model called %d times, want 2 (one retry)", len(*requests))
+	}
+	// The retry carries the validation error back to the model.
+	retry := (*requests)[1]
+	if len(retry.Contents) < 3 {
+		t.Fatalf("retry request has %d contents, want the correction appended", len(retry.Contents))
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+	"github.com/google/A2UI/a2a_agents/go/tools"
+)
+
+// ToolLoopOptions configure RunToolLoop.
+type ToolLoopOptions struct {
+	// SystemInstruction is the system prompt for the conversation.
+	SystemInstruction *gemini.Content
+	// Contents seed the conversation, typically one user turn.
+	Contents []gemini.Content
+	// OnCall, when set, observes every function call before it is
+	// dispatched.
+	OnCall func(call *gemini.FunctionCall)
+	// MaxTurns caps the model turns the loop may take. Zero applies the
+	// executor's built-in default; negative disables the cap.
+	MaxTurns int
+	// MaxRepeatedCalls is how many consecutive identical tool-call turns
+	// are tolerated before the loop aborts as stuck. Zero applies the
+	// built-in default; negative disables the check.
+	MaxRepeatedCalls int
+}
+
+// ToolLoopResult is the outcome of a completed tool loop.
+type ToolLoopResult struct {
+	// Text is the model's final text answer.
+	Text string
+	// Turns is the number of model calls made.
+	Turns int
+	// Contents is the full transcript: the seeded turns, the model's
+	// tool calls and the tool responses.
+	Contents []gemini.Content
+}
+
+// RunToolLoop drives a complete tool-calling conversation: it sends the
+// seeded contents, dispatches every function call the model makes to
+// the matching tool, feeds the responses back, and repeats until the
+// model answers with text. Unknown tools and tool failures are reported
+// to the model as error responses so it can correct itself; only
+// backend errors abort the loop. Executors that do not need the full
+// Executor pipeline — validation, policies, artifact emission — can
+// build on it directly.
+func RunToolLoop(ctx context.Context, llm LLMClient, toolset []tools.Tool, opts ToolLoopOptions) (*ToolLoopResult, error) {
+	var decls []*tools.Declaration
+	for _, tool := range toolset {
+		decls = append(decls, tool.Declaration())
+	}
+	req := &gemini.GenerateRequest{
+		SystemInstruction: opts.SystemInstruction,
+		Contents:          opts.Contents,
+	}
+	if len(decls) > 0 {
+		req.Tools = []gemini.Tool{{FunctionDeclarations: decls}}
+	}
+
+	guard := newLoopGuard(Config{MaxToolTurns: opts.MaxTurns, MaxRepeatedToolCalls: opts.MaxRepeatedCalls})
+	result := &ToolLoopResult{}
+	for {
+		if guard.turn() {
+			return nil, ErrToolLoopLimit
+		}
+		resp, err := llm.GenerateContent(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("agent: tool loop turn %d: %w", result.Turns+1, err)
+		}
+		result.Turns++
+		calls := resp.FunctionCalls()
+		if len(calls) == 0 {
+			result.Text = resp.Text()
+			result.Contents = req.Contents
+			return result, nil
+		}
+		if guard.record(calls) {
+			return nil, ErrToolLoopStuck
+		}
+
+		req.Contents = append(req.Contents, resp.Candidates[0].Content)
+		var responses []gemini.Part
+		for _, call := range calls {
+			if opts.OnCall != nil {
+				opts.OnCall(call)
+			}
+			responses = append(responses, gemini.Part{
+				FunctionResponse: &gemini.FunctionResponse{
+					Name:     call.Name,
+					Response: dispatchToolCall(ctx, toolset, call),
+				},
+			})
+		}
+		req.Contents = append(req.Contents, gemini.Content{Role: gemini.RoleUser, Parts: responses})
+	}
+}
+
+// dispatchToolCall routes one function call to the matching tool,
+// returning an error response for unknown tools, invalid arguments and
+// failed runs.
+func dispatchToolCall(ctx context.Context, toolset []tools.Tool, call *gemini.FunctionCall) map[string]any {
+	for _, tool := range toolset {
+		if tool.Declaration().Name != call.Name {
+			continue
+		}
+		args, err := tools.ResolveArgs(tool.Declaration(), call.Args)
+		if err != nil {
+			slog.Warn("tool call has invalid arguments", "tool", call.Name, "error", err)
+			return map[string]any{toolErrorKey: err.Error()}
+		}
+		result, err := tool.Run(ctx, args)
+		if err != nil {
+			slog.Warn("tool failed", "tool", call.Name, "error", err)
+			return map[string]any{toolErrorKey: err.Error()}
+		}
+		if err := tools.ValidateResult(tool.Declaration(), result); err != nil {
+			slog.Warn("tool returned a malformed result", "tool", call.Name, "error", err)
+			return map[string]any{toolErrorKey: err.Error()}
+		}
+		return result
+	}
+	return map[string]any{toolErrorKey: fmt.Sprintf("unknown tool %q", call.Name)}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+	"github.com/google/A2UI/a2a_agents/go/tools"
+)
+
+// scriptTool is a tools.Tool with a canned result or error.
+type scriptTool struct {
+	name   string
+	result map[string]any
+	err    error
+	calls  int
+}
+
+func (t *scriptTool) Declaration() *tools.Declaration {
+	return &tools.Declaration{Name: t.name, Description: "test tool"}
+}
+
+func (t *scriptTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	t.calls++
+	return t.result, t.err
+}
+
+func callResponse(names ...string) *gemini.GenerateResponse {
+	var parts []gemini.Part
+	for _, name := range names {
+		parts = append(parts, gemini.Part{FunctionCall: &gemini.FunctionCall{Name: name}})
+	}
+	return &gemini.GenerateResponse{Candidates: []gemini.Candidate{{
+		Content: gemini.Content{Role: "model", Parts: parts},
+	}}}
+}
+
+func textResponse(text string) *gemini.GenerateResponse {
+	return &gemini.GenerateResponse{Candidates: []gemini.Candidate{{
+		Content: gemini.Content{Role: "model", Parts: []gemini.Part{{Text: text}}},
+	}}}
+}
+
+func TestRunToolLoopParallelCalls(t *testing.T) {
+	weather := &scriptTool{name: "weather", result: map[string]any{"temp": 21}}
+	news := &scriptTool{name: "news", result: map[string]any{"headline": "ok"}}
+	llm := &fakeLLM{responses: []*gemini.GenerateResponse{
+		callResponse("weather", "news"),
+		textResponse("Sunny, and the news is good."),
+	}}
+
+	result, err := RunToolLoop(context.Background(), llm, []tools.Tool{weather, news}, ToolLoopOptions{
+		Contents: []gemini.Content{{Role: "user", Parts: []gemini.Part{{Text: "brief me"}}}},
+	})
+	if err != nil {
+		t.Fatalf("RunToolLoop: %v", err)
+	}
+	if weather.calls != 1 || news.calls != 1 {
+		t.Errorf("tool calls = %d/%d, want both dispatched once", weather.calls, news.calls)
+	}
+	if result.Turns != 2 || result.Text != "Sunny, and the news is good." {
+		t.Errorf("result = %d turns, %q", result.Turns, result.Text)
+	}
+
+	// Both responses ride back in one user turn, in call order.
+	feedback := llm.requests[1].Contents
+	last := feedback[len(feedback)-1]
+	if last.Role != "user" || len(last.Parts) != 2 {
+		t.Fatalf("tool responses = %+v, want one user turn with two parts", last)
+	}
+	if last.Parts[0].FunctionResponse.Name != "weather" || last.Parts[1].FunctionResponse.Name != "news" {
+		t.Errorf("response order = %s, %s", last.Parts[0].FunctionResponse.Name, last.Parts[1].FunctionResponse.Name)
+	}
+}
+
+func TestRunToolLoopUnknownTool(t *testing.T) {
+	llm := &fakeLLM{responses: []*gemini.GenerateResponse{
+		callResponse("no_such_tool"),
+		textResponse("Sorry."),
+	}}
+	result, err := RunToolLoop(context.Background(), llm, nil, ToolLoopOptions{})
+	if err != nil {
+		t.Fatalf("RunToolLoop: %v", err)
+	}
+	if result.Text != "Sorry." {
+		t.Errorf("Text = %q", result.Text)
+	}
+	last := llm.requests[1].Contents[len(llm.requests[1].Contents)-1]
+	response := last.Parts[0].FunctionResponse.Response
+	if msg, _ := response[toolErrorKey].(string); !strings.Contains(msg, "no_such_tool") {
+		t.Errorf("unknown tool response = %v, want an error naming the tool", response)
+	}
+}
+
+func TestRunToolLoopToolError(t *testing.T) {
+	failing := &scriptTool{name: "flaky", err: errors.New("backend down")}
+	llm := &fakeLLM{responses: []*gemini.GenerateResponse{
+		callResponse("flaky"),
+		textResponse("I could not fetch the data."),
+	}}
+	result, err := RunToolLoop(context.Background(), llm, []tools.Tool{failing}, ToolLoopOptions{})
+	if err != nil {
+		t.Fatalf("RunToolLoop: %v", err)
+	}
+	if result.Turns != 2 {
+		t.Errorf("Turns = %d, want the loop to continue past the tool error", result.Turns)
+	}
+	last := llm.requests[1].Contents[len(llm.requests[1].Contents)-1]
+	if msg, _ := last.Parts[0].FunctionResponse.Response[toolErrorKey].(string); msg != "backend down" {
+		t.Errorf("tool error response = %q", msg)
+	}
+}
+
+func TestRunToolLoopBackendError(t *testing.T) {
+	llm := &failingLLM{err: errors.New("quota exhausted")}
+	if _, err := RunToolLoop(context.Background(), llm, nil, ToolLoopOptions{}); err == nil || !strings.Contains(err.Error(), "quota exhausted") {
+		t.Fatalf("RunToolLoop = %v, want the backend error", err)
+	}
+}
+
+// failingLLM always errors.
+type failingLLM struct{ err error }
+
+func (f *failingLLM) GenerateContent(ctx context.Context, req *gemini.GenerateRequest) (*gemini.GenerateResponse, error) {
+	return nil, f.err
+}
+
+func (f *failingLLM) Model() string { return "failing-model" }
@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/tools"
+)
+
+// Function-response keys for invalid tool calls: alongside the error text
+// the model gets the missing argument names and the per-argument problems,
+// so it can correct the whole call in one retry.
+const (
+	missingArgsKey = "missing_arguments"
+	invalidArgsKey = "invalid_arguments"
+)
+
+// argumentErrorResponse builds the function response for a tool call that
+// failed argument resolution.
+func argumentErrorResponse(err error) map[string]any {
+	response := map[string]any{toolErrorKey: err.Error()}
+	var argErr *tools.ArgumentError
+	if !errors.As(err, &argErr) {
+		return response
+	}
+	if len(argErr.Missing) > 0 {
+		response[missingArgsKey] = argErr.Missing
+	}
+	if len(argErr.Invalid) > 0 {
+		response[invalidArgsKey] = argErr.Invalid
+	}
+	return response
+}
+
+// ToolArtifactName names artifacts carrying client-facing tool results.
+// Unlike A2UIArtifactName payloads these are not renderable surfaces;
+// clients decide how to present them.
+const ToolArtifactName = "tool_result"
+
+// runTool invokes a configured tool, collecting the client parts its
+// typed result reported when the tool supports them.
+func runTool(ctx context.Context, tool tools.Tool, args map[string]any) (map[string]any, []tools.ClientPart, error) {
+	if reporter, ok := tool.(tools.PartReporter); ok {
+		return reporter.RunWithParts(ctx, args)
+	}
+	result, err := tool.Run(ctx, args)
+	return result, nil, err
+}
+
+// invokeTool runs one configured tool, wiring the optional extensions:
+// streaming tools get their incremental results emitted as
+// dataModelUpdates, long-running tools get a progress callback surfaced
+// as non-final working statuses, and typed results get their client
+// parts collected.
+func (e *Executor) invokeTool(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue, tool tools.Tool, args map[string]any) (map[string]any, []tools.ClientPart, error) {
+	if streamer, ok := tool.(tools.Streamer); ok {
+		result, err := e.runStreaming(ctx, rc, queue, streamer, args)
+		return result, nil, err
+	}
+	if reporter, ok := tool.(tools.ProgressReporter); ok {
+		result, err := reporter.RunWithProgress(ctx, args, e.progressFunc(rc, queue))
+		return result, nil, err
+	}
+	return runTool(ctx, tool, args)
+}
+
+// runStreaming drains a streaming tool's updates while it works, emitting
+// each one as a dataModelUpdate so the surface fills in as rows arrive.
+func (e *Executor) runStreaming(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue, streamer tools.Streamer, args map[string]any) (map[string]any, error) {
+	updates := make(chan tools.DataModelUpdate, 16)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for update := range updates {
+			msg, err := a2ui.DataModelUpdateMessage(update.SurfaceID, update.Path, update.Value)
+			if err != nil {
+				slog.Warn("dropping malformed streamed update",
+					"tool", streamer.Declaration().Name, "error", err)
+				continue
+			}
+			event := &a2a.ArtifactUpdateEvent{
+				TaskID:    rc.TaskID,
+				ContextID: rc.ContextID,
+				Artifact: a2a.Artifact{
+					ArtifactID: a2a.NewID(),
+					Name:       A2UIArtifactName,
+					Parts:      []a2a.Part{a2ui.NewPart(msg)},
+				},
+			}
+			if err := queue.Write(event); err != nil {
+				slog.Warn("dropping streamed update", "task", rc.TaskID, "error", err)
+			}
+		}
+	}()
+	result, err := streamer.RunStreaming(ctx, args, updates)
+	close(updates)
+	<-done
+	return result, err
+}
+
+// progressFunc adapts a tool's progress updates into working-status
+// messages. Delivery failures are logged, not surfaced: progress is
+// advisory and must not fail the tool run.
+func (e *Executor) progressFunc(rc *a2a.RequestContext, queue *a2a.EventQueue) tools.ProgressFunc {
+	return func(message string, fraction float64) {
+		if fraction > 0 {
+			message = fmt.Sprintf("%s (%d%%)", message, int(fraction*100))
+		}
+		if err := e.status(rc, queue, a2a.TaskStateWorking, agentMessage(rc, message), false); err != nil {
+			slog.Warn("dropping tool progress update", "task", rc.TaskID, "error", err)
+		}
+	}
+}
+
+// toolResultParts maps reported client parts onto A2A parts: data becomes
+// a DataPart, text a TextPart.
+func toolResultParts(reported []tools.ClientPart) []a2a.Part {
+	parts := make([]a2a.Part, 0, len(reported))
+	for _, part := range reported {
+		if part.Data != nil {
+			parts = append(parts, a2a.NewDataPart(part.Data, nil))
+			continue
+		}
+		parts = append(parts, a2a.NewTextPart(part.Text))
+	}
+	return parts
+}
+
+// emitToolParts delivers a tool's client parts as a tool_result artifact.
+func (e *Executor) emitToolParts(rc *a2a.RequestContext, queue *a2a.EventQueue, reported []tools.ClientPart) error {
+	event := &a2a.ArtifactUpdateEvent{
+		TaskID:    rc.TaskID,
+		ContextID: rc.ContextID,
+		Artifact: a2a.Artifact{
+			ArtifactID: a2a.NewID(),
+			Name:       ToolArtifactName,
+			Parts:      toolResultParts(reported),
+		},
+	}
+	if err := queue.Write(event); err != nil {
+		return fmt.Errorf("emitting tool result artifact: %w", err)
+	}
+	return nil
+}
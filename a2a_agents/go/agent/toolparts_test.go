@@ -0,0 +1,271 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+	"github.com/google/A2UI/a2a_agents/go/tools"
+)
+
+// reportingTool reports a client part alongside its model response.
+type reportingTool struct{}
+
+func (reportingTool) Declaration() *tools.Declaration {
+	return &tools.Declaration{Name: "get_chart", Parameters: &tools.Schema{Type: "object"}}
+}
+
+func (reportingTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	return map[string]any{"total": 42.0}, nil
+}
+
+func (reportingTool) RunWithParts(ctx context.Context, args map[string]any) (map[string]any, []tools.ClientPart, error) {
+	return map[string]any{"total": 42.0}, []tools.ClientPart{
+		{Data: map[string]any{"total": 42.0}},
+		{Text: "chart ready"},
+	}, nil
+}
+
+func TestDispatchEmitsToolParts(t *testing.T) {
+	e, err := New(Config{Schema: testSchema, Tools: []tools.Tool{reportingTool{}}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2uiRequestContext("task1")
+	queue := a2a.NewEventQueue(4)
+
+	result := e.dispatch(context.Background(), rc, queue, &gemini.FunctionCall{Name: "get_chart"})
+	if result["total"] != 42.0 {
+		t.Errorf("model response = %v", result)
+	}
+
+	queue.Close()
+	event, ok := queue.Read()
+	if !ok {
+		t.Fatal("no artifact emitted")
+	}
+	artifact, ok := event.(*a2a.ArtifactUpdateEvent)
+	if !ok {
+		t.Fatalf("event is a %T, want ArtifactUpdateEvent", event)
+	}
+	if artifact.Artifact.Name != ToolArtifactName {
+		t.Errorf("artifact name = %q, want %q", artifact.Artifact.Name, ToolArtifactName)
+	}
+	parts := artifact.Artifact.Parts
+	if len(parts) != 2 {
+		t.Fatalf("got %d parts, want 2", len(parts))
+	}
+	if parts[0].Kind != "data" || parts[0].Data["total"] != 42.0 {
+		t.Errorf("data part = %+v", parts[0])
+	}
+	if parts[1].Kind != "text" || parts[1].Text != "chart ready" {
+		t.Errorf("text part = %+v", parts[1])
+	}
+	if _, ok := queue.Read(); ok {
+		t.Error("unexpected extra event")
+	}
+}
+
+func TestRunToolPlainFallback(t *testing.T) {
+	// runTool falls back to Run for tools without RunWithParts.
+	result, parts, err := runTool(context.Background(), plainTool{}, nil)
+	if err != nil {
+		t.Fatalf("runTool: %v", err)
+	}
+	if result["ok"] != true || parts != nil {
+		t.Errorf("runTool = %v, %v", result, parts)
+	}
+}
+
+func TestDispatchArgumentErrors(t *testing.T) {
+	e, err := New(Config{Schema: testSchema, Tools: []tools.Tool{strictTool{}}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	queue := a2a.NewEventQueue(4)
+
+	// A call missing a required argument never reaches Run; the model gets
+	// the structured problem list.
+	result := e.dispatch(context.Background(), a2uiRequestContext("task1"), queue,
+		&gemini.FunctionCall{Name: "get_report"})
+	if result["error"] == nil {
+		t.Fatalf("result = %v, want an error", result)
+	}
+	missing, _ := result[missingArgsKey].([]string)
+	if len(missing) != 1 || missing[0] != "region" {
+		t.Errorf("%s = %v, want [region]", missingArgsKey, result[missingArgsKey])
+	}
+
+	// A valid call has defaults injected before Run.
+	result = e.dispatch(context.Background(), a2uiRequestContext("task1"), queue,
+		&gemini.FunctionCall{Name: "get_report", Args: map[string]any{"region": "emea"}})
+	if result["period"] != "year" {
+		t.Errorf("period = %v, want the injected default", result["period"])
+	}
+}
+
+// strictTool declares a required argument and a default, and echoes the
+// arguments it was invoked with.
+type strictTool struct{}
+
+func (strictTool) Declaration() *tools.Declaration {
+	return &tools.Declaration{
+		Name: "get_report",
+		Parameters: &tools.Schema{
+			Type: "object",
+			Properties: map[string]*tools.Schema{
+				"region": {Type: "string"},
+				"period": {Type: "string", Default: "year"},
+			},
+			Required: []string{"region"},
+		},
+	}
+}
+
+func (strictTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	return args, nil
+}
+
+// slowTool reports progress twice before returning.
+type slowTool struct{}
+
+func (slowTool) Declaration() *tools.Declaration {
+	return &tools.Declaration{Name: "slow_query", Parameters: &tools.Schema{Type: "object"}}
+}
+
+func (slowTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	return map[string]any{"done": true}, nil
+}
+
+func (slowTool) RunWithProgress(ctx context.Context, args map[string]any, progress tools.ProgressFunc) (map[string]any, error) {
+	progress("Scanning rows", 0.4)
+	progress("Aggregating", 0)
+	return map[string]any{"done": true}, nil
+}
+
+func TestDispatchReportsProgress(t *testing.T) {
+	e, err := New(Config{Schema: testSchema, Tools: []tools.Tool{slowTool{}}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2uiRequestContext("task1")
+	queue := a2a.NewEventQueue(8)
+
+	result := e.dispatch(context.Background(), rc, queue, &gemini.FunctionCall{Name: "slow_query"})
+	if result["done"] != true {
+		t.Errorf("model response = %v", result)
+	}
+
+	queue.Close()
+	var texts []string
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		status, ok := event.(*a2a.StatusUpdateEvent)
+		if !ok {
+			t.Fatalf("event is a %T, want StatusUpdateEvent", event)
+		}
+		if status.Status.State != a2a.TaskStateWorking || status.Final {
+			t.Errorf("progress status = %+v, want non-final working", status)
+		}
+		texts = append(texts, messageText(status.Status.Message))
+	}
+	if len(texts) != 2 {
+		t.Fatalf("got %d progress updates, want 2: %v", len(texts), texts)
+	}
+	if texts[0] != "Scanning rows (40%)" {
+		t.Errorf("first update = %q, want the fraction rendered", texts[0])
+	}
+	if texts[1] != "Aggregating" {
+		t.Errorf("second update = %q, want no percentage", texts[1])
+	}
+}
+
+// streamingTool streams two rows into a surface before returning.
+type streamingTool struct{}
+
+func (streamingTool) Declaration() *tools.Declaration {
+	return &tools.Declaration{Name: "stream_rows", Parameters: &tools.Schema{Type: "object"}}
+}
+
+func (streamingTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	return map[string]any{"rows": 2.0}, nil
+}
+
+func (streamingTool) RunStreaming(ctx context.Context, args map[string]any, updates chan<- tools.DataModelUpdate) (map[string]any, error) {
+	updates <- tools.DataModelUpdate{SurfaceID: "chart", Path: "/sales/apparel", Value: 41.0}
+	updates <- tools.DataModelUpdate{SurfaceID: "chart", Path: "/sales/home", Value: 15.0}
+	return map[string]any{"rows": 2.0}, nil
+}
+
+func TestDispatchStreamsDataModelUpdates(t *testing.T) {
+	e, err := New(Config{Schema: testSchema, Tools: []tools.Tool{streamingTool{}}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rc := a2uiRequestContext("task1")
+	queue := a2a.NewEventQueue(8)
+
+	result := e.dispatch(context.Background(), rc, queue, &gemini.FunctionCall{Name: "stream_rows"})
+	if result["rows"] != 2.0 {
+		t.Errorf("model response = %v", result)
+	}
+
+	queue.Close()
+	var paths []string
+	for {
+		event, ok := queue.Read()
+		if !ok {
+			break
+		}
+		artifact, ok := event.(*a2a.ArtifactUpdateEvent)
+		if !ok {
+			t.Fatalf("event is a %T, want ArtifactUpdateEvent", event)
+		}
+		if artifact.Artifact.Name != A2UIArtifactName {
+			t.Errorf("artifact name = %q, want %q", artifact.Artifact.Name, A2UIArtifactName)
+		}
+		for _, part := range artifact.Artifact.Parts {
+			update, _ := part.Data["dataModelUpdate"].(map[string]any)
+			if update == nil {
+				t.Fatalf("part is not a dataModelUpdate: %+v", part)
+			}
+			if update["surfaceId"] != "chart" {
+				t.Errorf("surfaceId = %v, want chart", update["surfaceId"])
+			}
+			path, _ := update["path"].(string)
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) != 2 || paths[0] != "/sales" || paths[1] != "/sales" {
+		t.Errorf("paths = %v, want two updates under /sales", paths)
+	}
+}
+
+// plainTool implements only the base Tool interface.
+type plainTool struct{}
+
+func (plainTool) Declaration() *tools.Declaration {
+	return &tools.Declaration{Name: "plain", Parameters: &tools.Schema{Type: "object"}}
+}
+
+func (plainTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	return map[string]any{"ok": true}, nil
+}
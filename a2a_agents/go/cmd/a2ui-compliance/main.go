@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// a2ui-compliance runs the spec-derived compliance battery — valid and
+// invalid payloads against the merged standard schema, extension
+// negotiation scenarios — and prints a machine-readable JSON report, so
+// this implementation can be compared against others (e.g. the Python
+// library) vector by vector.
+//
+// Usage:
+//
+//	a2ui-compliance [-endpoint http://localhost:10002]
+//
+// With -endpoint, the agent's card is probed too: it must be served at
+// the well-known path and declare the A2UI extension. The exit code is 0
+// when every vector passed and 1 otherwise.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+func main() {
+	endpoint := flag.String("endpoint", "", "agent base URL to probe in addition to the library checks")
+	flag.Parse()
+
+	report, err := a2ui.RunCompliance()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *endpoint != "" {
+		for _, result := range probeEndpoint(*endpoint) {
+			if result.Passed {
+				report.Passed++
+			} else {
+				report.Failed++
+			}
+			report.Results = append(report.Results, result)
+		}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatal(err)
+	}
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// probeEndpoint checks a live agent: the card must be served at the
+// well-known path and declare the A2UI extension.
+func probeEndpoint(endpoint string) []a2ui.ComplianceResult {
+	const kind = "endpoint"
+	client := &http.Client{Timeout: 10 * time.Second}
+	url := strings.TrimSuffix(endpoint, "/") + a2a.AgentCardPath
+	served := a2ui.ComplianceResult{Name: "endpoint-card-served", Kind: kind}
+	declares := a2ui.ComplianceResult{Name: "endpoint-card-declares-a2ui", Kind: kind}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		served.Detail = err.Error()
+		declares.Detail = "card not available"
+		return []a2ui.ComplianceResult{served, declares}
+	}
+	defer resp.Body.Close()
+	var card a2a.AgentCard
+	if resp.StatusCode != http.StatusOK {
+		served.Detail = fmt.Sprintf("GET %s: %s", url, resp.Status)
+	} else if err := json.NewDecoder(resp.Body).Decode(&card); err != nil {
+		served.Detail = fmt.Sprintf("decoding card: %v", err)
+	} else {
+		served.Passed = true
+	}
+	if !served.Passed {
+		declares.Detail = "card not available"
+		return []a2ui.ComplianceResult{served, declares}
+	}
+
+	for _, extension := range card.Capabilities.Extensions {
+		if extension.URI == a2ui.ExtensionURI {
+			declares.Passed = true
+			break
+		}
+	}
+	if !declares.Passed {
+		declares.Detail = fmt.Sprintf("card does not declare %s", a2ui.ExtensionURI)
+	}
+	return []a2ui.ComplianceResult{served, declares}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// a2ui-diff structurally compares two A2UI payloads, printing the
+// components and data-model paths that were added, removed or changed —
+// handy when debugging why a prompt tweak altered the generated UI.
+//
+// Usage:
+//
+//	a2ui-diff before.json after.json
+//
+// Each input is a JSON array of A2UI messages in emission order ("-"
+// for stdin), as produced by a2ui-replay. The exit code is 0 when the
+// payloads are structurally equal and 1 when they differ.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: a2ui-diff before.json after.json")
+		os.Exit(2)
+	}
+
+	before, err := readPayload(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	after, err := readPayload(flag.Arg(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	changes := a2ui.DiffPayloads(before, after)
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+	if len(changes) > 0 {
+		os.Exit(1)
+	}
+}
+
+func readPayload(path string) ([]map[string]any, error) {
+	raw, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var messages []map[string]any
+	if err := json.Unmarshal(raw, &messages); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return messages, nil
+}
+
+func readFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// a2ui-init scaffolds a new Go A2UI agent: server wiring, executor setup,
+// catalog registration, an example data tool, a smoke test and a
+// Dockerfile, so a new agent does not start as a copy-paste of the
+// rizzcharts sample.
+//
+// Usage:
+//
+//	a2ui-init -name weather
+//	a2ui-init -name weather -module github.com/example/weather -catalogs https://example.com/catalog.json
+//
+// Files are written to a new directory named after the agent; the command
+// refuses to overwrite existing files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// namePattern is the shape of a valid agent name: it becomes a directory
+// name and part of the default module path.
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// templateData parameterizes the generated files.
+type templateData struct {
+	// Name is the agent name, e.g. "weather".
+	Name string
+	// Title is the agent name in title case for the agent card.
+	Title string
+	// Module is the Go module path of the generated agent.
+	Module string
+	// A2UIModule is the module path of this library.
+	A2UIModule string
+	// Catalogs are extra catalog IDs the agent advertises alongside the
+	// standard catalog.
+	Catalogs []string
+}
+
+func main() {
+	name := flag.String("name", "", "agent name, e.g. weather (required)")
+	dir := flag.String("dir", "", "output directory (default: the agent name)")
+	module := flag.String("module", "", "Go module path (default: example.com/<name>)")
+	catalogs := flag.String("catalogs", "", "comma-separated extra catalog IDs to advertise")
+	flag.Parse()
+
+	if *name == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if !namePattern.MatchString(*name) {
+		log.Fatalf("invalid agent name %q: use lowercase letters, digits and dashes", *name)
+	}
+	if *dir == "" {
+		*dir = *name
+	}
+	if *module == "" {
+		*module = "example.com/" + *name
+	}
+	data := &templateData{
+		Name:       *name,
+		Title:      strings.ToUpper((*name)[:1]) + (*name)[1:],
+		Module:     *module,
+		A2UIModule: "github.com/google/A2UI/a2a_agents/go",
+	}
+	for _, id := range strings.Split(*catalogs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			data.Catalogs = append(data.Catalogs, id)
+		}
+	}
+
+	if err := scaffold(*dir, data); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("scaffolded agent %q in %s\n\nnext steps:\n", *name, *dir)
+	fmt.Printf("  cd %s\n  go mod tidy\n  GEMINI_API_KEY=... go run .\n", *dir)
+}
+
+// scaffold writes every generated file, failing before the first write if
+// any of them already exists.
+func scaffold(dir string, data *templateData) error {
+	files := map[string]string{
+		"go.mod":          goModTemplate,
+		"main.go":         mainTemplate,
+		"instructions.go": instructionsTemplate,
+		"tools.go":        toolsTemplate,
+		"main_test.go":    testTemplate,
+		"Dockerfile":      dockerfileTemplate,
+	}
+	for name := range files {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return fmt.Errorf("refusing to overwrite existing %s", filepath.Join(dir, name))
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for name, text := range files {
+		tmpl, err := template.New(name).Parse(text)
+		if err != nil {
+			return fmt.Errorf("parsing %s template: %w", name, err)
+		}
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		err = tmpl.Execute(f, data)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+	}
+	return nil
+}
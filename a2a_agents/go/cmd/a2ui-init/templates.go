@@ -0,0 +1,195 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+// The templates below are the generated agent, parameterized by
+// templateData. They mirror the structure of the rizzcharts sample with
+// the domain specifics stripped out.
+
+const goModTemplate = `module {{.Module}}
+
+go 1.21
+
+require {{.A2UIModule}} v0.0.0
+
+// Run ` + "`go mod tidy`" + ` to resolve the A2UI dependency, or point it at a
+// local checkout:
+//
+//	replace {{.A2UIModule}} => ../A2UI/a2a_agents/go
+`
+
+const mainTemplate = `// {{.Name}} is an A2UI agent serving the A2A protocol.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"{{.A2UIModule}}/a2a"
+	"{{.A2UIModule}}/a2ui"
+	"{{.A2UIModule}}/agent"
+	"{{.A2UIModule}}/gemini"
+	"{{.A2UIModule}}/tools"
+)
+
+func main() {
+	host := flag.String("host", "localhost", "host to listen on")
+	port := flag.Int("port", 10002, "port to listen on")
+	model := flag.String("model", "gemini-2.5-flash", "Gemini model to use")
+	flag.Parse()
+
+	apiKey := os.Getenv("GEMINI_API_KEY")
+	if apiKey == "" {
+		log.Fatal("GEMINI_API_KEY environment variable not set")
+	}
+
+	// nil loads the standard catalog schema; register a custom catalog here
+	// when the agent grows its own components.
+	schema, err := a2ui.LoadA2UISchema(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	executor, err := agent.New(agent.Config{
+		Client:       gemini.NewClient(apiKey, *model),
+		Instructions: instructions,
+		Schema:       schema,
+		Tools:        []tools.Tool{exampleDataTool{}},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	card := a2a.AgentCard{
+		Name:        "{{.Title}} Agent",
+		Description: "An A2UI agent.",
+		URL:         fmt.Sprintf("http://%s:%d/", *host, *port),
+		Version:     "0.1.0",
+		Capabilities: a2a.AgentCapabilities{
+			Streaming: true,
+			Extensions: []a2a.AgentExtension{
+				a2ui.AgentExtension(false, []string{
+					a2ui.StandardCatalogID,{{range .Catalogs}}
+					"{{.}}",{{end}}
+				}),
+			},
+		},
+	}
+
+	server := a2a.NewServer(card, executor)
+	addr := fmt.Sprintf("%s:%d", *host, *port)
+	log.Printf("{{.Name}} agent listening on %s", addr)
+	log.Fatal(http.ListenAndServe(addr, server))
+}
+`
+
+const instructionsTemplate = `package main
+
+// instructions is the agent's system prompt. The A2UI schema block is
+// appended automatically by the executor.
+const instructions = ` + "`" + `### System Instructions
+
+You are the {{.Title}} agent. Translate user requests into A2UI JSON
+payloads and send them with the send_a2ui_json_to_client tool. Fetch data
+with the get_example_data tool, build a surface from standard catalog
+components (Card, Column, Text, List, ...), and include a brief text
+message describing what you rendered.
+` + "`" + `
+`
+
+const toolsTemplate = `package main
+
+import (
+	"context"
+	"log/slog"
+
+	"{{.A2UIModule}}/tools"
+)
+
+// exampleDataTool returns canned data; replace it with the agent's real
+// data sources.
+type exampleDataTool struct{}
+
+func (exampleDataTool) Declaration() *tools.Declaration {
+	return &tools.Declaration{
+		Name:        "get_example_data",
+		Description: "Gets example data items to display.",
+		Parameters: &tools.Schema{
+			Type: "object",
+			Properties: map[string]*tools.Schema{
+				"query": {
+					Type:        "string",
+					Description: "What data the user asked for.",
+				},
+			},
+		},
+	}
+}
+
+func (exampleDataTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	slog.Info("get_example_data called", "args", args)
+	return map[string]any{
+		"items": []any{
+			map[string]any{"label": "First", "value": 1},
+			map[string]any{"label": "Second", "value": 2},
+		},
+	}, nil
+}
+`
+
+const testTemplate = `package main
+
+import (
+	"testing"
+
+	"{{.A2UIModule}}/a2ui"
+	"{{.A2UIModule}}/agent"
+	"{{.A2UIModule}}/tools"
+)
+
+// TestExecutorConfig compiles the agent's schema and config the same way
+// main does, so a broken catalog or tool declaration fails in CI instead
+// of at boot.
+func TestExecutorConfig(t *testing.T) {
+	schema, err := a2ui.LoadA2UISchema(nil)
+	if err != nil {
+		t.Fatalf("LoadA2UISchema: %v", err)
+	}
+	if _, err := agent.New(agent.Config{
+		Instructions: instructions,
+		Schema:       schema,
+		Tools:        []tools.Tool{exampleDataTool{}},
+	}); err != nil {
+		t.Fatalf("agent.New: %v", err)
+	}
+	if (exampleDataTool{}).Declaration().Name == "" {
+		t.Error("tool declaration has no name")
+	}
+}
+`
+
+const dockerfileTemplate = `FROM golang:1.21 AS build
+WORKDIR /src
+COPY . .
+RUN go mod tidy && CGO_ENABLED=0 go build -o /bin/{{.Name}} .
+
+FROM gcr.io/distroless/static-debian12
+COPY --from=build /bin/{{.Name}} /{{.Name}}
+EXPOSE 10002
+ENTRYPOINT ["/{{.Name}}", "-host", "0.0.0.0"]
+`
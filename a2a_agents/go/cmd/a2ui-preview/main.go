@@ -0,0 +1,186 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// a2ui-preview serves a directory of A2UI payload JSON files as rendered
+// HTML with live reload, so catalog and prompt authors can iterate on
+// surfaces without a running agent or LLM.
+//
+// Usage:
+//
+//	a2ui-preview -dir ./payloads -addr localhost:8321
+//
+// Each .json file in the directory holds one payload: a JSON array of
+// A2UI messages, or a single message object. Saving a file reloads any
+// browser tab viewing it.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/A2UI/a2a_agents/go/render"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of payload JSON files to serve")
+	addr := flag.String("addr", "localhost:8321", "address to listen on")
+	flag.Parse()
+
+	srv := &previewServer{dir: *dir}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", srv.handleIndex)
+	mux.HandleFunc("/view", srv.handleView)
+	mux.HandleFunc("/mtime", srv.handleMtime)
+	log.Printf("previewing %s on http://%s", *dir, *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+// previewServer renders payload files from one directory.
+type previewServer struct {
+	dir string
+}
+
+// payloadFile resolves a requested file name inside the served directory,
+// rejecting path traversal and non-JSON files.
+func (s *previewServer) payloadFile(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || !strings.HasSuffix(name, ".json") {
+		return "", fmt.Errorf("invalid payload file %q", name)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+func (s *previewServer) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html><head><title>A2UI preview</title></head><body>\n<h1>A2UI preview</h1>\n<ul>\n")
+	for _, name := range names {
+		fmt.Fprintf(w, "<li><a href=\"/view?file=%s\">%s</a></li>\n",
+			url.QueryEscape(name), html.EscapeString(name))
+	}
+	if len(names) == 0 {
+		fmt.Fprintf(w, "<li>no .json payload files in %s</li>\n", html.EscapeString(s.dir))
+	}
+	fmt.Fprintf(w, "</ul>\n</body></html>\n")
+}
+
+func (s *previewServer) handleView(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("file")
+	path, err := s.payloadFile(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mtime := fileMtime(path)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	page, err := renderPayload(name, path)
+	if err != nil {
+		// Show the error in the page instead of a bare 500: with live
+		// reload, fixing the file replaces this view on the next save.
+		page = fmt.Sprintf("<!DOCTYPE html>\n<html><body><h1>%s</h1><pre>%s</pre></body></html>\n",
+			html.EscapeString(name), html.EscapeString(err.Error()))
+	}
+	page = strings.Replace(page, "</body>", reloadScript(name, mtime)+"</body>", 1)
+	fmt.Fprint(w, page)
+}
+
+func (s *previewServer) handleMtime(w http.ResponseWriter, r *http.Request) {
+	path, err := s.payloadFile(r.URL.Query().Get("file"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fmt.Fprintf(w, "%d", fileMtime(path))
+}
+
+// fileMtime returns the file's modification time in unix nanoseconds, or 0
+// when the file cannot be statted — deleting and recreating a file still
+// changes the value, which is all live reload needs.
+func fileMtime(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.ModTime().UnixNano()
+}
+
+// renderPayload reads one payload file and renders its surfaces to an HTML
+// page.
+func renderPayload(name, path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	var payload any
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return "", fmt.Errorf("parsing %s: %w", name, err)
+	}
+	list, ok := payload.([]any)
+	if !ok {
+		list = []any{payload}
+	}
+	messages := make([]map[string]any, len(list))
+	for i, elem := range list {
+		messages[i], ok = elem.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("%s: payload element %d is not an object", name, i)
+		}
+	}
+	surfaces := render.BuildSurfaces(messages)
+	var buf bytes.Buffer
+	if err := render.WriteHTMLBundle(name, surfaces, &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// reloadScript polls the file's mtime once a second and reloads the page
+// when it changes.
+func reloadScript(name string, mtime int64) string {
+	return fmt.Sprintf(`<script>
+(function() {
+  var since = %q;
+  setInterval(function() {
+    fetch("/mtime?file=%s").then(function(r) { return r.text(); }).then(function(t) {
+      if (t !== since) location.reload();
+    }).catch(function() {});
+  }, 1000);
+})();
+</script>
+`, fmt.Sprint(mtime), url.QueryEscape(name))
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// a2ui-replay extracts the A2UI surfaces from a stored task, so support
+// engineers can reproduce exactly what a user saw without rerunning the
+// LLM.
+//
+// Usage:
+//
+//	a2ui-replay -task task.json > surfaces.json
+//
+// The input is a JSON dump of an A2A task ("-" for stdin); the output is
+// the task's A2UI messages as a JSON array, in emission order.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+func main() {
+	taskFile := flag.String("task", "", `task JSON file to replay ("-" for stdin)`)
+	flag.Parse()
+	if *taskFile == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	raw, err := readTask(*taskFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var task a2a.Task
+	if err := json.Unmarshal(raw, &task); err != nil {
+		log.Fatalf("parsing task: %v", err)
+	}
+	if err := a2ui.ExportMessages(&task, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(os.Stderr, "replayed %d A2UI message(s) from task %s\n",
+		len(a2ui.TaskMessages(&task)), task.ID)
+}
+
+func readTask(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoRate is returned when a rates provider has no rate for a currency
+// pair.
+var ErrNoRate = errors.New("format: no exchange rate for currency pair")
+
+// RatesProvider supplies exchange rates. Implementations may hit a live
+// rates API, a cache, or a fixed table; they return the multiplier that
+// converts an amount in from-currency to to-currency.
+type RatesProvider interface {
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// StaticRates is a fixed-rate provider keyed by "FROM/TO" pairs, e.g.
+// {"USD/EUR": 0.92}. The inverse of a listed pair is derived
+// automatically; identical currencies convert at 1.
+type StaticRates map[string]float64
+
+// Rate implements RatesProvider.
+func (r StaticRates) Rate(_ context.Context, from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return 1, nil
+	}
+	if rate, ok := r[from+"/"+to]; ok {
+		return rate, nil
+	}
+	if rate, ok := r[to+"/"+from]; ok && rate != 0 {
+		return 1 / rate, nil
+	}
+	return 0, fmt.Errorf("%w: %s/%s", ErrNoRate, from, to)
+}
+
+// Converter converts amounts into a display currency and formats them for
+// a locale, so multi-region dashboards show the user's currency rather
+// than whatever the data source used.
+type Converter struct {
+	// Provider supplies exchange rates.
+	Provider RatesProvider
+	// Currency is the ISO 4217 code amounts are converted to.
+	Currency string
+	// Locale controls the formatting of converted amounts.
+	Locale Locale
+}
+
+// Convert converts an amount from the given source currency into the
+// converter's display currency.
+func (c *Converter) Convert(ctx context.Context, amount float64, from string) (float64, error) {
+	rate, err := c.Provider.Rate(ctx, from, c.Currency)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
+
+// Format converts an amount from the source currency and formats it in
+// the display currency for the converter's locale.
+func (c *Converter) Format(ctx context.Context, amount float64, from string) (string, error) {
+	converted, err := c.Convert(ctx, amount, from)
+	if err != nil {
+		return "", err
+	}
+	return c.Locale.Currency(converted, c.Currency), nil
+}
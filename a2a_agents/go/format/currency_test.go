@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStaticRates(t *testing.T) {
+	rates := StaticRates{"USD/EUR": 0.5}
+	ctx := context.Background()
+
+	if rate, err := rates.Rate(ctx, "USD", "EUR"); err != nil || rate != 0.5 {
+		t.Errorf("USD/EUR = %v, %v", rate, err)
+	}
+	if rate, err := rates.Rate(ctx, "eur", "usd"); err != nil || rate != 2 {
+		t.Errorf("inverse EUR/USD = %v, %v", rate, err)
+	}
+	if rate, err := rates.Rate(ctx, "USD", "USD"); err != nil || rate != 1 {
+		t.Errorf("identity = %v, %v", rate, err)
+	}
+	if _, err := rates.Rate(ctx, "USD", "CHF"); !errors.Is(err, ErrNoRate) {
+		t.Errorf("missing pair error = %v, want ErrNoRate", err)
+	}
+}
+
+func TestConverterFormat(t *testing.T) {
+	c := &Converter{
+		Provider: StaticRates{"USD/EUR": 0.5},
+		Currency: "EUR",
+		Locale:   ParseLocale("de-DE"),
+	}
+	got, err := c.Format(context.Background(), 2469, "USD")
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	if got != "1.234,50 €" {
+		t.Errorf("Format = %q", got)
+	}
+	if _, err := c.Format(context.Background(), 1, "CHF"); !errors.Is(err, ErrNoRate) {
+		t.Errorf("missing rate error = %v, want ErrNoRate", err)
+	}
+}
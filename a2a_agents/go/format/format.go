@@ -0,0 +1,173 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package format provides locale-aware formatting for numbers, currencies
+// and dates going into A2UI data models, so agents stop baking en-US
+// strings into literalString values. It carries a small built-in locale
+// table rather than a full CLDR dependency; unknown locales fall back to
+// their base language, then to en-US.
+package format
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateOrder is the order of day, month and year in a formatted date.
+type DateOrder int
+
+const (
+	// MonthDayYear is e.g. 01/31/2026.
+	MonthDayYear DateOrder = iota
+	// DayMonthYear is e.g. 31/01/2026 or 31.01.2026.
+	DayMonthYear
+	// YearMonthDay is e.g. 2026/01/31.
+	YearMonthDay
+)
+
+// Locale holds the formatting rules for one locale.
+type Locale struct {
+	// Tag is the BCP 47 tag the locale was resolved from, e.g. "de-DE".
+	Tag string
+	// Decimal is the decimal separator.
+	Decimal string
+	// Group is the thousands separator.
+	Group string
+	// DateOrder is the field order in formatted dates.
+	DateOrder DateOrder
+	// DateSep separates the date fields.
+	DateSep string
+	// CurrencyAfter places the currency symbol after the amount
+	// ("12,50 €" rather than "€12.50").
+	CurrencyAfter bool
+}
+
+// locales is the built-in locale table, keyed by lowercase tag. Entries
+// exist for both full tags and bare languages so resolution can fall back.
+var locales = map[string]Locale{
+	"en":    {Tag: "en-US", Decimal: ".", Group: ",", DateOrder: MonthDayYear, DateSep: "/"},
+	"en-us": {Tag: "en-US", Decimal: ".", Group: ",", DateOrder: MonthDayYear, DateSep: "/"},
+	"en-gb": {Tag: "en-GB", Decimal: ".", Group: ",", DateOrder: DayMonthYear, DateSep: "/"},
+	"de":    {Tag: "de-DE", Decimal: ",", Group: ".", DateOrder: DayMonthYear, DateSep: ".", CurrencyAfter: true},
+	"de-de": {Tag: "de-DE", Decimal: ",", Group: ".", DateOrder: DayMonthYear, DateSep: ".", CurrencyAfter: true},
+	"fr":    {Tag: "fr-FR", Decimal: ",", Group: " ", DateOrder: DayMonthYear, DateSep: "/", CurrencyAfter: true},
+	"fr-fr": {Tag: "fr-FR", Decimal: ",", Group: " ", DateOrder: DayMonthYear, DateSep: "/", CurrencyAfter: true},
+	"es":    {Tag: "es-ES", Decimal: ",", Group: ".", DateOrder: DayMonthYear, DateSep: "/", CurrencyAfter: true},
+	"es-es": {Tag: "es-ES", Decimal: ",", Group: ".", DateOrder: DayMonthYear, DateSep: "/", CurrencyAfter: true},
+	"ja":    {Tag: "ja-JP", Decimal: ".", Group: ",", DateOrder: YearMonthDay, DateSep: "/"},
+	"ja-jp": {Tag: "ja-JP", Decimal: ".", Group: ",", DateOrder: YearMonthDay, DateSep: "/"},
+}
+
+// currencySymbols maps ISO 4217 codes to display symbols. Codes without an
+// entry format with the code itself ("CHF 12.50").
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"INR": "₹",
+	"KRW": "₩",
+}
+
+// zeroDecimalCurrencies are currencies conventionally formatted without
+// fractional digits.
+var zeroDecimalCurrencies = map[string]bool{"JPY": true, "KRW": true}
+
+// DefaultLocale is used when no locale can be resolved.
+var DefaultLocale = locales["en-us"]
+
+// ParseLocale resolves a BCP 47 tag to formatting rules: exact match
+// first, then the bare language, then DefaultLocale.
+func ParseLocale(tag string) Locale {
+	normalized := strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if l, ok := locales[normalized]; ok {
+		return l
+	}
+	if language, _, found := strings.Cut(normalized, "-"); found {
+		if l, ok := locales[language]; ok {
+			return l
+		}
+	}
+	return DefaultLocale
+}
+
+// Number formats v with the locale's separators and the given number of
+// decimal places.
+func (l Locale) Number(v float64, decimals int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	var groups []string
+	for len(whole) > 3 {
+		groups = append([]string{whole[len(whole)-3:]}, groups...)
+		whole = whole[:len(whole)-3]
+	}
+	groups = append([]string{whole}, groups...)
+	out := sign + strings.Join(groups, l.Group)
+	if frac != "" {
+		out += l.Decimal + frac
+	}
+	return out
+}
+
+// Currency formats an amount in the given ISO 4217 currency, placing the
+// symbol per the locale's convention.
+func (l Locale) Currency(v float64, code string) string {
+	code = strings.ToUpper(code)
+	decimals := 2
+	if zeroDecimalCurrencies[code] {
+		decimals = 0
+	}
+	amount := l.Number(v, decimals)
+	symbol, ok := currencySymbols[code]
+	if !ok {
+		return code + " " + amount
+	}
+	if l.CurrencyAfter {
+		return amount + " " + symbol
+	}
+	return symbol + amount
+}
+
+// Date formats the date portion of t in the locale's field order.
+func (l Locale) Date(t time.Time) string {
+	year, month, day := t.Date()
+	switch l.DateOrder {
+	case DayMonthYear:
+		return fmt.Sprintf("%02d%s%02d%s%04d", day, l.DateSep, int(month), l.DateSep, year)
+	case YearMonthDay:
+		return fmt.Sprintf("%04d%s%02d%s%02d", year, l.DateSep, int(month), l.DateSep, day)
+	default:
+		return fmt.Sprintf("%02d%s%02d%s%04d", int(month), l.DateSep, day, l.DateSep, year)
+	}
+}
+
+// DateTime formats t as the locale's date followed by a 24-hour time.
+func (l Locale) DateTime(t time.Time) string {
+	return l.Date(t) + " " + t.Format("15:04")
+}
+
+// Percent formats a ratio (0.42 -> "42%") with the given decimal places.
+func (l Locale) Percent(ratio float64, decimals int) string {
+	return l.Number(ratio*100, decimals) + "%"
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"de-DE", "de-DE"},
+		{"de_DE", "de-DE"},
+		{"de-AT", "de-DE"},
+		{"en-GB", "en-GB"},
+		{"xx-YY", "en-US"},
+		{"", "en-US"},
+	}
+	for _, tt := range tests {
+		if got := ParseLocale(tt.tag); got.Tag != tt.want {
+			t.Errorf("ParseLocale(%q).Tag = %q, want %q", tt.tag, got.Tag, tt.want)
+		}
+	}
+}
+
+func TestNumber(t *testing.T) {
+	tests := []struct {
+		tag      string
+		v        float64
+		decimals int
+		want     string
+	}{
+		{"en-US", 1234567.891, 2, "1,234,567.89"},
+		{"de-DE", 1234567.891, 2, "1.234.567,89"},
+		{"fr-FR", 1234.5, 1, "1 234,5"},
+		{"en-US", -1234, 0, "-1,234"},
+		{"en-US", 42, 0, "42"},
+	}
+	for _, tt := range tests {
+		if got := ParseLocale(tt.tag).Number(tt.v, tt.decimals); got != tt.want {
+			t.Errorf("%s Number(%v, %d) = %q, want %q", tt.tag, tt.v, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestCurrency(t *testing.T) {
+	tests := []struct {
+		tag  string
+		v    float64
+		code string
+		want string
+	}{
+		{"en-US", 1234.5, "USD", "$1,234.50"},
+		{"de-DE", 1234.5, "EUR", "1.234,50 €"},
+		{"ja-JP", 1234, "JPY", "¥1,234"},
+		{"en-US", 12.5, "CHF", "CHF 12.50"},
+	}
+	for _, tt := range tests {
+		if got := ParseLocale(tt.tag).Currency(tt.v, tt.code); got != tt.want {
+			t.Errorf("%s Currency(%v, %s) = %q, want %q", tt.tag, tt.v, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestDate(t *testing.T) {
+	date := time.Date(2026, time.January, 31, 14, 5, 0, 0, time.UTC)
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"en-US", "01/31/2026"},
+		{"en-GB", "31/01/2026"},
+		{"de-DE", "31.01.2026"},
+		{"ja-JP", "2026/01/31"},
+	}
+	for _, tt := range tests {
+		if got := ParseLocale(tt.tag).Date(date); got != tt.want {
+			t.Errorf("%s Date = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+	if got := ParseLocale("en-US").DateTime(date); got != "01/31/2026 14:05" {
+		t.Errorf("DateTime = %q", got)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	if got := ParseLocale("de-DE").Percent(0.425, 1); got != "42,5%" {
+		t.Errorf("Percent = %q", got)
+	}
+}
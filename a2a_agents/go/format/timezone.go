@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"fmt"
+	"time"
+)
+
+// ResolveTimeZone loads an IANA timezone name from client capabilities.
+// An empty name resolves to UTC so agents without a client hint still get
+// deterministic output.
+func ResolveTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("format: resolving timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// NormalizeTimestamp converts t to the given zone and returns it as an
+// RFC 3339 string along with the explicit UTC offset ("+02:00"), so data
+// models record which zone the value was rendered in.
+func NormalizeTimestamp(t time.Time, loc *time.Location) (formatted, offset string) {
+	local := t.In(loc)
+	return local.Format(time.RFC3339), local.Format("-07:00")
+}
+
+// TimestampEntries returns the flattened data model entries for one
+// timestamp: the normalized value under key and the offset under
+// key + ".offset".
+func TimestampEntries(key string, t time.Time, loc *time.Location) map[string]any {
+	formatted, offset := NormalizeTimestamp(t, loc)
+	return map[string]any{
+		key:             formatted,
+		key + ".offset": offset,
+	}
+}
+
+// NormalizeSeries converts every timestamp of a time series to the given
+// zone, preserving order, so hourly charts line up with the user's clock.
+func NormalizeSeries(times []time.Time, loc *time.Location) []time.Time {
+	normalized := make([]time.Time, len(times))
+	for i, t := range times {
+		normalized[i] = t.In(loc)
+	}
+	return normalized
+}
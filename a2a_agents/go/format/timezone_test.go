@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveTimeZone(t *testing.T) {
+	if loc, err := ResolveTimeZone(""); err != nil || loc != time.UTC {
+		t.Errorf("empty name = %v, %v, want UTC", loc, err)
+	}
+	if loc, err := ResolveTimeZone("Europe/Berlin"); err != nil || loc.String() != "Europe/Berlin" {
+		t.Errorf("Europe/Berlin = %v, %v", loc, err)
+	}
+	if _, err := ResolveTimeZone("Not/AZone"); err == nil {
+		t.Error("invalid zone did not error")
+	}
+}
+
+func TestNormalizeTimestamp(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("tzdata unavailable:", err)
+	}
+	utc := time.Date(2026, time.July, 1, 12, 0, 0, 0, time.UTC)
+	formatted, offset := NormalizeTimestamp(utc, berlin)
+	if formatted != "2026-07-01T14:00:00+02:00" || offset != "+02:00" {
+		t.Errorf("NormalizeTimestamp = %q, %q", formatted, offset)
+	}
+
+	entries := TimestampEntries("series.points[0].time", utc, berlin)
+	if entries["series.points[0].time"] != formatted {
+		t.Errorf("entries = %v", entries)
+	}
+	if entries["series.points[0].time.offset"] != "+02:00" {
+		t.Errorf("offset entry = %v", entries)
+	}
+}
+
+func TestNormalizeSeries(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("tzdata unavailable:", err)
+	}
+	series := []time.Time{
+		time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, time.July, 1, 1, 0, 0, 0, time.UTC),
+	}
+	normalized := NormalizeSeries(series, berlin)
+	if len(normalized) != 2 || normalized[0].Hour() != 2 || normalized[1].Hour() != 3 {
+		t.Errorf("NormalizeSeries = %v", normalized)
+	}
+	if !normalized[0].Equal(series[0]) {
+		t.Error("normalization changed the instant")
+	}
+}
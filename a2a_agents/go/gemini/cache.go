@@ -0,0 +1,57 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CachedContent mirrors the cachedContents resource: content uploaded once
+// and referenced by name from generateContent requests, so large static
+// prompt blocks are not re-sent (or re-billed) per request.
+type CachedContent struct {
+	// Name is the server-assigned resource name, e.g. "cachedContents/abc".
+	Name              string    `json:"name,omitempty"`
+	Model             string    `json:"model,omitempty"`
+	SystemInstruction *Content  `json:"systemInstruction,omitempty"`
+	Contents          []Content `json:"contents,omitempty"`
+	Tools             []Tool    `json:"tools,omitempty"`
+	// TTL is the cache lifetime as a duration string, e.g. "3600s".
+	TTL string `json:"ttl,omitempty"`
+}
+
+// CreateCachedContent uploads content to the cache and returns the resource
+// with its server-assigned name. The model defaults to the client's model.
+func (c *Client) CreateCachedContent(ctx context.Context, content *CachedContent) (*CachedContent, error) {
+	if content.Model == "" {
+		withModel := *content
+		withModel.Model = "models/" + c.model
+		content = &withModel
+	}
+	var created CachedContent
+	url := fmt.Sprintf("%s/cachedContents", c.baseURL)
+	if err := c.doJSON(ctx, http.MethodPost, url, content, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// DeleteCachedContent deletes a cached content resource by name.
+func (c *Client) DeleteCachedContent(ctx context.Context, name string) error {
+	url := fmt.Sprintf("%s/%s", c.baseURL, name)
+	return c.doJSON(ctx, http.MethodDelete, url, nil, nil)
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gemini is a small client for the Gemini generateContent REST API,
+// covering the text and function-calling features the A2UI agents use.
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/A2UI/a2a_agents/go/tools"
+)
+
+// DefaultBaseURL is the production Gemini API endpoint.
+const DefaultBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+// Client calls the Gemini API. The zero value is not usable; use NewClient.
+type Client struct {
+	apiKey     string
+	model      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL overrides the API endpoint, e.g. for tests or proxies.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) { c.baseURL = baseURL }
+}
+
+// WithHTTPClient overrides the HTTP client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// NewClient returns a client for the given model (e.g. "gemini-2.0-flash")
+// authenticated with the API key.
+func NewClient(apiKey, model string, opts ...Option) *Client {
+	c := &Client{
+		apiKey:     apiKey,
+		model:      model,
+		baseURL:    DefaultBaseURL,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Model returns the model name the client targets.
+func (c *Client) Model() string { return c.model }
+
+// Roles for Content.Role: the Gemini API names the caller's turns "user"
+// and the model's turns "model".
+const (
+	RoleUser  = "user"
+	RoleModel = "model"
+)
+
+// Content is one conversational turn sent to or received from the model.
+type Content struct {
+	Role  string `json:"role,omitempty"`
+	Parts []Part `json:"parts"`
+}
+
+// Part is one piece of content: text, a function call from the model, or a
+// function response from the agent.
+type Part struct {
+	Text             string            `json:"text,omitempty"`
+	FunctionCall     *FunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *FunctionResponse `json:"functionResponse,omitempty"`
+}
+
+// FunctionCall is the model asking the agent to invoke a tool.
+type FunctionCall struct {
+	Name string         `json:"name"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+// FunctionResponse carries a tool's result back to the model.
+type FunctionResponse struct {
+	Name     string         `json:"name"`
+	Response map[string]any `json:"response"`
+}
+
+// Tool groups the function declarations offered to the model.
+type Tool struct {
+	FunctionDeclarations []*tools.Declaration `json:"functionDeclarations,omitempty"`
+}
+
+// GenerationConfig tunes the model's output.
+type GenerationConfig struct {
+	Temperature      *float64       `json:"temperature,omitempty"`
+	ResponseMIMEType string         `json:"responseMimeType,omitempty"`
+	ResponseSchema   map[string]any `json:"responseSchema,omitempty"`
+}
+
+// GenerateRequest is the generateContent request body.
+type GenerateRequest struct {
+	Contents          []Content         `json:"contents"`
+	SystemInstruction *Content          `json:"systemInstruction,omitempty"`
+	Tools             []Tool            `json:"tools,omitempty"`
+	GenerationConfig  *GenerationConfig `json:"generationConfig,omitempty"`
+	// CachedContent references a CachedContent resource by name. When set,
+	// the cached systemInstruction and tools apply and must not be repeated
+	// in the request.
+	CachedContent string `json:"cachedContent,omitempty"`
+}
+
+// Candidate is one response alternative from the model.
+type Candidate struct {
+	Content      Content `json:"content"`
+	FinishReason string  `json:"finishReason,omitempty"`
+}
+
+// GenerateResponse is the generateContent response body.
+type GenerateResponse struct {
+	Candidates []Candidate `json:"candidates"`
+}
+
+// Text concatenates the text parts of the first candidate.
+func (r *GenerateResponse) Text() string {
+	if len(r.Candidates) == 0 {
+		return ""
+	}
+	var out string
+	for _, part := range r.Candidates[0].Content.Parts {
+		out += part.Text
+	}
+	return out
+}
+
+// FunctionCalls returns the function calls of the first candidate, in order.
+func (r *GenerateResponse) FunctionCalls() []*FunctionCall {
+	if len(r.Candidates) == 0 {
+		return nil
+	}
+	var calls []*FunctionCall
+	for _, part := range r.Candidates[0].Content.Parts {
+		if part.FunctionCall != nil {
+			calls = append(calls, part.FunctionCall)
+		}
+	}
+	return calls
+}
+
+// APIError is a non-2xx response from the Gemini API.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gemini: API returned HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// GenerateContent performs a single generateContent call.
+func (c *Client) GenerateContent(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	url := fmt.Sprintf("%s/models/%s:generateContent", c.baseURL, c.model)
+	var resp GenerateResponse
+	if err := c.doJSON(ctx, http.MethodPost, url, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// doJSON performs one JSON API call. in and out may be nil for empty request
+// or response bodies.
+func (c *Client) doJSON(ctx context.Context, method, url string, in, out any) error {
+	var reqBody io.Reader
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("gemini: encoding request: %w", err)
+		}
+		reqBody = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return fmt.Errorf("gemini: building request: %w", err)
+	}
+	if in != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("x-goog-api-key", c.apiKey)
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("gemini: calling API: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("gemini: reading response: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return &APIError{StatusCode: httpResp.StatusCode, Body: string(respBody)}
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("gemini: decoding response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// BidiTransport is a bidirectional streaming connection to the Gemini Live
+// API, typically a WebSocket. It is an interface so transports (and test
+// fakes) can be plugged in without tying this package to a WebSocket
+// implementation.
+type BidiTransport interface {
+	// Send writes one message to the backend.
+	Send(ctx context.Context, msg []byte) error
+	// Receive blocks for the next message from the backend. It returns an
+	// error (conventionally io.EOF) once the connection is closed.
+	Receive(ctx context.Context) ([]byte, error)
+	// Close tears down the connection.
+	Close() error
+}
+
+// LiveSetup is the session configuration sent as the first client message.
+type LiveSetup struct {
+	Model             string   `json:"model,omitempty"`
+	SystemInstruction *Content `json:"systemInstruction,omitempty"`
+	Tools             []Tool   `json:"tools,omitempty"`
+}
+
+// LiveClientContent carries user turns into the session.
+type LiveClientContent struct {
+	Turns        []Content `json:"turns,omitempty"`
+	TurnComplete bool      `json:"turnComplete,omitempty"`
+}
+
+// LiveToolResponse returns tool results to the model mid-session.
+type LiveToolResponse struct {
+	FunctionResponses []*FunctionResponse `json:"functionResponses,omitempty"`
+}
+
+// liveClientMessage is the envelope for client-to-server messages.
+type liveClientMessage struct {
+	Setup         *LiveSetup         `json:"setup,omitempty"`
+	ClientContent *LiveClientContent `json:"clientContent,omitempty"`
+	ToolResponse  *LiveToolResponse  `json:"toolResponse,omitempty"`
+}
+
+// LiveServerContent is a model turn streamed from the session.
+type LiveServerContent struct {
+	ModelTurn    *Content `json:"modelTurn,omitempty"`
+	TurnComplete bool     `json:"turnComplete,omitempty"`
+}
+
+// LiveToolCall asks the client to run tools mid-session.
+type LiveToolCall struct {
+	FunctionCalls []*FunctionCall `json:"functionCalls,omitempty"`
+}
+
+// LiveServerMessage is the envelope for server-to-client messages. Exactly
+// one field is set.
+type LiveServerMessage struct {
+	SetupComplete *struct{}          `json:"setupComplete,omitempty"`
+	ServerContent *LiveServerContent `json:"serverContent,omitempty"`
+	ToolCall      *LiveToolCall      `json:"toolCall,omitempty"`
+}
+
+// LiveSession is a bidirectional streaming session with the model.
+type LiveSession struct {
+	transport BidiTransport
+}
+
+// NewLiveSession wraps an established transport in a session.
+func NewLiveSession(transport BidiTransport) *LiveSession {
+	return &LiveSession{transport: transport}
+}
+
+// Setup sends the session configuration. It must be the first call on the
+// session.
+func (s *LiveSession) Setup(ctx context.Context, setup *LiveSetup) error {
+	return s.send(ctx, &liveClientMessage{Setup: setup})
+}
+
+// SendContent pushes user turns into the session.
+func (s *LiveSession) SendContent(ctx context.Context, content *LiveClientContent) error {
+	return s.send(ctx, &liveClientMessage{ClientContent: content})
+}
+
+// SendToolResponse returns tool results to the model.
+func (s *LiveSession) SendToolResponse(ctx context.Context, resp *LiveToolResponse) error {
+	return s.send(ctx, &liveClientMessage{ToolResponse: resp})
+}
+
+// Receive blocks for the next server message.
+func (s *LiveSession) Receive(ctx context.Context) (*LiveServerMessage, error) {
+	raw, err := s.transport.Receive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var msg LiveServerMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, fmt.Errorf("gemini: decoding live message: %w", err)
+	}
+	return &msg, nil
+}
+
+// Close tears down the session.
+func (s *LiveSession) Close() error {
+	return s.transport.Close()
+}
+
+func (s *LiveSession) send(ctx context.Context, msg *liveClientMessage) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("gemini: encoding live message: %w", err)
+	}
+	return s.transport.Send(ctx, raw)
+}
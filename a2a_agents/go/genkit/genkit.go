@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package genkit integrates A2UI with Genkit for Go, so teams standardizing
+// on Genkit can emit A2UI surfaces from their existing flows.
+//
+// To keep this module free of a hard Genkit dependency, the integration is
+// expressed as plain values and funcs that plug directly into Genkit's
+// registration APIs:
+//
+//	sender, _ := a2uigenkit.NewSender(schema, emit)
+//	def := sender.Definition()
+//	genkit.DefineTool(g, def.Name, def.Description,
+//	    func(ctx *ai.ToolContext, in a2uigenkit.SendInput) (a2uigenkit.SendResult, error) {
+//	        return sender.Handle(ctx.Context, in)
+//	    })
+//
+// and the flow's system prompt gains the schema block via
+// Sender.InstructionBlock.
+package genkit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+// ToolDefinition describes the A2UI send tool in the shape Genkit's
+// DefineTool expects.
+type ToolDefinition struct {
+	// Name is the tool name the model calls.
+	Name string
+	// Description tells the model what the tool does.
+	Description string
+	// InputSchema is the JSON schema of SendInput.
+	InputSchema map[string]any
+}
+
+// SendInput is the tool input: the A2UI payload as a JSON string.
+type SendInput struct {
+	A2UIJSON string `json:"a2ui_json"`
+}
+
+// SendResult is the tool output returned to the model.
+type SendResult struct {
+	// ValidatedA2UIJSON holds the validated payload messages.
+	ValidatedA2UIJSON []map[string]any `json:"validated_a2ui_json,omitempty"`
+	// Error describes a validation failure the model should correct.
+	Error string `json:"error,omitempty"`
+}
+
+// EmitFunc receives validated A2UI messages for delivery to the client, e.g.
+// by wrapping them in A2A parts or forwarding them on a flow's stream.
+type EmitFunc func(ctx context.Context, messages []map[string]any) error
+
+// Sender exposes the A2UI send tool to Genkit flows.
+type Sender struct {
+	schema    *jsonschema.Schema
+	schemaDoc map[string]any
+	emit      EmitFunc
+}
+
+// NewSender builds a sender validating payloads against the given A2UI
+// message schema and delivering them through emit.
+func NewSender(schema map[string]any, emit EmitFunc) (*Sender, error) {
+	wrapped, err := a2ui.WrapAsJSONArray(schema)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := jsonschema.Compile(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("genkit: compiling A2UI schema: %w", err)
+	}
+	return &Sender{schema: compiled, schemaDoc: wrapped, emit: emit}, nil
+}
+
+// Definition returns the tool definition to register with genkit.DefineTool.
+func (s *Sender) Definition() ToolDefinition {
+	return ToolDefinition{
+		Name: "send_a2ui_json_to_client",
+		Description: "Sends A2UI JSON to the client to render rich UI for the" +
+			" user. The A2UI JSON Schema definition is between " +
+			a2ui.SchemaBlockBegin + " and " + a2ui.SchemaBlockEnd +
+			" in the system instructions.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"a2ui_json": map[string]any{
+					"type":        "string",
+					"description": "valid A2UI JSON Schema to send to the client.",
+				},
+			},
+			"required": []any{"a2ui_json"},
+		},
+	}
+}
+
+// InstructionBlock returns the schema block to append to the flow's system
+// prompt so the model knows the A2UI format.
+func (s *Sender) InstructionBlock() string {
+	return a2ui.SchemaInstructionBlock(s.schemaDoc)
+}
+
+// Handle is the tool implementation: it validates the payload and delivers
+// it through the emit callback. Validation failures are reported in the
+// result rather than as an error, so the model can correct the payload.
+func (s *Sender) Handle(ctx context.Context, in SendInput) (SendResult, error) {
+	if in.A2UIJSON == "" {
+		return SendResult{Error: "missing required arg a2ui_json"}, nil
+	}
+	var payload any
+	if err := json.Unmarshal([]byte(in.A2UIJSON), &payload); err != nil {
+		return SendResult{Error: fmt.Sprintf("parsing a2ui_json: %v", err)}, nil
+	}
+	list, ok := payload.([]any)
+	if !ok {
+		list = []any{payload}
+	}
+	if err := s.schema.Validate(list); err != nil {
+		return SendResult{Error: fmt.Sprintf("payload does not conform to the A2UI schema: %v", err)}, nil
+	}
+	messages := make([]map[string]any, len(list))
+	for i, elem := range list {
+		messages[i], ok = elem.(map[string]any)
+		if !ok {
+			return SendResult{Error: fmt.Sprintf("payload element %d is not an object", i)}, nil
+		}
+	}
+	if err := s.emit(ctx, messages); err != nil {
+		return SendResult{}, fmt.Errorf("genkit: emitting A2UI messages: %w", err)
+	}
+	return SendResult{ValidatedA2UIJSON: messages}, nil
+}
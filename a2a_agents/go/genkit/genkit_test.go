@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genkit
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+var messageSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"deleteSurface": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"surfaceId": map[string]any{"type": "string"}},
+			"required":   []any{"surfaceId"},
+		},
+	},
+	"additionalProperties": false,
+}
+
+func TestSenderHandleEmitsValidatedPayload(t *testing.T) {
+	var emitted []map[string]any
+	sender, err := NewSender(messageSchema, func(_ context.Context, msgs []map[string]any) error {
+		emitted = append(emitted, msgs...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewSender: %v", err)
+	}
+	result, err := sender.Handle(context.Background(), SendInput{
+		A2UIJSON: `[{"deleteSurface": {"surfaceId": "s1"}}]`,
+	})
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if result.Error != "" {
+		t.Fatalf("Handle result error: %s", result.Error)
+	}
+	if len(emitted) != 1 {
+		t.Fatalf("emitted %d messages, want 1", len(emitted))
+	}
+}
+
+func TestSenderHandleReportsValidationErrorToModel(t *testing.T) {
+	sender, err := NewSender(messageSchema, func(context.Context, []map[string]any) error {
+		t.Fatal("emit called for invalid payload")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewSender: %v", err)
+	}
+	result, err := sender.Handle(context.Background(), SendInput{
+		A2UIJSON: `[{"unknownMessage": {}}]`,
+	})
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if result.Error == "" {
+		t.Fatal("invalid payload produced no correction error")
+	}
+}
+
+func TestSenderInstructionBlock(t *testing.T) {
+	sender, err := NewSender(messageSchema, func(context.Context, []map[string]any) error { return nil })
+	if err != nil {
+		t.Fatalf("NewSender: %v", err)
+	}
+	block := sender.InstructionBlock()
+	if !strings.Contains(block, "deleteSurface") {
+		t.Error("instruction block does not contain the schema")
+	}
+	def := sender.Definition()
+	if def.Name != "send_a2ui_json_to_client" {
+		t.Errorf("tool name = %q", def.Name)
+	}
+}
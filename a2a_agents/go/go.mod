@@ -0,0 +1,3 @@
+module github.com/google/A2UI/a2a_agents/go
+
+go 1.21
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"html"
+	"io"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+// bundleCSS is the stylesheet embedded in exported bundles. It is
+// deliberately minimal: enough to make the layout containers legible
+// without guessing at client themes.
+const bundleCSS = `body { font-family: sans-serif; margin: 2em; color: #202124; }
+.a2ui-surface { margin-bottom: 2em; }
+.a2ui-surface > h2 { border-bottom: 1px solid #dadce0; padding-bottom: 0.3em; }
+.a2ui-row { display: flex; flex-direction: row; gap: 1em; }
+.a2ui-column { display: flex; flex-direction: column; gap: 0.5em; }
+.a2ui-card { border: 1px solid #dadce0; border-radius: 8px; padding: 1em; }
+.a2ui-placeholder { border: 1px dashed #dadce0; border-radius: 8px; padding: 1em; }
+.a2ui-placeholder::before { content: attr(data-component); font-weight: bold; }
+.a2ui-placeholder pre { overflow-x: auto; font-size: 0.85em; color: #5f6368; }
+.a2ui-missing { color: #d93025; }
+`
+
+// ExportHTMLBundle writes a self-contained HTML document with every
+// surface the task produced, for archiving or sharing a conversation's
+// dashboards. Tasks without A2UI output export an empty-but-valid page.
+func ExportHTMLBundle(task *a2a.Task, w io.Writer) error {
+	return WriteHTMLBundle(fmt.Sprintf("A2UI export — task %s", task.ID),
+		BuildSurfaces(a2ui.TaskMessages(task)), w)
+}
+
+// WriteHTMLBundle writes the given surfaces as a single HTML document
+// with the title and the embedded stylesheet.
+func WriteHTMLBundle(title string, surfaces []*Surface, w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n<h1>%s</h1>\n",
+		html.EscapeString(title), bundleCSS, html.EscapeString(title)); err != nil {
+		return fmt.Errorf("render: writing bundle: %w", err)
+	}
+	for _, surface := range surfaces {
+		if _, err := fmt.Fprintf(w, "<section class=\"a2ui-surface\">\n<h2>%s</h2>\n%s\n</section>\n",
+			html.EscapeString(surface.ID), surface.HTML()); err != nil {
+			return fmt.Errorf("render: writing surface %s: %w", surface.ID, err)
+		}
+	}
+	if _, err := io.WriteString(w, "</body>\n</html>\n"); err != nil {
+		return fmt.Errorf("render: writing bundle: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+// ExportDataJSON writes the surface's flattened data model as indented
+// JSON, so users can get the numbers behind an agent-generated chart.
+func ExportDataJSON(s *Surface, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s.Data); err != nil {
+		return fmt.Errorf("render: exporting data for surface %s: %w", s.ID, err)
+	}
+	return nil
+}
+
+// seriesKey matches indexed data model entries like "chart.items[2].value".
+var seriesKey = regexp.MustCompile(`^(.+)\[(\d+)\]\.(.+)$`)
+
+// ExportDataCSV writes the surface's data model as CSV. Indexed entries
+// ("items[0].label", "items[0].value", ...) become one row per index with
+// one column per field; everything else falls back to key,value pairs.
+func ExportDataCSV(s *Surface, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header, rows := dataRows(s)
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("render: exporting data for surface %s: %w", s.ID, err)
+	}
+	for _, row := range rows {
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("render: exporting data for surface %s: %w", s.ID, err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("render: exporting data for surface %s: %w", s.ID, err)
+	}
+	return nil
+}
+
+// dataRows shapes the data model for CSV: a table over the indexed series
+// entries when there are any, key/value pairs otherwise.
+func dataRows(s *Surface) (header []string, rows [][]string) {
+	type cell struct {
+		index int
+		field string
+		value string
+	}
+	var cells []cell
+	fields := map[string]bool{}
+	for _, key := range s.DataKeys() {
+		if m := seriesKey.FindStringSubmatch(key); m != nil {
+			index, _ := strconv.Atoi(m[2])
+			cells = append(cells, cell{index: index, field: m[3], value: fmt.Sprint(s.Data[key])})
+			fields[m[3]] = true
+		}
+	}
+	if len(cells) == 0 {
+		header = []string{"key", "value"}
+		for _, key := range s.DataKeys() {
+			rows = append(rows, []string{key, fmt.Sprint(s.Data[key])})
+		}
+		return header, rows
+	}
+
+	header = make([]string, 0, len(fields))
+	for field := range fields {
+		header = append(header, field)
+	}
+	sort.Strings(header)
+	column := map[string]int{}
+	for i, field := range header {
+		column[field] = i
+	}
+	byIndex := map[int][]string{}
+	var indexes []int
+	for _, c := range cells {
+		if _, ok := byIndex[c.index]; !ok {
+			byIndex[c.index] = make([]string, len(header))
+			indexes = append(indexes, c.index)
+		}
+		byIndex[c.index][column[c.field]] = c.value
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		rows = append(rows, byIndex[index])
+	}
+	return header, rows
+}
+
+// DataExportURL builds the download URL an agent can reference from a
+// surface's "export" action, e.g. as a Button pointing at the data behind
+// a chart.
+func DataExportURL(base, taskID, surfaceID, format string) string {
+	query := url.Values{}
+	query.Set("task", taskID)
+	query.Set("surface", surfaceID)
+	query.Set("format", format)
+	return base + "?" + query.Encode()
+}
+
+// DataExportHandler serves surface data models as CSV or JSON downloads.
+// Requests name a stored task and surface:
+//
+//	GET /export?task=<id>&surface=<id>&format=csv|json
+func DataExportHandler(store a2a.TaskStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		taskID := r.URL.Query().Get("task")
+		surfaceID := r.URL.Query().Get("surface")
+		if taskID == "" || surfaceID == "" {
+			http.Error(w, "task and surface are required", http.StatusBadRequest)
+			return
+		}
+		task, err := store.Get(r.Context(), taskID)
+		if err != nil {
+			http.Error(w, "task not found", http.StatusNotFound)
+			return
+		}
+		var surface *Surface
+		for _, s := range BuildSurfaces(a2ui.TaskMessages(task)) {
+			if s.ID == surfaceID {
+				surface = s
+				break
+			}
+		}
+		if surface == nil {
+			http.Error(w, "surface not found", http.StatusNotFound)
+			return
+		}
+
+		switch format := r.URL.Query().Get("format"); format {
+		case "csv":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", surfaceID+".csv"))
+			if err := ExportDataCSV(surface, w); err != nil {
+				http.Error(w, "export failed", http.StatusInternalServerError)
+			}
+		case "json", "":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", surfaceID+".json"))
+			if err := ExportDataJSON(surface, w); err != nil {
+				http.Error(w, "export failed", http.StatusInternalServerError)
+			}
+		default:
+			http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+		}
+	})
+}
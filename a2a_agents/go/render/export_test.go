@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+func exportTestSurface() *Surface {
+	return &Surface{
+		ID: "s1",
+		Data: map[string]any{
+			"chart.title":          "Sales",
+			"chart.items[0].label": "Shoes",
+			"chart.items[0].value": float64(41),
+			"chart.items[1].label": "Hats",
+			"chart.items[1].value": float64(17),
+		},
+	}
+}
+
+func TestExportDataCSVSeries(t *testing.T) {
+	var b strings.Builder
+	if err := ExportDataCSV(exportTestSurface(), &b); err != nil {
+		t.Fatalf("ExportDataCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	want := []string{"label,value", "Shoes,41", "Hats,17"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), b.String())
+	}
+	for i, line := range want {
+		if lines[i] != line {
+			t.Errorf("line %d = %q, want %q", i, lines[i], line)
+		}
+	}
+}
+
+func TestExportDataCSVKeyValue(t *testing.T) {
+	s := &Surface{ID: "s1", Data: map[string]any{"chart.title": "Sales", "chart.type": "bar"}}
+	var b strings.Builder
+	if err := ExportDataCSV(s, &b); err != nil {
+		t.Fatalf("ExportDataCSV: %v", err)
+	}
+	if got := strings.TrimSpace(b.String()); got != "key,value\nchart.title,Sales\nchart.type,bar" {
+		t.Errorf("key/value export = %q", got)
+	}
+}
+
+func TestDataExportHandler(t *testing.T) {
+	store := a2a.NewInMemoryTaskStore()
+	task := &a2a.Task{
+		ID:     "task1",
+		Status: a2a.NewTaskStatus(a2a.TaskStateCompleted, nil),
+		Artifacts: []a2a.Artifact{{ArtifactID: "art1", Name: "a2ui", Parts: []a2a.Part{
+			a2ui.NewPart(map[string]any{"beginRendering": map[string]any{"surfaceId": "s1", "root": "r"}}),
+			a2ui.NewPart(map[string]any{"dataModelUpdate": map[string]any{"surfaceId": "s1", "path": "/", "contents": []any{
+				map[string]any{"key": "chart.title", "valueString": "Sales"},
+			}}}),
+		}}},
+	}
+	if err := store.Save(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+	handler := DataExportHandler(store)
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantBody   string
+	}{
+		{"json", "/export?task=task1&surface=s1&format=json", 200, `"chart.title": "Sales"`},
+		{"csv", "/export?task=task1&surface=s1&format=csv", 200, "chart.title,Sales"},
+		{"default is json", "/export?task=task1&surface=s1", 200, `"chart.title"`},
+		{"unknown task", "/export?task=nope&surface=s1", 404, ""},
+		{"unknown surface", "/export?task=task1&surface=nope", 404, ""},
+		{"bad format", "/export?task=task1&surface=s1&format=xml", 400, ""},
+		{"missing params", "/export", 400, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantBody != "" && !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body missing %q:\n%s", tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestDataExportURL(t *testing.T) {
+	got := DataExportURL("/export", "task1", "s1", "csv")
+	if got != "/export?format=csv&surface=s1&task=task1" {
+		t.Errorf("DataExportURL = %q", got)
+	}
+}
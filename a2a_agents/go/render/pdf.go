@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+)
+
+// ErrNoHeadlessBrowser is returned when no headless browser binary could
+// be found for the print pipeline.
+var ErrNoHeadlessBrowser = errors.New("render: no headless browser found")
+
+// browserCandidates are the binaries tried, in order, when PDFRenderer
+// has no explicit Binary configured.
+var browserCandidates = []string{
+	"chromium",
+	"chromium-browser",
+	"google-chrome",
+	"chrome",
+}
+
+// PDFRenderer prints HTML bundles to PDF through a headless browser. The
+// zero value finds a browser on PATH; set Binary to pin one.
+type PDFRenderer struct {
+	// Binary is the browser executable. Empty means search PATH for the
+	// usual Chromium/Chrome names.
+	Binary string
+	// ExtraArgs are appended to the browser invocation, e.g. sandbox
+	// flags needed in containerized environments.
+	ExtraArgs []string
+}
+
+// RenderToPDF renders the task's surfaces as a printable PDF report, so
+// agents can attach it alongside the interactive A2UI artifact.
+func (r *PDFRenderer) RenderToPDF(ctx context.Context, task *a2a.Task, w io.Writer) error {
+	var html bytes.Buffer
+	if err := ExportHTMLBundle(task, &html); err != nil {
+		return err
+	}
+	return r.printHTML(ctx, html.Bytes(), w)
+}
+
+// printHTML runs the headless print pipeline: the bundle goes to a temp
+// file, the browser prints it, and the resulting PDF is copied to w.
+func (r *PDFRenderer) printHTML(ctx context.Context, html []byte, w io.Writer) error {
+	binary, err := r.binary()
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "a2ui-pdf-")
+	if err != nil {
+		return fmt.Errorf("render: creating work dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	htmlPath := filepath.Join(dir, "bundle.html")
+	pdfPath := filepath.Join(dir, "bundle.pdf")
+	if err := os.WriteFile(htmlPath, html, 0o600); err != nil {
+		return fmt.Errorf("render: writing bundle: %w", err)
+	}
+
+	args := []string{"--headless", "--disable-gpu", "--print-to-pdf=" + pdfPath}
+	args = append(args, r.ExtraArgs...)
+	args = append(args, htmlPath)
+	cmd := exec.CommandContext(ctx, binary, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("render: printing to PDF: %w (%s)", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	pdf, err := os.ReadFile(pdfPath)
+	if err != nil {
+		return fmt.Errorf("render: reading printed PDF: %w", err)
+	}
+	if _, err := w.Write(pdf); err != nil {
+		return fmt.Errorf("render: writing PDF: %w", err)
+	}
+	return nil
+}
+
+// binary resolves the browser executable to run.
+func (r *PDFRenderer) binary() (string, error) {
+	if r.Binary != "" {
+		return r.Binary, nil
+	}
+	for _, candidate := range browserCandidates {
+		if path, err := exec.LookPath(candidate); err == nil {
+			return path, nil
+		}
+	}
+	return "", ErrNoHeadlessBrowser
+}
+
+// PDFArtifact renders the task's surfaces to PDF and wraps the result as
+// an artifact ready to attach to the task.
+func (r *PDFRenderer) PDFArtifact(ctx context.Context, task *a2a.Task) (*a2a.Artifact, error) {
+	var pdf bytes.Buffer
+	if err := r.RenderToPDF(ctx, task, &pdf); err != nil {
+		return nil, err
+	}
+	return &a2a.Artifact{
+		ArtifactID: fmt.Sprintf("%s-report", task.ID),
+		Name:       "a2ui_report",
+		Parts:      []a2a.Part{a2a.NewFilePart("report.pdf", "application/pdf", pdf.Bytes())},
+	}, nil
+}
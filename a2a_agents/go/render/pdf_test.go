@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+// fakeBrowser writes an executable that mimics the headless print
+// pipeline: it writes a PDF marker to the --print-to-pdf target.
+func fakeBrowser(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake browser script requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "fake-chromium")
+	script := `#!/bin/sh
+for arg in "$@"; do
+  case "$arg" in
+    --print-to-pdf=*) printf '%s' "%PDF-1.4 fake" > "${arg#--print-to-pdf=}" ;;
+  esac
+done
+`
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func pdfTestTask() *a2a.Task {
+	return &a2a.Task{
+		ID:     "task1",
+		Status: a2a.NewTaskStatus(a2a.TaskStateCompleted, nil),
+		Artifacts: []a2a.Artifact{{
+			ArtifactID: "art1",
+			Name:       "a2ui",
+			Parts: []a2a.Part{
+				a2ui.NewPart(map[string]any{"beginRendering": map[string]any{"surfaceId": "s1", "root": "r"}}),
+			},
+		}},
+	}
+}
+
+func TestRenderToPDF(t *testing.T) {
+	r := &PDFRenderer{Binary: fakeBrowser(t)}
+	var pdf bytes.Buffer
+	if err := r.RenderToPDF(context.Background(), pdfTestTask(), &pdf); err != nil {
+		t.Fatalf("RenderToPDF: %v", err)
+	}
+	if !strings.HasPrefix(pdf.String(), "%PDF") {
+		t.Errorf("output is not a PDF: %q", pdf.String())
+	}
+}
+
+func TestRenderToPDFNoBrowser(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	r := &PDFRenderer{}
+	err := r.RenderToPDF(context.Background(), pdfTestTask(), &bytes.Buffer{})
+	if !errors.Is(err, ErrNoHeadlessBrowser) {
+		t.Errorf("got %v, want ErrNoHeadlessBrowser", err)
+	}
+}
+
+func TestPDFArtifact(t *testing.T) {
+	r := &PDFRenderer{Binary: fakeBrowser(t)}
+	artifact, err := r.PDFArtifact(context.Background(), pdfTestTask())
+	if err != nil {
+		t.Fatalf("PDFArtifact: %v", err)
+	}
+	if artifact.Name != "a2ui_report" || len(artifact.Parts) != 1 {
+		t.Fatalf("artifact = %+v", artifact)
+	}
+	part := artifact.Parts[0]
+	if part.Kind != "file" || part.File == nil || part.File.MimeType != "application/pdf" {
+		t.Errorf("part = %+v, want inline PDF file part", part)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package render is a server-side HTML renderer for A2UI surfaces. It is
+// not a substitute for a real client — interactivity and custom components
+// degrade to static placeholders — but it is good enough for archival
+// exports, previews, and printable reports.
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// Surface is the assembled state of one A2UI surface: the component tree
+// and the data model, built by applying messages in order.
+type Surface struct {
+	// ID is the surfaceId the messages referred to.
+	ID string
+	// Root is the component ID rendering starts from.
+	Root string
+	// Components maps component IDs to their wrapper objects ({"Text": {...}}).
+	Components map[string]map[string]any
+	// Data is the flattened data model: entry key to value.
+	Data map[string]any
+}
+
+// BuildSurfaces applies A2UI messages in order and returns the resulting
+// surfaces, in the order they began rendering. Deleted surfaces are
+// omitted.
+func BuildSurfaces(messages []map[string]any) []*Surface {
+	byID := map[string]*Surface{}
+	var order []string
+	surface := func(id string) *Surface {
+		s, ok := byID[id]
+		if !ok {
+			s = &Surface{ID: id, Components: map[string]map[string]any{}, Data: map[string]any{}}
+			byID[id] = s
+			order = append(order, id)
+		}
+		return s
+	}
+	for _, msg := range messages {
+		switch {
+		case msg["beginRendering"] != nil:
+			begin, _ := msg["beginRendering"].(map[string]any)
+			s := surface(str(begin["surfaceId"]))
+			s.Root = str(begin["root"])
+		case msg["surfaceUpdate"] != nil:
+			update, _ := msg["surfaceUpdate"].(map[string]any)
+			s := surface(str(update["surfaceId"]))
+			components, _ := update["components"].([]any)
+			for _, entry := range components {
+				wrapper, ok := entry.(map[string]any)
+				if !ok {
+					continue
+				}
+				component, _ := wrapper["component"].(map[string]any)
+				s.Components[str(wrapper["id"])] = component
+			}
+		case msg["dataModelUpdate"] != nil:
+			update, _ := msg["dataModelUpdate"].(map[string]any)
+			s := surface(str(update["surfaceId"]))
+			contents, _ := update["contents"].([]any)
+			for _, entry := range contents {
+				m, ok := entry.(map[string]any)
+				if !ok {
+					continue
+				}
+				key := str(m["key"])
+				for name, value := range m {
+					if strings.HasPrefix(name, "value") {
+						s.Data[key] = value
+					}
+				}
+			}
+		case msg["deleteSurface"] != nil:
+			del, _ := msg["deleteSurface"].(map[string]any)
+			id := str(del["surfaceId"])
+			if _, ok := byID[id]; ok {
+				delete(byID, id)
+				for i, existing := range order {
+					if existing == id {
+						order = append(order[:i], order[i+1:]...)
+						break
+					}
+				}
+			}
+		}
+	}
+	surfaces := make([]*Surface, len(order))
+	for i, id := range order {
+		surfaces[i] = byID[id]
+	}
+	return surfaces
+}
+
+// HTML renders the surface's component tree from its root.
+func (s *Surface) HTML() string {
+	var b strings.Builder
+	s.renderComponent(&b, s.Root, map[string]bool{})
+	return b.String()
+}
+
+// renderComponent writes one component and its children. visiting guards
+// against cycles in malformed trees.
+func (s *Surface) renderComponent(b *strings.Builder, id string, visiting map[string]bool) {
+	if visiting[id] {
+		return
+	}
+	component, ok := s.Components[id]
+	if !ok || len(component) != 1 {
+		fmt.Fprintf(b, `<div class="a2ui-missing">missing component %s</div>`, html.EscapeString(id))
+		return
+	}
+	visiting[id] = true
+	defer delete(visiting, id)
+
+	var kind string
+	var props map[string]any
+	for name, value := range component {
+		kind = name
+		props, _ = value.(map[string]any)
+	}
+
+	switch kind {
+	case "Column", "Canvas":
+		fmt.Fprintf(b, `<div class="a2ui-column" data-id=%q>`, html.EscapeString(id))
+		s.renderChildren(b, props, visiting)
+		b.WriteString(`</div>`)
+	case "Row":
+		fmt.Fprintf(b, `<div class="a2ui-row" data-id=%q>`, html.EscapeString(id))
+		s.renderChildren(b, props, visiting)
+		b.WriteString(`</div>`)
+	case "Card":
+		fmt.Fprintf(b, `<div class="a2ui-card" data-id=%q>`, html.EscapeString(id))
+		if child := str(props["child"]); child != "" {
+			s.renderComponent(b, child, visiting)
+		}
+		s.renderChildren(b, props, visiting)
+		b.WriteString(`</div>`)
+	case "List":
+		fmt.Fprintf(b, `<div class="a2ui-list" data-id=%q>`, html.EscapeString(id))
+		s.renderChildren(b, props, visiting)
+		b.WriteString(`</div>`)
+	case "Text":
+		tag := "p"
+		switch str(props["usageHint"]) {
+		case "h1":
+			tag = "h1"
+		case "h2":
+			tag = "h2"
+		case "h3":
+			tag = "h3"
+		}
+		fmt.Fprintf(b, "<%s>%s</%s>", tag, html.EscapeString(s.text(props["text"])), tag)
+	case "Image":
+		fmt.Fprintf(b, `<img src=%q alt="">`, html.EscapeString(s.text(props["url"])))
+	case "Button":
+		fmt.Fprintf(b, `<button disabled>%s</button>`, html.EscapeString(s.text(props["label"])))
+	case "Divider":
+		b.WriteString("<hr>")
+	default:
+		// Custom or unsupported component: a static placeholder carrying the
+		// component type and its properties.
+		raw, _ := json.MarshalIndent(props, "", "  ")
+		fmt.Fprintf(b, `<div class="a2ui-placeholder" data-component=%q data-id=%q><pre>%s</pre></div>`,
+			html.EscapeString(kind), html.EscapeString(id), html.EscapeString(string(raw)))
+	}
+}
+
+// renderChildren renders the explicit child list of a container. Template
+// bindings are rendered once as the template component.
+func (s *Surface) renderChildren(b *strings.Builder, props map[string]any, visiting map[string]bool) {
+	children, _ := props["children"].(map[string]any)
+	if children == nil {
+		return
+	}
+	if explicit, ok := children["explicitList"].([]any); ok {
+		for _, child := range explicit {
+			s.renderComponent(b, str(child), visiting)
+		}
+		return
+	}
+	if template, ok := children["template"].(map[string]any); ok {
+		if id := str(template["componentId"]); id != "" {
+			s.renderComponent(b, id, visiting)
+		}
+	}
+}
+
+// text resolves a text-valued property: a plain string, a literalString
+// wrapper, or a data model path.
+func (s *Surface) text(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if literal, ok := v["literalString"].(string); ok {
+			return literal
+		}
+		if path, ok := v["path"].(string); ok {
+			if data, ok := s.Data[path]; ok {
+				return fmt.Sprint(data)
+			}
+			return path
+		}
+	}
+	return ""
+}
+
+// DataKeys returns the surface's data model keys, sorted.
+func (s *Surface) DataKeys() []string {
+	keys := make([]string, 0, len(s.Data))
+	for key := range s.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return s
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+func renderTestMessages() []map[string]any {
+	return []map[string]any{
+		{"beginRendering": map[string]any{"surfaceId": "s1", "root": "root"}},
+		{"surfaceUpdate": map[string]any{"surfaceId": "s1", "components": []any{
+			map[string]any{"id": "root", "component": map[string]any{"Column": map[string]any{
+				"children": map[string]any{"explicitList": []any{"title", "chart"}},
+			}}},
+			map[string]any{"id": "title", "component": map[string]any{"Text": map[string]any{
+				"usageHint": "h1",
+				"text":      map[string]any{"path": "chart.title"},
+			}}},
+			map[string]any{"id": "chart", "component": map[string]any{"Chart": map[string]any{
+				"type": map[string]any{"literalString": "bar"},
+			}}},
+		}}},
+		{"dataModelUpdate": map[string]any{"surfaceId": "s1", "path": "/", "contents": []any{
+			map[string]any{"key": "chart.title", "valueString": "Sales <by> Category"},
+		}}},
+	}
+}
+
+func TestBuildSurfaces(t *testing.T) {
+	surfaces := BuildSurfaces(renderTestMessages())
+	if len(surfaces) != 1 {
+		t.Fatalf("got %d surfaces, want 1", len(surfaces))
+	}
+	s := surfaces[0]
+	if s.ID != "s1" || s.Root != "root" {
+		t.Errorf("surface = %q root %q, want s1/root", s.ID, s.Root)
+	}
+	if len(s.Components) != 3 {
+		t.Errorf("got %d components, want 3", len(s.Components))
+	}
+	if got := s.Data["chart.title"]; got != "Sales <by> Category" {
+		t.Errorf("data chart.title = %v", got)
+	}
+}
+
+func TestBuildSurfacesDelete(t *testing.T) {
+	messages := append(renderTestMessages(),
+		map[string]any{"deleteSurface": map[string]any{"surfaceId": "s1"}})
+	if surfaces := BuildSurfaces(messages); len(surfaces) != 0 {
+		t.Errorf("deleted surface still present: %v", surfaces)
+	}
+}
+
+func TestSurfaceHTML(t *testing.T) {
+	surfaces := BuildSurfaces(renderTestMessages())
+	out := surfaces[0].HTML()
+	if !strings.Contains(out, "<h1>Sales &lt;by&gt; Category</h1>") {
+		t.Errorf("title not rendered with data binding and escaping:\n%s", out)
+	}
+	if !strings.Contains(out, `data-component="Chart"`) {
+		t.Errorf("custom component has no placeholder:\n%s", out)
+	}
+	if !strings.Contains(out, `class="a2ui-column"`) {
+		t.Errorf("column container missing:\n%s", out)
+	}
+}
+
+func TestSurfaceHTMLCycle(t *testing.T) {
+	surfaces := BuildSurfaces([]map[string]any{
+		{"beginRendering": map[string]any{"surfaceId": "s1", "root": "a"}},
+		{"surfaceUpdate": map[string]any{"surfaceId": "s1", "components": []any{
+			map[string]any{"id": "a", "component": map[string]any{"Column": map[string]any{
+				"children": map[string]any{"explicitList": []any{"a"}},
+			}}},
+		}}},
+	})
+	// Must terminate; the cycle guard drops the repeated visit.
+	if out := surfaces[0].HTML(); !strings.Contains(out, "a2ui-column") {
+		t.Errorf("cyclic tree rendered nothing:\n%s", out)
+	}
+}
+
+func TestExportHTMLBundle(t *testing.T) {
+	var parts []a2a.Part
+	for _, msg := range renderTestMessages() {
+		parts = append(parts, a2ui.NewPart(msg))
+	}
+	task := &a2a.Task{
+		ID:        "task1",
+		Status:    a2a.NewTaskStatus(a2a.TaskStateCompleted, nil),
+		Artifacts: []a2a.Artifact{{ArtifactID: "art1", Name: "a2ui", Parts: parts}},
+	}
+
+	var b strings.Builder
+	if err := ExportHTMLBundle(task, &b); err != nil {
+		t.Fatalf("ExportHTMLBundle: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{"<!DOCTYPE html>", "<style>", "<h2>s1</h2>", "Sales &lt;by&gt; Category"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("bundle missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+	"github.com/google/A2UI/a2a_agents/go/agent"
+)
+
+// feedbackExecutor implements a2a.AgentExecutor deterministically, without
+// an LLM: a text message gets the feedback form, and a userAction from the
+// form's submit button gets validated and answered with either an error
+// update or a confirmation surface.
+type feedbackExecutor struct {
+	schema *jsonschema.Schema
+}
+
+// Execute routes the request to the form, error, or confirmation path.
+func (f *feedbackExecutor) Execute(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue) error {
+	if !a2ui.TryActivateExtension(rc) {
+		return f.status(rc, queue, a2a.TaskStateFailed, "A2UI must be enabled to use this agent.")
+	}
+
+	action := userAction(rc.Message)
+	if action == nil || action["name"] != submitAction {
+		if err := f.emit(rc, queue, formMessages()); err != nil {
+			return err
+		}
+		return f.status(rc, queue, a2a.TaskStateInputRequired, "Please fill in the feedback form.")
+	}
+
+	actionContext, _ := action["context"].(map[string]any)
+	name, rating, problems := validateSubmission(actionContext)
+	if len(problems) > 0 {
+		slog.Info("submission rejected", "problems", problems)
+		if err := f.emit(rc, queue, errorMessages(strings.Join(problems, " "))); err != nil {
+			return err
+		}
+		return f.status(rc, queue, a2a.TaskStateInputRequired, "Please correct the form and resubmit.")
+	}
+
+	slog.Info("submission accepted", "name", name, "rating", rating)
+	if err := f.emit(rc, queue, confirmationMessages(name, rating)); err != nil {
+		return err
+	}
+	return f.status(rc, queue, a2a.TaskStateCompleted, "Feedback recorded.")
+}
+
+// Cancel transitions the task to canceled.
+func (f *feedbackExecutor) Cancel(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue) error {
+	return f.status(rc, queue, a2a.TaskStateCanceled, "")
+}
+
+// validateSubmission checks the resolved action context and returns the
+// submitted name and rating along with any problems found.
+func validateSubmission(actionContext map[string]any) (name string, rating float64, problems []string) {
+	name, _ = actionContext["name"].(string)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		problems = append(problems, "Please enter your name.")
+	}
+	if comment, ok := actionContext["comment"].(string); ok && len(comment) > 500 {
+		problems = append(problems, "Please keep comments under 500 characters.")
+	}
+	rating, ok := actionContext["rating"].(float64)
+	if !ok || rating < 1 || rating > 5 {
+		problems = append(problems, "Rating must be between 1 and 5.")
+	}
+	return name, rating, problems
+}
+
+// emit validates the A2UI messages against the standard catalog schema and
+// publishes them as a single artifact. Validation guards the hand-built
+// payloads the same way the executor guards LLM output.
+func (f *feedbackExecutor) emit(rc *a2a.RequestContext, queue *a2a.EventQueue, messages []map[string]any) error {
+	parts := make([]a2a.Part, len(messages))
+	for i, msg := range messages {
+		if err := f.schema.Validate(msg); err != nil {
+			return fmt.Errorf("invalid A2UI message: %w", err)
+		}
+		parts[i] = a2ui.NewPart(msg)
+	}
+	return queue.Write(&a2a.ArtifactUpdateEvent{
+		TaskID:    rc.TaskID,
+		ContextID: rc.ContextID,
+		Artifact: a2a.Artifact{
+			ArtifactID: a2a.NewID(),
+			Name:       agent.A2UIArtifactName,
+			Parts:      parts,
+		},
+	})
+}
+
+// status publishes a final status update, optionally carrying a text
+// message.
+func (f *feedbackExecutor) status(rc *a2a.RequestContext, queue *a2a.EventQueue, state a2a.TaskState, text string) error {
+	var msg *a2a.Message
+	if text != "" {
+		msg = &a2a.Message{
+			MessageID: a2a.NewID(),
+			Role:      a2a.RoleAgent,
+			Parts:     []a2a.Part{a2a.NewTextPart(text)},
+			ContextID: rc.ContextID,
+			TaskID:    rc.TaskID,
+		}
+	}
+	return queue.Write(&a2a.StatusUpdateEvent{
+		TaskID:    rc.TaskID,
+		ContextID: rc.ContextID,
+		Status:    a2a.NewTaskStatus(state, msg),
+		Final:     true,
+	})
+}
+
+// userAction extracts the first userAction carried by the message's A2UI
+// data parts, or nil when the message is plain text.
+func userAction(msg *a2a.Message) map[string]any {
+	if msg == nil {
+		return nil
+	}
+	for _, part := range msg.Parts {
+		data := a2ui.Data(part)
+		if data == nil {
+			continue
+		}
+		if action, ok := data["userAction"].(map[string]any); ok {
+			return action
+		}
+	}
+	return nil
+}
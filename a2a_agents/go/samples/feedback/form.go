@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "fmt"
+
+// surfaceID is the single surface this agent renders.
+const surfaceID = "feedback"
+
+// submitAction is the action name the submit button dispatches; the
+// executor keys on it when a userAction arrives.
+const submitAction = "submit_feedback"
+
+// text builds a literal text binding.
+func text(s string) map[string]any {
+	return map[string]any{"literalString": s}
+}
+
+// path builds a data-model path binding.
+func path(p string) map[string]any {
+	return map[string]any{"path": p}
+}
+
+// component wraps one component for a surfaceUpdate's components list.
+func component(id, typeName string, props map[string]any) map[string]any {
+	return map[string]any{"id": id, "component": map[string]any{typeName: props}}
+}
+
+// formMessages builds the feedback form: a begin/update pair plus the
+// initial data model. Field values live in the data model under /form, so
+// the submit action's context can reference them by path.
+func formMessages() []map[string]any {
+	components := []any{
+		component("root", "Column", map[string]any{
+			"children": map[string]any{"explicitList": []any{
+				"title", "name_field", "comment_field", "rating_slider", "submit_button", "error_text",
+			}},
+		}),
+		component("title", "Text", map[string]any{"text": text("Share your feedback")}),
+		component("name_field", "TextField", map[string]any{
+			"label": text("Your name"),
+			"text":  path("/form/name"),
+		}),
+		component("comment_field", "TextField", map[string]any{
+			"label":         text("Comments"),
+			"text":          path("/form/comment"),
+			"textFieldType": "longText",
+		}),
+		component("rating_slider", "Slider", map[string]any{
+			"value":    path("/form/rating"),
+			"minValue": 1.0,
+			"maxValue": 5.0,
+		}),
+		component("submit_label", "Text", map[string]any{"text": text("Submit")}),
+		component("submit_button", "Button", map[string]any{
+			"child":   "submit_label",
+			"primary": true,
+			"action": map[string]any{
+				"name": submitAction,
+				"context": []any{
+					map[string]any{"key": "name", "value": path("/form/name")},
+					map[string]any{"key": "comment", "value": path("/form/comment")},
+					map[string]any{"key": "rating", "value": path("/form/rating")},
+				},
+			},
+		}),
+		component("error_text", "Text", map[string]any{"text": path("/form/error")}),
+	}
+	return []map[string]any{
+		{"beginRendering": map[string]any{"surfaceId": surfaceID, "root": "root"}},
+		{"surfaceUpdate": map[string]any{"surfaceId": surfaceID, "components": components}},
+		{"dataModelUpdate": map[string]any{
+			"surfaceId": surfaceID,
+			"path":      "/form",
+			"contents": []any{
+				map[string]any{"key": "name", "valueString": ""},
+				map[string]any{"key": "comment", "valueString": ""},
+				map[string]any{"key": "rating", "valueNumber": 3.0},
+				map[string]any{"key": "error", "valueString": ""},
+			},
+		}},
+	}
+}
+
+// errorMessages surfaces a validation failure in the form's error text,
+// leaving the user's input in place.
+func errorMessages(problem string) []map[string]any {
+	return []map[string]any{
+		{"dataModelUpdate": map[string]any{
+			"surfaceId": surfaceID,
+			"path":      "/form",
+			"contents": []any{
+				map[string]any{"key": "error", "valueString": problem},
+			},
+		}},
+	}
+}
+
+// confirmationMessages replaces the form with a confirmation card once a
+// submission validates.
+func confirmationMessages(name string, rating float64) []map[string]any {
+	components := []any{
+		component("root", "Column", map[string]any{
+			"children": map[string]any{"explicitList": []any{"confirm_card"}},
+		}),
+		component("confirm_card", "Card", map[string]any{"child": "confirm_column"}),
+		component("confirm_column", "Column", map[string]any{
+			"children": map[string]any{"explicitList": []any{"confirm_title", "confirm_text"}},
+		}),
+		component("confirm_title", "Text", map[string]any{
+			"text": text(fmt.Sprintf("Thanks, %s!", name)),
+		}),
+		component("confirm_text", "Text", map[string]any{
+			"text": text(fmt.Sprintf("Your %.0f-star feedback was recorded.", rating)),
+		}),
+	}
+	return []map[string]any{
+		{"surfaceUpdate": map[string]any{"surfaceId": surfaceID, "components": components}},
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// feedback is a sample A2UI agent built around input components: it sends
+// a feedback form (text fields, a slider, a submit button), validates the
+// userAction the form produces, and updates the surface with either an
+// inline error or a confirmation card. Unlike the rizzcharts sample it
+// exercises the client-to-server half of the protocol and needs no LLM.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/a2ui/jsonschema"
+)
+
+func main() {
+	host := flag.String("host", "localhost", "host to listen on")
+	port := flag.Int("port", 10004, "port to listen on")
+	flag.Parse()
+
+	doc, err := a2ui.LoadA2UISchema(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	schema, err := jsonschema.Compile(doc)
+	if err != nil {
+		log.Fatal(err)
+	}
+	executor := &feedbackExecutor{schema: schema}
+
+	// Catch payload-builder regressions at boot rather than on first use.
+	for _, messages := range [][]map[string]any{
+		formMessages(),
+		errorMessages("example"),
+		confirmationMessages("example", 5),
+	} {
+		for _, msg := range messages {
+			if err := schema.Validate(msg); err != nil {
+				log.Fatalf("built-in payload fails schema validation: %v", err)
+			}
+		}
+	}
+
+	card := a2a.AgentCard{
+		Name:        "Feedback Agent",
+		Description: "Collects structured feedback through an A2UI form.",
+		URL:         fmt.Sprintf("http://%s:%d/", *host, *port),
+		Version:     "1.0.0",
+		Capabilities: a2a.AgentCapabilities{
+			Streaming: true,
+			Extensions: []a2a.AgentExtension{
+				a2ui.AgentExtension(false, []string{a2ui.StandardCatalogID}),
+			},
+		},
+	}
+
+	server := a2a.NewServer(card, executor)
+	addr := fmt.Sprintf("%s:%d", *host, *port)
+	log.Printf("feedback agent listening on %s", addr)
+	log.Fatal(http.ListenAndServe(addr, server))
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+// rpcRequest and rpcResponse mirror the server's JSON-RPC framing; the
+// library only ships the server side, so the orchestrator carries its own
+// minimal client.
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// sendToAgent sends the user's query to one sub-agent via message/send,
+// requesting the A2UI extension, and returns the completed task with its
+// artifacts.
+func sendToAgent(ctx context.Context, client *http.Client, url, query string) (*a2a.Task, error) {
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "message/send",
+		Params: map[string]any{
+			"message": a2a.Message{
+				MessageID:  a2a.NewID(),
+				Role:       a2a.RoleUser,
+				Parts:      []a2a.Part{a2a.NewTextPart(query)},
+				Extensions: []string{a2ui.ExtensionURI},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(a2a.ExtensionsHeader, a2ui.ExtensionURI)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, fmt.Errorf("decoding response from %s: %w", url, err)
+	}
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("%s: %s (code %d)", url, rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+	var task a2a.Task
+	if err := json.Unmarshal(rpcResp.Result, &task); err != nil {
+		return nil, fmt.Errorf("decoding task from %s: %w", url, err)
+	}
+	return &task, nil
+}
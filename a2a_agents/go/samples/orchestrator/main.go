@@ -0,0 +1,215 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// orchestrator is a sample A2UI agent that does not call an LLM itself:
+// it fans the user's query out to A2UI-capable sub-agents over A2A,
+// collects the surfaces they produce, and forwards them to the client
+// under namespaced surface IDs so the aggregated view cannot collide.
+//
+// Usage:
+//
+//	orchestrator -agents http://localhost:10002/,http://localhost:10003/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/agent"
+)
+
+func main() {
+	host := flag.String("host", "localhost", "host to listen on")
+	port := flag.Int("port", 10001, "port to listen on")
+	agents := flag.String("agents", "", "comma-separated sub-agent URLs to delegate to (required)")
+	flag.Parse()
+
+	var urls []string
+	for _, u := range strings.Split(*agents, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	if len(urls) == 0 {
+		flag.Usage()
+		log.Fatal("at least one -agents URL is required")
+	}
+
+	executor := &orchestrator{
+		agents: urls,
+		client: &http.Client{Timeout: 2 * time.Minute},
+	}
+	card := a2a.AgentCard{
+		Name:        "Orchestrator Agent",
+		Description: "Aggregates A2UI surfaces from multiple sub-agents.",
+		URL:         fmt.Sprintf("http://%s:%d/", *host, *port),
+		Version:     "1.0.0",
+		Capabilities: a2a.AgentCapabilities{
+			Streaming: true,
+			Extensions: []a2a.AgentExtension{
+				a2ui.AgentExtension(false, []string{a2ui.StandardCatalogID}),
+			},
+		},
+	}
+
+	server := a2a.NewServer(card, executor)
+	addr := fmt.Sprintf("%s:%d", *host, *port)
+	log.Printf("orchestrator listening on %s, delegating to %s", addr, strings.Join(urls, ", "))
+	log.Fatal(http.ListenAndServe(addr, server))
+}
+
+// orchestrator implements a2a.AgentExecutor by delegation instead of
+// generation.
+type orchestrator struct {
+	agents []string
+	client *http.Client
+}
+
+// subResult is one sub-agent's contribution to the aggregated view.
+type subResult struct {
+	url   string
+	parts []a2a.Part
+	err   error
+}
+
+// Execute fans the query out to every sub-agent concurrently and forwards
+// each one's surfaces as they are collected.
+func (o *orchestrator) Execute(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue) error {
+	if !a2ui.TryActivateExtension(rc) {
+		return o.status(rc, queue, a2a.TaskStateFailed, "A2UI must be enabled to use this agent.", true)
+	}
+	if err := o.status(rc, queue, a2a.TaskStateWorking, "", false); err != nil {
+		return err
+	}
+	query := queryText(rc.Message)
+
+	results := make([]subResult, len(o.agents))
+	var wg sync.WaitGroup
+	for i, agentURL := range o.agents {
+		wg.Add(1)
+		go func(i int, agentURL string) {
+			defer wg.Done()
+			results[i] = subResult{url: agentURL}
+			task, err := sendToAgent(ctx, o.client, agentURL, query)
+			if err != nil {
+				results[i].err = err
+				return
+			}
+			for _, artifact := range task.Artifacts {
+				results[i].parts = append(results[i].parts, artifact.Parts...)
+			}
+		}(i, agentURL)
+	}
+	wg.Wait()
+
+	forwarded, failed := 0, 0
+	for _, result := range results {
+		if result.err != nil {
+			slog.Error("sub-agent failed", "agent", result.url, "error", result.err)
+			failed++
+			continue
+		}
+		// Namespace surface IDs per sub-agent so two sub-agents using the
+		// same ID (e.g. "main") do not overwrite each other client-side.
+		parts, err := a2ui.ForwardParts(result.parts, a2ui.ForwardOptions{
+			SurfacePrefix: agentPrefix(result.url),
+		})
+		if err != nil {
+			slog.Error("sub-agent surfaces not forwardable", "agent", result.url, "error", err)
+			failed++
+			continue
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		event := &a2a.ArtifactUpdateEvent{
+			TaskID:    rc.TaskID,
+			ContextID: rc.ContextID,
+			Artifact: a2a.Artifact{
+				ArtifactID: a2a.NewID(),
+				Name:       agent.A2UIArtifactName,
+				Parts:      parts,
+			},
+		}
+		if err := queue.Write(event); err != nil {
+			return fmt.Errorf("forwarding surfaces from %s: %w", result.url, err)
+		}
+		forwarded++
+	}
+
+	summary := fmt.Sprintf("Aggregated surfaces from %d of %d agents.", forwarded, len(o.agents))
+	if failed == len(o.agents) {
+		return o.status(rc, queue, a2a.TaskStateFailed, "All sub-agents failed to respond.", true)
+	}
+	return o.status(rc, queue, a2a.TaskStateCompleted, summary, true)
+}
+
+// Cancel transitions the task to canceled.
+func (o *orchestrator) Cancel(ctx context.Context, rc *a2a.RequestContext, queue *a2a.EventQueue) error {
+	return o.status(rc, queue, a2a.TaskStateCanceled, "", true)
+}
+
+// status publishes a status update, optionally carrying a text message.
+func (o *orchestrator) status(rc *a2a.RequestContext, queue *a2a.EventQueue, state a2a.TaskState, text string, final bool) error {
+	var msg *a2a.Message
+	if text != "" {
+		msg = &a2a.Message{
+			MessageID: a2a.NewID(),
+			Role:      a2a.RoleAgent,
+			Parts:     []a2a.Part{a2a.NewTextPart(text)},
+			ContextID: rc.ContextID,
+			TaskID:    rc.TaskID,
+		}
+	}
+	return queue.Write(&a2a.StatusUpdateEvent{
+		TaskID:    rc.TaskID,
+		ContextID: rc.ContextID,
+		Status:    a2a.NewTaskStatus(state, msg),
+		Final:     final,
+	})
+}
+
+// queryText concatenates the text parts of the user's message.
+func queryText(msg *a2a.Message) string {
+	if msg == nil {
+		return ""
+	}
+	var out string
+	for _, part := range msg.Parts {
+		if part.Kind == "text" {
+			out += part.Text
+		}
+	}
+	return out
+}
+
+// agentPrefix derives a stable surface-ID prefix from a sub-agent URL,
+// e.g. "localhost_10002/".
+func agentPrefix(agentURL string) string {
+	u, err := url.Parse(agentURL)
+	if err != nil || u.Host == "" {
+		return "agent/"
+	}
+	return strings.ReplaceAll(u.Host, ":", "_") + "/"
+}
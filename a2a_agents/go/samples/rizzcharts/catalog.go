@@ -0,0 +1,53 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+// rizzchartsCatalogURI identifies the custom rizzcharts component catalog.
+const rizzchartsCatalogURI = "https://github.com/google/A2UI/blob/main/samples/agent/adk/rizzcharts/rizzcharts_catalog_definition.json"
+
+//go:embed rizzcharts_catalog_definition.json
+var rizzchartsCatalogDefinition []byte
+
+// rizzchartsCatalog parses the bundled catalog definition into a catalog of
+// the custom components (Canvas, Chart, GoogleMap). The definition's $ref to
+// the standard catalog is skipped: the standard components are already part
+// of the base schema.
+func rizzchartsCatalog() (*a2ui.Catalog, error) {
+	var definition struct {
+		Components map[string]any `json:"components"`
+	}
+	if err := json.Unmarshal(rizzchartsCatalogDefinition, &definition); err != nil {
+		return nil, fmt.Errorf("parsing rizzcharts catalog definition: %w", err)
+	}
+	delete(definition.Components, "$ref")
+	return &a2ui.Catalog{
+		ID:         rizzchartsCatalogURI,
+		Components: definition.Components,
+		// Earlier prompts named the chart variants as their own component
+		// types; alias them to Chart so old payloads keep rendering.
+		Aliases: a2ui.AliasMap{
+			"PieChart":      {Target: "Chart", Props: map[string]any{"type": "pie"}},
+			"DoughnutChart": {Target: "Chart", Props: map[string]any{"type": "doughnut"}},
+		},
+	}, nil
+}
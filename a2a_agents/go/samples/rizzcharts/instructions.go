@@ -0,0 +1,68 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+)
+
+// instructionsHeader is the static part of the agent prompt; the bundled
+// example payloads are appended per example at startup.
+const instructionsHeader = `### System Instructions
+
+You are an expert A2UI Ecommerce Dashboard analyst. Your primary function is to translate user requests for ecommerce data into A2UI JSON payloads to display charts and visualizations. You MUST use the ` + "`send_a2ui_json_to_client`" + ` tool with the ` + "`a2ui_json`" + ` argument set to the A2UI JSON payload to send to the client. You should also include a brief text message with each response saying what you did and asking if you can help with anything else.
+
+**Core Objective:** To provide a dynamic and interactive dashboard by constructing UI surfaces with the appropriate visualization components based on user queries.
+
+**Key Components & Examples:**
+
+You will be provided a schema that defines the A2UI message structure and generic component templates for displaying data, one per example block below.
+
+1.  **Charts:** Used for requests about sales breakdowns, revenue performance, comparisons, or trends.
+    * **Template:** Use the JSON from ` + "`---BEGIN CHART EXAMPLE---`" + `.
+2.  **Maps:** Used for requests about regional data, store locations, geography-based performance, or regional outliers.
+    * **Template:** Use the JSON from ` + "`---BEGIN MAP EXAMPLE---`" + `.
+
+---
+
+### Workflow and Rules
+
+1.  **Analyze the Request:** Determine the user's intent (Visual Chart vs. Geospatial Map).
+2.  **Fetch Data:** Use ` + "`get_sales_data`" + ` for sales and product category trends (Charts), and ` + "`get_store_sales`" + ` for regional performance and store locations (Maps).
+3.  **Select Example:** Based on the intent, choose the correct example block to use as your template.
+4.  **Construct the JSON Payload:** Use the entire JSON array from the chosen example as the base. Generate a new, unique surfaceId for this request and use it in all messages of the array. Update the title Text component to reflect the specific user query. The payload is validated against the schema and rejected if it does not conform.
+5.  **Call the Tool:** Call ` + "`send_a2ui_json_to_client`" + ` with the fully constructed payload.
+`
+
+// maxExamplesPerPrompt bounds how many few-shot examples are inlined: the
+// selector picks the example matching the query's intent, keeping the prompt
+// small, and falls back to the full set for unrecognized queries.
+const maxExamplesPerPrompt = 1
+
+// instructionsFor assembles the system instructions for one query,
+// appending a delimited block for each selected example.
+func instructionsFor(library *a2ui.ExampleLibrary, query string) string {
+	var b strings.Builder
+	b.WriteString(instructionsHeader)
+	for _, example := range library.Select(query, maxExamplesPerPrompt) {
+		marker := strings.ToUpper(example.Name)
+		fmt.Fprintf(&b, "\n---BEGIN %s EXAMPLE---\n%s\n---END %s EXAMPLE---\n",
+			marker, example.Payload, marker)
+	}
+	return b.String()
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The rizzcharts sample agent serves an ecommerce dashboard: it turns user
+// requests about sales data into A2UI chart and map surfaces.
+package main
+
+import (
+	"embed"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/google/A2UI/a2a_agents/go/a2a"
+	"github.com/google/A2UI/a2a_agents/go/a2ui"
+	"github.com/google/A2UI/a2a_agents/go/agent"
+	"github.com/google/A2UI/a2a_agents/go/gemini"
+	"github.com/google/A2UI/a2a_agents/go/tools"
+)
+
+// embeddedExamples carries the few-shot example payloads inside the
+// binary, so the agent starts from any working directory or container.
+//
+//go:embed examples
+var embeddedExamples embed.FS
+
+func main() {
+	host := flag.String("host", "localhost", "host to listen on")
+	port := flag.Int("port", 10002, "port to listen on")
+	model := flag.String("model", "gemini-2.5-flash", "Gemini model to use")
+	dev := flag.Bool("dev", false, "reload the examples directory per request for prompt iteration")
+	flag.Parse()
+
+	// The sample logs tool arguments and error details; run every record
+	// through redaction so a credential can never reach the logs.
+	slog.SetDefault(slog.New(agent.NewRedactingHandler(slog.Default().Handler())))
+
+	apiKey := os.Getenv("GEMINI_API_KEY")
+	if apiKey == "" {
+		log.Fatal("GEMINI_API_KEY environment variable not set")
+	}
+
+	catalog, err := rizzchartsCatalog()
+	if err != nil {
+		log.Fatal(err)
+	}
+	schema, err := a2ui.LoadA2UISchema(catalog)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Loading the library validates every bundled example against the merged
+	// catalog schema, so a stale example stops the agent here instead of
+	// surfacing as a request-time error when it is inlined into a prompt.
+	library, err := a2ui.LoadExampleLibraryFS(embeddedExamples, "examples", catalog)
+	if err != nil {
+		log.Fatalf("example validation failed:\n%v", err)
+	}
+
+	salesTool, err := newSalesDataTool()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg := agent.Config{
+		Client: gemini.NewClient(apiKey, *model),
+		InstructionsFunc: func(query string) string {
+			return instructionsFor(library, query)
+		},
+		Schema:  schema,
+		Tools:   []tools.Tool{salesTool, storeSalesTool{}},
+		Aliases: catalog.Aliases,
+	}
+	if *dev {
+		// Reload and revalidate the examples on every request, so edited
+		// payloads reach the prompt without restarting the agent. A broken
+		// example keeps the last good library.
+		loader := agent.NewDevLoader("", "examples", catalog)
+		cfg.InstructionsFunc = func(query string) string {
+			reloaded := loader.Library()
+			if reloaded == nil {
+				reloaded = library
+			}
+			return instructionsFor(reloaded, query)
+		}
+	}
+	executor, err := agent.New(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	card := a2a.AgentCard{
+		Name:        "Rizzcharts Agent",
+		Description: "An agent that runs an ecommerce dashboard.",
+		URL:         fmt.Sprintf("http://%s:%d/", *host, *port),
+		Version:     "1.0.0",
+		Capabilities: a2a.AgentCapabilities{
+			Streaming: true,
+			Extensions: []a2a.AgentExtension{
+				a2ui.AgentExtension(false, []string{a2ui.StandardCatalogID, rizzchartsCatalogURI}),
+			},
+		},
+	}
+
+	server := a2a.NewServer(card, executor)
+	addr := fmt.Sprintf("%s:%d", *host, *port)
+	log.Printf("rizzcharts agent listening on %s", addr)
+	log.Fatal(http.ListenAndServe(addr, server))
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/A2UI/a2a_agents/go/tools"
+)
+
+// salesQuery is the statement a real deployment would run; the demo
+// querier below serves the same rows from memory. Swap the querier for a
+// tools.BigQueryRunner to serve live data without touching the tool.
+const salesQuery = `SELECT category, subcategory, sales
+FROM demo.category_sales
+WHERE period = @time_period
+ORDER BY category, subcategory`
+
+// newSalesDataTool builds the category-sales tool on the SQL data tool
+// framework: rows come back flat and the shape function groups them into
+// the nested drill-down structure the chart instructions expect.
+func newSalesDataTool() (tools.Tool, error) {
+	return tools.NewSQLTool(tools.SQLToolConfig{
+		Name:        "get_sales_data",
+		Description: "Gets the sales data broken down by product category.",
+		Query:       salesQuery,
+		Parameters: &tools.Schema{
+			Type: "object",
+			Properties: map[string]*tools.Schema{
+				"time_period": {
+					Type:        "string",
+					Description: "The time period to get sales data for (e.g. 'Q1', 'year').",
+					Default:     "year",
+				},
+			},
+		},
+		Querier: demoSalesQuerier{},
+		MaxRows: 100,
+		Shape:   shapeSalesData,
+	})
+}
+
+// demoSalesQuerier serves the sample's canned rows in the column layout
+// the real query produces.
+type demoSalesQuerier struct{}
+
+func (demoSalesQuerier) QueryRows(ctx context.Context, query string, params map[string]any) ([]map[string]any, error) {
+	slog.Info("get_sales_data called", "args", params)
+	return []map[string]any{
+		{"category": "Apparel", "subcategory": "", "sales": 41.0},
+		{"category": "Apparel", "subcategory": "Tops", "sales": 31.0},
+		{"category": "Apparel", "subcategory": "Bottoms", "sales": 38.0},
+		{"category": "Apparel", "subcategory": "Outerwear", "sales": 20.0},
+		{"category": "Apparel", "subcategory": "Footwear", "sales": 11.0},
+		{"category": "Home Goods", "subcategory": "", "sales": 15.0},
+		{"category": "Home Goods", "subcategory": "Pillow", "sales": 8.0},
+		{"category": "Home Goods", "subcategory": "Coffee Maker", "sales": 16.0},
+		{"category": "Home Goods", "subcategory": "Area Rug", "sales": 3.0},
+		{"category": "Home Goods", "subcategory": "Bath Towels", "sales": 14.0},
+		{"category": "Electronics", "subcategory": "", "sales": 28.0},
+		{"category": "Electronics", "subcategory": "Phones", "sales": 25.0},
+		{"category": "Electronics", "subcategory": "Laptops", "sales": 27.0},
+		{"category": "Electronics", "subcategory": "TVs", "sales": 21.0},
+		{"category": "Electronics", "subcategory": "Other", "sales": 27.0},
+		{"category": "Health & Beauty", "subcategory": "", "sales": 10.0},
+		{"category": "Other", "subcategory": "", "sales": 6.0},
+	}, nil
+}
+
+// shapeSalesData groups flat (category, subcategory, sales) rows into the
+// chart-ready list of categories with optional drill-downs. A row with an
+// empty subcategory carries the category total.
+func shapeSalesData(rows []map[string]any) map[string]any {
+	var categories []any
+	byLabel := map[string]map[string]any{}
+	for _, row := range rows {
+		category, _ := row["category"].(string)
+		subcategory, _ := row["subcategory"].(string)
+		entry := byLabel[category]
+		if entry == nil {
+			entry = map[string]any{"label": category}
+			byLabel[category] = entry
+			categories = append(categories, entry)
+		}
+		if subcategory == "" {
+			entry["value"] = row["sales"]
+			continue
+		}
+		drillDown, _ := entry["drillDown"].([]any)
+		entry["drillDown"] = append(drillDown, map[string]any{
+			"label": subcategory, "value": row["sales"],
+		})
+	}
+	return map[string]any{"sales_data": categories}
+}
+
+// storeSalesTool returns (canned) per-store sales with locations, with
+// outlier stores highlighted.
+type storeSalesTool struct{}
+
+func (storeSalesTool) Declaration() *tools.Declaration {
+	return &tools.Declaration{
+		Name:        "get_store_sales",
+		Description: "Gets individual store sales with locations, highlighting outlier stores.",
+		Parameters: &tools.Schema{
+			Type: "object",
+			Properties: map[string]*tools.Schema{
+				"region": {
+					Type:        "string",
+					Description: "The region to get store sales for.",
+					Default:     "all",
+				},
+			},
+		},
+		// The map surface needs every location positioned and named; the
+		// response schema fails the call on a malformed row instead of
+		// letting it produce a broken map.
+		Response: &tools.Schema{
+			Type:     "object",
+			Required: []string{"center", "locations"},
+			Properties: map[string]*tools.Schema{
+				"center": {
+					Type:     "object",
+					Required: []string{"lat", "lng"},
+					Properties: map[string]*tools.Schema{
+						"lat": {Type: "number"},
+						"lng": {Type: "number"},
+					},
+				},
+				"zoom": {Type: "integer"},
+				"locations": {
+					Type: "array",
+					Items: &tools.Schema{
+						Type:     "object",
+						Required: []string{"lat", "lng", "name"},
+						Properties: map[string]*tools.Schema{
+							"lat":  {Type: "number"},
+							"lng":  {Type: "number"},
+							"name": {Type: "string"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (storeSalesTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	slog.Info("get_store_sales called", "args", args)
+	return map[string]any{
+		"center": map[string]any{"lat": 34, "lng": -118.2437},
+		"zoom":   10,
+		"locations": []any{
+			map[string]any{
+				"lat": 34.0195, "lng": -118.4912,
+				"name":           "Santa Monica Branch",
+				"description":    "High traffic coastal location.",
+				"outlier_reason": "Yes, 15% sales over baseline",
+				"background":     "#4285F4",
+				"borderColor":    "#FFFFFF",
+				"glyphColor":     "#FFFFFF",
+			},
+			map[string]any{"lat": 34.0488, "lng": -118.2518, "name": "Downtown Flagship"},
+			map[string]any{"lat": 34.1016, "lng": -118.3287, "name": "Hollywood Boulevard Store"},
+			map[string]any{"lat": 34.1478, "lng": -118.1445, "name": "Pasadena Location"},
+			map[string]any{"lat": 33.7701, "lng": -118.1937, "name": "Long Beach Outlet"},
+			map[string]any{"lat": 34.0736, "lng": -118.4004, "name": "Beverly Hills Boutique"},
+		},
+	}, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ArgumentError describes an invalid tool call in a form the invocation
+// layer can hand back to the model for correction: which required
+// arguments were missing and which supplied values were wrong.
+type ArgumentError struct {
+	// Tool is the name of the tool that was called.
+	Tool string
+	// Missing lists required arguments absent from the call.
+	Missing []string
+	// Invalid maps supplied argument names to what was wrong with them.
+	Invalid map[string]string
+}
+
+func (e *ArgumentError) Error() string {
+	var clauses []string
+	if len(e.Missing) > 0 {
+		clauses = append(clauses, fmt.Sprintf("is missing required argument(s): %s",
+			strings.Join(e.Missing, ", ")))
+	}
+	names := make([]string, 0, len(e.Invalid))
+	for name := range e.Invalid {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		clauses = append(clauses, fmt.Sprintf("has invalid argument %q: %s", name, e.Invalid[name]))
+	}
+	return fmt.Sprintf("tools: call to %q %s", e.Tool, strings.Join(clauses, "; "))
+}
+
+// DecodeArgs decodes the loosely typed argument map the model produced for a
+// tool call into the struct pointed to by out, using the tool's declaration
+// to fill in defaults and enforce required parameters.
+//
+// Fields of out are matched by their json struct tags. Values that arrive as
+// strings but are declared as numbers or booleans (a common model quirk) are
+// coerced before decoding, so tools do not need per-argument type-assertion
+// fallbacks.
+func DecodeArgs(decl *Declaration, args map[string]any, out any) error {
+	resolved, err := ResolveArgs(decl, args)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(resolved)
+	if err != nil {
+		return fmt.Errorf("tools: encoding args for %q: %w", decl.Name, err)
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("tools: decoding args for %q: %w", decl.Name, err)
+	}
+	return nil
+}
+
+// ResolveArgs returns a copy of args with the declaration's defaults applied
+// to absent optional parameters, string values coerced to their declared
+// types, and required parameters enforced. The input map is not modified.
+// Invalid calls fail with an *ArgumentError collecting every problem at
+// once, so the model can correct the whole call in one retry.
+func ResolveArgs(decl *Declaration, args map[string]any) (map[string]any, error) {
+	params := decl.Parameters
+	resolved := make(map[string]any, len(args))
+	for k, v := range args {
+		resolved[k] = v
+	}
+	if params == nil {
+		return resolved, nil
+	}
+	argErr := &ArgumentError{Tool: decl.Name}
+	for name, prop := range params.Properties {
+		v, ok := resolved[name]
+		if !ok || v == nil {
+			if prop.Default != nil {
+				resolved[name] = prop.Default
+			}
+			continue
+		}
+		coerced, err := coerce(prop, v)
+		if err != nil {
+			if argErr.Invalid == nil {
+				argErr.Invalid = map[string]string{}
+			}
+			argErr.Invalid[name] = err.Error()
+			continue
+		}
+		resolved[name] = coerced
+	}
+	for _, name := range params.Required {
+		if v, ok := resolved[name]; !ok || v == nil {
+			argErr.Missing = append(argErr.Missing, name)
+		}
+	}
+	sort.Strings(argErr.Missing)
+	if len(argErr.Missing) > 0 || len(argErr.Invalid) > 0 {
+		return nil, argErr
+	}
+	return resolved, nil
+}
+
+// coerce converts string values to the schema's declared scalar type. Values
+// that already have the right dynamic type pass through unchanged.
+func coerce(schema *Schema, v any) (any, error) {
+	s, isString := v.(string)
+	switch schema.Type {
+	case "number", "integer":
+		if !isString {
+			return v, nil
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a %s, got %q", schema.Type, s)
+		}
+		return n, nil
+	case "boolean":
+		if !isString {
+			return v, nil
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("expected a boolean, got %q", s)
+		}
+		return b, nil
+	default:
+		return v, nil
+	}
+}
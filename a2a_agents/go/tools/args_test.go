@@ -0,0 +1,132 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type salesArgs struct {
+	Region     string  `json:"region"`
+	TimePeriod string  `json:"time_period"`
+	Limit      float64 `json:"limit"`
+	Detailed   bool    `json:"detailed"`
+}
+
+func salesDecl() *Declaration {
+	return &Declaration{
+		Name: "get_sales_data",
+		Parameters: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"region":      {Type: "string", Default: "all"},
+				"time_period": {Type: "string", Default: "year"},
+				"limit":       {Type: "number"},
+				"detailed":    {Type: "boolean"},
+			},
+			Required: []string{"limit"},
+		},
+	}
+}
+
+func TestDecodeArgsAppliesDefaults(t *testing.T) {
+	var got salesArgs
+	err := DecodeArgs(salesDecl(), map[string]any{"limit": 10.0}, &got)
+	if err != nil {
+		t.Fatalf("DecodeArgs: %v", err)
+	}
+	if got.Region != "all" || got.TimePeriod != "year" {
+		t.Errorf("defaults not applied, got %+v", got)
+	}
+	if got.Limit != 10 {
+		t.Errorf("limit = %v, want 10", got.Limit)
+	}
+}
+
+func TestDecodeArgsCoercesStringValues(t *testing.T) {
+	var got salesArgs
+	args := map[string]any{
+		"region":   "emea",
+		"limit":    "25",
+		"detailed": "true",
+	}
+	if err := DecodeArgs(salesDecl(), args, &got); err != nil {
+		t.Fatalf("DecodeArgs: %v", err)
+	}
+	if got.Limit != 25 {
+		t.Errorf("limit = %v, want 25", got.Limit)
+	}
+	if !got.Detailed {
+		t.Error("detailed = false, want true")
+	}
+	if got.Region != "emea" {
+		t.Errorf("region = %q, want emea", got.Region)
+	}
+}
+
+func TestDecodeArgsMissingRequired(t *testing.T) {
+	var got salesArgs
+	err := DecodeArgs(salesDecl(), map[string]any{"region": "apac"}, &got)
+	if err == nil {
+		t.Fatal("DecodeArgs succeeded, want missing-required error")
+	}
+	if !strings.Contains(err.Error(), "limit") {
+		t.Errorf("error %q does not name the missing argument", err)
+	}
+}
+
+func TestDecodeArgsBadCoercion(t *testing.T) {
+	var got salesArgs
+	err := DecodeArgs(salesDecl(), map[string]any{"limit": "lots"}, &got)
+	if err == nil {
+		t.Fatal("DecodeArgs succeeded, want coercion error")
+	}
+}
+
+func TestResolveArgsCollectsAllProblems(t *testing.T) {
+	_, err := ResolveArgs(salesDecl(), map[string]any{"detailed": "maybe"})
+	if err == nil {
+		t.Fatal("ResolveArgs succeeded, want argument error")
+	}
+	var argErr *ArgumentError
+	if !errors.As(err, &argErr) {
+		t.Fatalf("error is a %T, want *ArgumentError", err)
+	}
+	if argErr.Tool != "get_sales_data" {
+		t.Errorf("tool = %q", argErr.Tool)
+	}
+	if len(argErr.Missing) != 1 || argErr.Missing[0] != "limit" {
+		t.Errorf("missing = %v, want [limit]", argErr.Missing)
+	}
+	if problem := argErr.Invalid["detailed"]; !strings.Contains(problem, "boolean") {
+		t.Errorf("invalid[detailed] = %q, want a boolean complaint", problem)
+	}
+	// The rendered message names every problem for the model.
+	if msg := err.Error(); !strings.Contains(msg, "limit") || !strings.Contains(msg, "detailed") {
+		t.Errorf("error %q does not name both problems", msg)
+	}
+}
+
+func TestResolveArgsDoesNotMutateInput(t *testing.T) {
+	args := map[string]any{"limit": "5"}
+	if _, err := ResolveArgs(salesDecl(), args); err != nil {
+		t.Fatalf("ResolveArgs: %v", err)
+	}
+	if v := args["limit"]; v != "5" {
+		t.Errorf("input map mutated: limit = %v", v)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// bigQueryBaseURL is the production BigQuery REST endpoint.
+const bigQueryBaseURL = "https://bigquery.googleapis.com"
+
+// BigQueryRunner is a RowQuerier executing queries through the BigQuery
+// REST API. It depends only on the standard library: authentication is
+// supplied by the HTTP client (e.g. an oauth2 transport carrying
+// application default credentials).
+type BigQueryRunner struct {
+	// ProjectID is the Google Cloud project billed for the query.
+	ProjectID string
+	// Client issues the requests; it must attach credentials. Nil uses
+	// http.DefaultClient, which only works against unauthenticated
+	// endpoints such as emulators.
+	Client *http.Client
+	// BaseURL overrides the API endpoint, for emulators and tests. Empty
+	// uses the production endpoint.
+	BaseURL string
+	// MaxResults caps how many rows the API returns; zero uses the API
+	// default.
+	MaxResults int
+}
+
+// bigQueryRequest and bigQueryResponse mirror the subset of the
+// jobs.query API this runner uses.
+type bigQueryRequest struct {
+	Query           string              `json:"query"`
+	UseLegacySQL    bool                `json:"useLegacySql"`
+	ParameterMode   string              `json:"parameterMode,omitempty"`
+	QueryParameters []bigQueryParameter `json:"queryParameters,omitempty"`
+	MaxResults      int                 `json:"maxResults,omitempty"`
+}
+
+type bigQueryParameter struct {
+	Name          string `json:"name"`
+	ParameterType struct {
+		Type string `json:"type"`
+	} `json:"parameterType"`
+	ParameterValue struct {
+		Value string `json:"value"`
+	} `json:"parameterValue"`
+}
+
+type bigQueryResponse struct {
+	JobComplete bool `json:"jobComplete"`
+	Schema      struct {
+		Fields []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"fields"`
+	} `json:"schema"`
+	Rows []struct {
+		F []struct {
+			V any `json:"v"`
+		} `json:"f"`
+	} `json:"rows"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// QueryRows runs the query with named parameters and converts the
+// response into column-keyed rows with Go-typed values.
+func (r *BigQueryRunner) QueryRows(ctx context.Context, query string, params map[string]any) ([]map[string]any, error) {
+	body := bigQueryRequest{
+		Query:      query,
+		MaxResults: r.MaxResults,
+	}
+	for name, value := range params {
+		param, err := bigQueryParam(name, value)
+		if err != nil {
+			return nil, err
+		}
+		body.QueryParameters = append(body.QueryParameters, param)
+	}
+	if len(body.QueryParameters) > 0 {
+		body.ParameterMode = "NAMED"
+	}
+
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("tools: encoding BigQuery request: %w", err)
+	}
+	baseURL := r.BaseURL
+	if baseURL == "" {
+		baseURL = bigQueryBaseURL
+	}
+	url := fmt.Sprintf("%s/bigquery/v2/projects/%s/queries", baseURL, r.ProjectID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(encoded))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("tools: BigQuery request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tools: BigQuery returned status %s", resp.Status)
+	}
+	var decoded bigQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("tools: decoding BigQuery response: %w", err)
+	}
+	if decoded.Error != nil {
+		return nil, fmt.Errorf("tools: BigQuery query failed: %s", decoded.Error.Message)
+	}
+	if !decoded.JobComplete {
+		return nil, fmt.Errorf("tools: BigQuery query did not complete within the request")
+	}
+
+	rows := make([]map[string]any, 0, len(decoded.Rows))
+	for _, raw := range decoded.Rows {
+		row := make(map[string]any, len(decoded.Schema.Fields))
+		for i, field := range decoded.Schema.Fields {
+			if i >= len(raw.F) {
+				break
+			}
+			row[field.Name] = bigQueryValue(field.Type, raw.F[i].V)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// bigQueryParam converts one Go argument into a typed query parameter.
+func bigQueryParam(name string, value any) (bigQueryParameter, error) {
+	var param bigQueryParameter
+	param.Name = name
+	switch v := value.(type) {
+	case string:
+		param.ParameterType.Type = "STRING"
+		param.ParameterValue.Value = v
+	case bool:
+		param.ParameterType.Type = "BOOL"
+		param.ParameterValue.Value = strconv.FormatBool(v)
+	case float64:
+		param.ParameterType.Type = "FLOAT64"
+		param.ParameterValue.Value = strconv.FormatFloat(v, 'g', -1, 64)
+	case int:
+		param.ParameterType.Type = "INT64"
+		param.ParameterValue.Value = strconv.Itoa(v)
+	case int64:
+		param.ParameterType.Type = "INT64"
+		param.ParameterValue.Value = strconv.FormatInt(v, 10)
+	default:
+		return param, fmt.Errorf("tools: unsupported BigQuery parameter type %T for %q", value, name)
+	}
+	return param, nil
+}
+
+// bigQueryValue converts one cell from the API's string encoding into the
+// Go type matching the column.
+func bigQueryValue(fieldType string, raw any) any {
+	s, ok := raw.(string)
+	if !ok {
+		return raw
+	}
+	switch fieldType {
+	case "INTEGER", "INT64", "FLOAT", "FLOAT64", "NUMERIC", "BIGNUMERIC":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case "BOOLEAN", "BOOL":
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBigQueryRunner(t *testing.T) {
+	var got bigQueryRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/projects/demo/queries") {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		w.Write([]byte(`{
+			"jobComplete": true,
+			"schema": {"fields": [
+				{"name": "category", "type": "STRING"},
+				{"name": "sales", "type": "INTEGER"},
+				{"name": "active", "type": "BOOLEAN"}
+			]},
+			"rows": [
+				{"f": [{"v": "Apparel"}, {"v": "41"}, {"v": "true"}]},
+				{"f": [{"v": "Other"}, {"v": "6"}, {"v": "false"}]}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	runner := &BigQueryRunner{ProjectID: "demo", BaseURL: server.URL, MaxResults: 100}
+	rows, err := runner.QueryRows(context.Background(),
+		"SELECT category, sales, active FROM t WHERE period = @period AND min_sales > @min",
+		map[string]any{"period": "Q1", "min": 5})
+	if err != nil {
+		t.Fatalf("QueryRows: %v", err)
+	}
+
+	if !strings.Contains(got.Query, "@period") || got.UseLegacySQL {
+		t.Errorf("request = %+v", got)
+	}
+	if got.ParameterMode != "NAMED" || len(got.QueryParameters) != 2 {
+		t.Fatalf("parameters = %+v", got.QueryParameters)
+	}
+	types := map[string]string{}
+	for _, p := range got.QueryParameters {
+		types[p.Name] = p.ParameterType.Type
+	}
+	if types["period"] != "STRING" || types["min"] != "INT64" {
+		t.Errorf("parameter types = %v", types)
+	}
+	if got.MaxResults != 100 {
+		t.Errorf("maxResults = %d, want 100", got.MaxResults)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("rows = %v", rows)
+	}
+	first := rows[0]
+	if first["category"] != "Apparel" || first["sales"] != 41.0 || first["active"] != true {
+		t.Errorf("row values not typed: %v", first)
+	}
+}
+
+func TestBigQueryRunnerErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		code int
+	}{
+		{"api error", `{"error": {"message": "syntax error"}}`, http.StatusOK},
+		{"incomplete job", `{"jobComplete": false}`, http.StatusOK},
+		{"http error", `denied`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.code)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			runner := &BigQueryRunner{ProjectID: "demo", BaseURL: server.URL}
+			if _, err := runner.QueryRows(context.Background(), "SELECT 1", nil); err == nil {
+				t.Error("QueryRows succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestBigQueryParamRejectsUnsupported(t *testing.T) {
+	runner := &BigQueryRunner{ProjectID: "demo", BaseURL: "http://unused.invalid"}
+	if _, err := runner.QueryRows(context.Background(), "SELECT 1", map[string]any{
+		"bad": []string{"no", "arrays"},
+	}); err == nil {
+		t.Error("unsupported parameter type accepted")
+	}
+}
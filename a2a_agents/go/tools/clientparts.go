@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import "context"
+
+// ClientPart is one piece of a tool result destined for the client rather
+// than the model. Exactly one field is set: Data becomes a data part on
+// the transport, Text a text part.
+type ClientPart struct {
+	Text string
+	Data map[string]any
+}
+
+// ClientRenderer is implemented by typed tool results (see NewTool) whose
+// value should reach the client as well as the model. The conversion
+// layer maps each reported part onto the transport's part types, so
+// results declare what the client sees instead of callers re-inspecting
+// the string-keyed model response.
+type ClientRenderer interface {
+	ClientParts() []ClientPart
+}
+
+// PartReporter is the optional Tool extension the conversion layer checks
+// for: RunWithParts returns the model response together with any client
+// parts the result reported. Tools built with NewTool implement it;
+// callers that only know the Tool interface keep working through Run.
+type PartReporter interface {
+	Tool
+	RunWithParts(ctx context.Context, args map[string]any) (map[string]any, []ClientPart, error)
+}
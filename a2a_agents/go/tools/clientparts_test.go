@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+// chartResult is a typed result that also renders itself for the client.
+type chartResult struct {
+	Title string  `json:"title"`
+	Total float64 `json:"total"`
+}
+
+func (r chartResult) ClientParts() []ClientPart {
+	return []ClientPart{
+		{Data: map[string]any{"title": r.Title, "total": r.Total}},
+		{Text: "Chart ready: " + r.Title},
+	}
+}
+
+func TestRunWithParts(t *testing.T) {
+	tool, err := NewTool("get_chart", "Builds a chart.",
+		func(ctx context.Context, args struct{}) (chartResult, error) {
+			return chartResult{Title: "Sales", Total: 42.0}, nil
+		})
+	if err != nil {
+		t.Fatalf("NewTool: %v", err)
+	}
+	reporter, ok := tool.(PartReporter)
+	if !ok {
+		t.Fatalf("NewTool result does not implement PartReporter")
+	}
+
+	response, parts, err := reporter.RunWithParts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("RunWithParts: %v", err)
+	}
+	if response["title"] != "Sales" || response["total"] != 42.0 {
+		t.Errorf("model response = %v", response)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("got %d client parts, want 2", len(parts))
+	}
+	if parts[0].Data["title"] != "Sales" {
+		t.Errorf("data part = %+v", parts[0])
+	}
+	if parts[1].Text != "Chart ready: Sales" {
+		t.Errorf("text part = %+v", parts[1])
+	}
+
+	// Run keeps returning the model response alone.
+	response, err = tool.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if response["title"] != "Sales" {
+		t.Errorf("Run response = %v", response)
+	}
+}
+
+func TestRunWithPartsPlainResult(t *testing.T) {
+	tool, err := NewTool("get_total", "Sums things.",
+		func(ctx context.Context, args struct{}) (reportResult, error) {
+			return reportResult{Summary: "ok"}, nil
+		})
+	if err != nil {
+		t.Fatalf("NewTool: %v", err)
+	}
+	_, parts, err := tool.(PartReporter).RunWithParts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("RunWithParts: %v", err)
+	}
+	if parts != nil {
+		t.Errorf("plain result reported parts: %+v", parts)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// RunFunc is the Run signature middleware wraps.
+type RunFunc func(ctx context.Context, args map[string]any) (map[string]any, error)
+
+// Middleware wraps one tool invocation: it receives the next stage of the
+// chain and returns the stage to run in its place. Cross-cutting concerns
+// — logging, auth checks, caching, metrics, timeouts — compose here once
+// instead of being re-implemented inside every tool.
+type Middleware func(decl *Declaration, next RunFunc) RunFunc
+
+// Wrap returns a Tool whose Run passes through the middlewares in order:
+// the first middleware is outermost. Tools reporting client parts keep
+// doing so through the wrapper; middleware sees only the model response.
+func Wrap(tool Tool, middlewares ...Middleware) Tool {
+	if len(middlewares) == 0 {
+		return tool
+	}
+	return &wrappedTool{tool: tool, middlewares: middlewares}
+}
+
+// WrapAll wraps every tool in the set with the same middleware chain.
+func WrapAll(set []Tool, middlewares ...Middleware) []Tool {
+	wrapped := make([]Tool, len(set))
+	for i, tool := range set {
+		wrapped[i] = Wrap(tool, middlewares...)
+	}
+	return wrapped
+}
+
+// wrappedTool threads Run (and RunWithParts, when the inner tool reports
+// client parts) through the middleware chain.
+type wrappedTool struct {
+	tool        Tool
+	middlewares []Middleware
+}
+
+func (w *wrappedTool) Declaration() *Declaration {
+	return w.tool.Declaration()
+}
+
+func (w *wrappedTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	response, _, err := w.RunWithParts(ctx, args)
+	return response, err
+}
+
+func (w *wrappedTool) RunWithParts(ctx context.Context, args map[string]any) (map[string]any, []ClientPart, error) {
+	// The innermost stage captures the client parts, which bypass the
+	// chain: middleware shapes what the model sees, not the client.
+	var parts []ClientPart
+	next := func(ctx context.Context, args map[string]any) (map[string]any, error) {
+		if reporter, ok := w.tool.(PartReporter); ok {
+			response, reported, err := reporter.RunWithParts(ctx, args)
+			parts = reported
+			return response, err
+		}
+		return w.tool.Run(ctx, args)
+	}
+	decl := w.tool.Declaration()
+	for i := len(w.middlewares) - 1; i >= 0; i-- {
+		next = w.middlewares[i](decl, next)
+	}
+	response, err := next(ctx, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, parts, nil
+}
+
+// Logging is a middleware recording each invocation and its duration
+// through slog.
+func Logging() Middleware {
+	return func(decl *Declaration, next RunFunc) RunFunc {
+		return func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			start := time.Now()
+			response, err := next(ctx, args)
+			if err != nil {
+				slog.Warn("tool failed", "tool", decl.Name, "duration", time.Since(start), "error", err)
+				return nil, err
+			}
+			slog.Info("tool succeeded", "tool", decl.Name, "duration", time.Since(start))
+			return response, nil
+		}
+	}
+}
+
+// Timeout is a middleware bounding each invocation; a tool that overruns
+// fails with a deadline error instead of stalling the tool loop.
+func Timeout(limit time.Duration) Middleware {
+	return func(decl *Declaration, next RunFunc) RunFunc {
+		return func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			ctx, cancel := context.WithTimeout(ctx, limit)
+			defer cancel()
+			response, err := next(ctx, args)
+			if err != nil && ctx.Err() == context.DeadlineExceeded {
+				return nil, fmt.Errorf("tools: %q exceeded its %v timeout", decl.Name, limit)
+			}
+			return response, err
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// echoTool records whether and how it was called.
+type echoTool struct {
+	delay  time.Duration
+	called *bool
+}
+
+func (t echoTool) Declaration() *Declaration {
+	return &Declaration{Name: "echo", Parameters: &Schema{Type: "object"}}
+}
+
+func (t echoTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	if t.called != nil {
+		*t.called = true
+	}
+	if t.delay > 0 {
+		select {
+		case <-time.After(t.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return map[string]any{"echo": args["value"]}, nil
+}
+
+// tagging returns a middleware appending its tag to order as it runs.
+func tagging(tag string, order *[]string) Middleware {
+	return func(decl *Declaration, next RunFunc) RunFunc {
+		return func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			*order = append(*order, tag)
+			return next(ctx, args)
+		}
+	}
+}
+
+func TestWrapOrdersMiddleware(t *testing.T) {
+	var order []string
+	tool := Wrap(echoTool{}, tagging("outer", &order), tagging("inner", &order))
+	if tool.Declaration().Name != "echo" {
+		t.Errorf("declaration = %+v", tool.Declaration())
+	}
+	result, err := tool.Run(context.Background(), map[string]any{"value": "hi"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result["echo"] != "hi" {
+		t.Errorf("result = %v", result)
+	}
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("order = %v, want [outer inner]", order)
+	}
+}
+
+func TestWrapWithoutMiddleware(t *testing.T) {
+	tool := echoTool{}
+	if wrapped := Wrap(tool); wrapped != Tool(tool) {
+		t.Error("Wrap without middleware should return the tool unchanged")
+	}
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	called := false
+	tool := Wrap(echoTool{delay: time.Minute, called: &called}, Timeout(5*time.Millisecond))
+	_, err := tool.Run(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Errorf("err = %v, want a timeout error", err)
+	}
+	if !called {
+		t.Error("tool never ran")
+	}
+
+	tool = Wrap(echoTool{}, Timeout(time.Minute))
+	if _, err := tool.Run(context.Background(), map[string]any{"value": "ok"}); err != nil {
+		t.Errorf("fast tool failed: %v", err)
+	}
+}
+
+func TestWrapKeepsClientParts(t *testing.T) {
+	inner, err := NewTool("get_chart", "Builds a chart.",
+		func(ctx context.Context, args struct{}) (chartResult, error) {
+			return chartResult{Title: "Sales"}, nil
+		})
+	if err != nil {
+		t.Fatalf("NewTool: %v", err)
+	}
+	var order []string
+	wrapped := Wrap(inner, tagging("mw", &order))
+	reporter, ok := wrapped.(PartReporter)
+	if !ok {
+		t.Fatal("wrapped tool lost PartReporter")
+	}
+	_, parts, err := reporter.RunWithParts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("RunWithParts: %v", err)
+	}
+	if len(parts) != 2 {
+		t.Errorf("got %d client parts, want 2", len(parts))
+	}
+	if len(order) != 1 {
+		t.Errorf("middleware ran %d times, want 1", len(order))
+	}
+}
+
+func TestWrapAll(t *testing.T) {
+	var order []string
+	set := WrapAll([]Tool{echoTool{}, echoTool{}}, tagging("mw", &order))
+	for _, tool := range set {
+		if _, err := tool.Run(context.Background(), nil); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	}
+	if len(order) != 2 {
+		t.Errorf("middleware ran %d times, want 2", len(order))
+	}
+}
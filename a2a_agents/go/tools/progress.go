@@ -0,0 +1,32 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import "context"
+
+// ProgressFunc receives progress from a long-running tool: a short
+// user-facing message and the fraction complete in [0, 1]. A zero
+// fraction means the tool cannot estimate completion.
+type ProgressFunc func(message string, fraction float64)
+
+// ProgressReporter is the optional Tool extension for long-running tools:
+// the invocation layer calls RunWithProgress with a callback the tool may
+// invoke as it works, and surfaces each update to the user so slow
+// queries do not appear frozen. Implementations must stop calling the
+// callback once RunWithProgress returns.
+type ProgressReporter interface {
+	Tool
+	RunWithProgress(ctx context.Context, args map[string]any, progress ProgressFunc) (map[string]any, error)
+}
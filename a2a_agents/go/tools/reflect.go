@@ -0,0 +1,224 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// NewTool builds a Tool from a typed function: the parameter schema is
+// derived from the Args struct by reflection and arguments are decoded
+// into it before each call, so tools stop hand-writing Parameters maps.
+//
+// Args must be a struct. Fields are named by their json tag and described
+// by these tags:
+//
+//	Period string `json:"time_period" description:"The period to report." default:"year" enum:"Q1,Q2,year"`
+//
+// A field is required unless it declares a default, its json tag carries
+// omitempty, or its type is a pointer. The function's result is returned
+// to the model as a JSON object; a result that does not marshal to an
+// object is wrapped under "result".
+func NewTool[Args, Result any](name, description string, run func(ctx context.Context, args Args) (Result, error)) (Tool, error) {
+	if name == "" {
+		return nil, fmt.Errorf("tools: tool needs a name")
+	}
+	if run == nil {
+		return nil, fmt.Errorf("tools: tool %q needs a function", name)
+	}
+	argsType := reflect.TypeOf((*Args)(nil)).Elem()
+	if argsType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tools: tool %q args type %s is not a struct", name, argsType)
+	}
+	params, err := structSchema(argsType)
+	if err != nil {
+		return nil, fmt.Errorf("tools: tool %q: %w", name, err)
+	}
+	return &reflectTool[Args, Result]{
+		decl: &Declaration{Name: name, Description: description, Parameters: params},
+		run:  run,
+	}, nil
+}
+
+// reflectTool adapts a typed function to the Tool interface.
+type reflectTool[Args, Result any] struct {
+	decl *Declaration
+	run  func(ctx context.Context, args Args) (Result, error)
+}
+
+func (t *reflectTool[Args, Result]) Declaration() *Declaration {
+	return t.decl
+}
+
+func (t *reflectTool[Args, Result]) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	response, _, err := t.RunWithParts(ctx, args)
+	return response, err
+}
+
+// RunWithParts implements PartReporter: when the typed result is a
+// ClientRenderer, the parts it reports ride alongside the model response.
+func (t *reflectTool[Args, Result]) RunWithParts(ctx context.Context, args map[string]any) (map[string]any, []ClientPart, error) {
+	var decoded Args
+	if err := DecodeArgs(t.decl, args, &decoded); err != nil {
+		return nil, nil, err
+	}
+	result, err := t.run(ctx, decoded)
+	if err != nil {
+		return nil, nil, err
+	}
+	var parts []ClientPart
+	if renderer, ok := any(result).(ClientRenderer); ok {
+		parts = renderer.ClientParts()
+	}
+	response, err := t.encode(result)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, parts, nil
+}
+
+// encode marshals the typed result into the model response object.
+func (t *reflectTool[Args, Result]) encode(result Result) (map[string]any, error) {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("tools: encoding result of %q: %w", t.decl.Name, err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		// Non-object results (slices, scalars) still reach the model.
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("tools: decoding result of %q: %w", t.decl.Name, err)
+		}
+		return map[string]any{"result": value}, nil
+	}
+	return out, nil
+}
+
+// structSchema derives an object schema from a struct type's fields and
+// tags.
+func structSchema(t reflect.Type) (*Schema, error) {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, omitempty := jsonName(field)
+		if name == "" {
+			continue
+		}
+		prop, err := fieldSchema(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		prop.Description = field.Tag.Get("description")
+		if enum := field.Tag.Get("enum"); enum != "" {
+			prop.Enum = strings.Split(enum, ",")
+		}
+		if raw, ok := field.Tag.Lookup("default"); ok {
+			value, err := parseDefault(prop.Type, raw)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			prop.Default = value
+		}
+		schema.Properties[name] = prop
+		if prop.Default == nil && !omitempty && field.Type.Kind() != reflect.Pointer {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema, nil
+}
+
+// jsonName returns the argument name for a struct field and whether its
+// tag carries omitempty. Fields tagged "-" are skipped.
+func jsonName(field reflect.StructField) (name string, omitempty bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty
+}
+
+// fieldSchema maps a Go type onto the schema subset declarations use.
+func fieldSchema(t reflect.Type) (*Schema, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := fieldSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Struct:
+		return structSchema(t)
+	case reflect.Map:
+		return &Schema{Type: "object"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported argument type %s", t)
+	}
+}
+
+// parseDefault converts a default tag's string into the declared type.
+func parseDefault(schemaType, raw string) (any, error) {
+	switch schemaType {
+	case "number", "integer":
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("default %q is not a %s", raw, schemaType)
+		}
+		return value, nil
+	case "boolean":
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("default %q is not a boolean", raw)
+		}
+		return value, nil
+	case "string":
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("default tags are only supported on scalar fields")
+	}
+}
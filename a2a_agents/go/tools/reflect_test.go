@@ -0,0 +1,144 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type reportArgs struct {
+	Period  string   `json:"time_period" description:"The period to report." default:"year" enum:"Q1,Q2,year"`
+	Region  string   `json:"region"`
+	Limit   int      `json:"limit,omitempty" description:"Max rows."`
+	Verbose *bool    `json:"verbose"`
+	Tags    []string `json:"tags,omitempty"`
+
+	unexported string
+	Skipped    string `json:"-"`
+}
+
+type reportResult struct {
+	Summary string  `json:"summary"`
+	Total   float64 `json:"total"`
+}
+
+func TestNewToolSchema(t *testing.T) {
+	tool, err := NewTool("get_report", "Builds a report.",
+		func(ctx context.Context, args reportArgs) (reportResult, error) {
+			return reportResult{}, nil
+		})
+	if err != nil {
+		t.Fatalf("NewTool: %v", err)
+	}
+	decl := tool.Declaration()
+	if decl.Name != "get_report" || decl.Description != "Builds a report." {
+		t.Errorf("declaration = %+v", decl)
+	}
+	params := decl.Parameters
+	if params.Type != "object" {
+		t.Errorf("parameters type = %q", params.Type)
+	}
+
+	period := params.Properties["time_period"]
+	if period == nil || period.Type != "string" || period.Description != "The period to report." {
+		t.Fatalf("time_period = %+v", period)
+	}
+	if period.Default != "year" || len(period.Enum) != 3 || period.Enum[2] != "year" {
+		t.Errorf("time_period default/enum = %v/%v", period.Default, period.Enum)
+	}
+	if got := params.Properties["limit"]; got == nil || got.Type != "integer" {
+		t.Errorf("limit = %+v", got)
+	}
+	if got := params.Properties["tags"]; got == nil || got.Type != "array" || got.Items.Type != "string" {
+		t.Errorf("tags = %+v", got)
+	}
+	if _, ok := params.Properties["unexported"]; ok {
+		t.Error("unexported field declared")
+	}
+	if _, ok := params.Properties["Skipped"]; ok {
+		t.Error("json:\"-\" field declared")
+	}
+
+	// Only region is required: time_period has a default, limit and tags
+	// are omitempty, verbose is a pointer.
+	if len(params.Required) != 1 || params.Required[0] != "region" {
+		t.Errorf("required = %v", params.Required)
+	}
+}
+
+func TestNewToolRun(t *testing.T) {
+	tool, err := NewTool("get_report", "Builds a report.",
+		func(ctx context.Context, args reportArgs) (reportResult, error) {
+			return reportResult{
+				Summary: args.Period + "/" + args.Region,
+				Total:   float64(args.Limit),
+			}, nil
+		})
+	if err != nil {
+		t.Fatalf("NewTool: %v", err)
+	}
+
+	result, err := tool.Run(context.Background(), map[string]any{
+		"region": "EMEA",
+		"limit":  "25", // models often send numbers as strings
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result["summary"] != "year/EMEA" {
+		t.Errorf("summary = %v, want the default period applied", result["summary"])
+	}
+	if result["total"] != 25.0 {
+		t.Errorf("total = %v, want the coerced limit", result["total"])
+	}
+
+	// Missing required arguments fail before the function runs.
+	if _, err := tool.Run(context.Background(), map[string]any{}); err == nil ||
+		!strings.Contains(err.Error(), "region") {
+		t.Errorf("missing required arg: err = %v", err)
+	}
+}
+
+func TestNewToolNonObjectResult(t *testing.T) {
+	tool, err := NewTool("list_names", "Lists names.",
+		func(ctx context.Context, args struct{}) ([]string, error) {
+			return []string{"a", "b"}, nil
+		})
+	if err != nil {
+		t.Fatalf("NewTool: %v", err)
+	}
+	result, err := tool.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if list, ok := result["result"].([]any); !ok || len(list) != 2 {
+		t.Errorf("result = %v, want the slice wrapped under result", result)
+	}
+}
+
+func TestNewToolRejectsNonStructArgs(t *testing.T) {
+	if _, err := NewTool("bad", "", func(ctx context.Context, args string) (string, error) {
+		return "", nil
+	}); err == nil {
+		t.Error("non-struct args accepted")
+	}
+	if _, err := NewTool("", "", func(ctx context.Context, args struct{}) (string, error) {
+		return "", nil
+	}); err == nil {
+		t.Error("empty name accepted")
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+)
+
+// RowQuerier runs one parameterized SQL query and returns its rows as
+// column-keyed maps. BigQueryRunner is the hosted implementation; tests
+// and samples can supply canned rows.
+type RowQuerier interface {
+	QueryRows(ctx context.Context, query string, params map[string]any) ([]map[string]any, error)
+}
+
+// ShapeFunc turns query rows into the result map handed back to the
+// model, typically a chart-ready structure. Nil wraps the rows as
+// {"rows": ..., "rowCount": n}.
+type ShapeFunc func(rows []map[string]any) map[string]any
+
+// SQLToolConfig configures NewSQLTool.
+type SQLToolConfig struct {
+	// Name and Description form the function declaration.
+	Name        string
+	Description string
+	// Query is the parameterized SQL statement; the model's arguments are
+	// passed as named query parameters, never spliced into the text.
+	Query string
+	// Parameters describes the tool's arguments to the model. Nil declares
+	// a tool without arguments.
+	Parameters *Schema
+	// Querier executes the query.
+	Querier RowQuerier
+	// MaxRows truncates the result; zero keeps every row. Keeping results
+	// small matters doubly here, since rows are replayed into the model's
+	// context.
+	MaxRows int
+	// Shape converts rows into the tool result; nil uses the default
+	// wrapping.
+	Shape ShapeFunc
+}
+
+// SQLTool is a reusable data tool backed by a parameterized SQL query: the
+// model supplies arguments, the querier runs the statement, and the shape
+// function converts rows into a chart-ready result.
+type SQLTool struct {
+	cfg SQLToolConfig
+}
+
+// NewSQLTool builds a SQLTool from the config.
+func NewSQLTool(cfg SQLToolConfig) (*SQLTool, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("tools: SQL tool needs a name")
+	}
+	if cfg.Query == "" {
+		return nil, fmt.Errorf("tools: SQL tool %q needs a query", cfg.Name)
+	}
+	if cfg.Querier == nil {
+		return nil, fmt.Errorf("tools: SQL tool %q needs a querier", cfg.Name)
+	}
+	return &SQLTool{cfg: cfg}, nil
+}
+
+// Declaration returns the function declaration sent to the model.
+func (t *SQLTool) Declaration() *Declaration {
+	return &Declaration{
+		Name:        t.cfg.Name,
+		Description: t.cfg.Description,
+		Parameters:  t.cfg.Parameters,
+	}
+}
+
+// Run executes the query with the model's arguments as named parameters
+// and returns the shaped rows.
+func (t *SQLTool) Run(ctx context.Context, args map[string]any) (map[string]any, error) {
+	rows, err := t.cfg.Querier.QueryRows(ctx, t.cfg.Query, args)
+	if err != nil {
+		return nil, fmt.Errorf("tools: %s query failed: %w", t.cfg.Name, err)
+	}
+	truncated := false
+	if t.cfg.MaxRows > 0 && len(rows) > t.cfg.MaxRows {
+		rows = rows[:t.cfg.MaxRows]
+		truncated = true
+	}
+	var result map[string]any
+	if t.cfg.Shape != nil {
+		result = t.cfg.Shape(rows)
+	} else {
+		result = map[string]any{"rows": rows, "rowCount": len(rows)}
+	}
+	if truncated {
+		result["truncated"] = true
+	}
+	return result, nil
+}
+
+// ChartShape shapes rows into the flat structure most chart components
+// bind to: the label column becomes "labels" and each value column one
+// entry in "series" with its values in row order.
+func ChartShape(labelColumn string, valueColumns ...string) ShapeFunc {
+	return func(rows []map[string]any) map[string]any {
+		labels := make([]any, len(rows))
+		series := make([]any, 0, len(valueColumns))
+		for i, row := range rows {
+			labels[i] = row[labelColumn]
+		}
+		for _, column := range valueColumns {
+			values := make([]any, len(rows))
+			for i, row := range rows {
+				values[i] = row[column]
+			}
+			series = append(series, map[string]any{"name": column, "values": values})
+		}
+		return map[string]any{"labels": labels, "series": series}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+// fakeQuerier records the query it saw and returns canned rows.
+type fakeQuerier struct {
+	query  string
+	params map[string]any
+	rows   []map[string]any
+	err    error
+}
+
+func (q *fakeQuerier) QueryRows(_ context.Context, query string, params map[string]any) ([]map[string]any, error) {
+	q.query = query
+	q.params = params
+	return q.rows, q.err
+}
+
+func TestNewSQLToolValidates(t *testing.T) {
+	querier := &fakeQuerier{}
+	tests := []struct {
+		name string
+		cfg  SQLToolConfig
+		ok   bool
+	}{
+		{"complete", SQLToolConfig{Name: "q", Query: "SELECT 1", Querier: querier}, true},
+		{"no name", SQLToolConfig{Query: "SELECT 1", Querier: querier}, false},
+		{"no query", SQLToolConfig{Name: "q", Querier: querier}, false},
+		{"no querier", SQLToolConfig{Name: "q", Query: "SELECT 1"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSQLTool(tt.cfg)
+			if (err == nil) != tt.ok {
+				t.Errorf("NewSQLTool: err = %v, want ok = %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSQLToolRun(t *testing.T) {
+	querier := &fakeQuerier{rows: []map[string]any{
+		{"category": "Apparel", "sales": 41.0},
+		{"category": "Electronics", "sales": 28.0},
+		{"category": "Other", "sales": 6.0},
+	}}
+	tool, err := NewSQLTool(SQLToolConfig{
+		Name:    "get_sales",
+		Query:   "SELECT category, sales FROM sales WHERE period = @period",
+		Querier: querier,
+		MaxRows: 2,
+	})
+	if err != nil {
+		t.Fatalf("NewSQLTool: %v", err)
+	}
+
+	result, err := tool.Run(context.Background(), map[string]any{"period": "Q1"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if querier.params["period"] != "Q1" {
+		t.Errorf("params = %v, want the model's arguments", querier.params)
+	}
+	rows := result["rows"].([]map[string]any)
+	if len(rows) != 2 || result["rowCount"] != 2 {
+		t.Errorf("result = %v, want two rows after the cap", result)
+	}
+	if result["truncated"] != true {
+		t.Errorf("truncation not reported: %v", result)
+	}
+}
+
+func TestSQLToolChartShape(t *testing.T) {
+	querier := &fakeQuerier{rows: []map[string]any{
+		{"month": "Jan", "online": 10.0, "retail": 4.0},
+		{"month": "Feb", "online": 12.0, "retail": 5.0},
+	}}
+	tool, err := NewSQLTool(SQLToolConfig{
+		Name:    "get_trend",
+		Query:   "SELECT month, online, retail FROM trend",
+		Querier: querier,
+		Shape:   ChartShape("month", "online", "retail"),
+	})
+	if err != nil {
+		t.Fatalf("NewSQLTool: %v", err)
+	}
+	result, err := tool.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	labels := result["labels"].([]any)
+	if len(labels) != 2 || labels[0] != "Jan" {
+		t.Errorf("labels = %v", labels)
+	}
+	series := result["series"].([]any)
+	if len(series) != 2 {
+		t.Fatalf("series = %v", series)
+	}
+	online := series[0].(map[string]any)
+	if online["name"] != "online" || online["values"].([]any)[1] != 12.0 {
+		t.Errorf("series[0] = %v", online)
+	}
+}
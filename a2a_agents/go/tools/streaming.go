@@ -0,0 +1,36 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import "context"
+
+// DataModelUpdate is one incremental value a streaming tool writes into a
+// surface's data model: the scalar (or flat map) at Path on the surface.
+type DataModelUpdate struct {
+	SurfaceID string
+	Path      string
+	Value     any
+}
+
+// Streamer is the optional Tool extension for tools that fill a surface
+// in as results arrive from a slow backend: the invocation layer drains
+// the updates channel while RunStreaming works, emitting each entry as a
+// dataModelUpdate to the client. The channel is closed by the caller
+// after RunStreaming returns; implementations must not send on it past
+// that point.
+type Streamer interface {
+	Tool
+	RunStreaming(ctx context.Context, args map[string]any, updates chan<- DataModelUpdate) (map[string]any, error)
+}
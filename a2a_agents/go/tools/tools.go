@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tools provides the tool abstraction used by A2UI agents to expose
+// functions to an LLM, along with helpers for declaring parameter schemas and
+// decoding the loosely typed arguments the model sends back.
+package tools
+
+import "context"
+
+// Schema is a minimal JSON-schema-shaped description of a tool parameter or
+// result. It covers the subset of JSON Schema that LLM function declarations
+// understand.
+type Schema struct {
+	// Type is one of "object", "array", "string", "number", "integer" or
+	// "boolean".
+	Type string `json:"type,omitempty"`
+	// Description explains the value to the model.
+	Description string `json:"description,omitempty"`
+	// Properties describes the fields of an object schema.
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// Required lists the property names that must be present on an object.
+	Required []string `json:"required,omitempty"`
+	// Items describes the elements of an array schema.
+	Items *Schema `json:"items,omitempty"`
+	// Enum restricts a value to a fixed set of options.
+	Enum []string `json:"enum,omitempty"`
+	// Default is applied when the model omits an optional argument.
+	Default any `json:"default,omitempty"`
+}
+
+// Declaration describes a tool to the LLM as a function declaration.
+type Declaration struct {
+	// Name is the function name the model uses to call the tool.
+	Name string `json:"name"`
+	// Description tells the model what the tool does and when to use it.
+	Description string `json:"description"`
+	// Parameters is an object schema describing the tool's arguments.
+	Parameters *Schema `json:"parameters,omitempty"`
+	// Response optionally declares the shape of Run's result map. It is
+	// not sent to the model: the invocation layer validates results
+	// against it, so data-shape bugs fail the call instead of reaching
+	// the model and producing broken surfaces.
+	Response *Schema `json:"-"`
+}
+
+// Tool is a function an agent exposes to the LLM.
+type Tool interface {
+	// Declaration returns the function declaration sent to the model.
+	Declaration() *Declaration
+	// Run executes the tool with the arguments provided by the model and
+	// returns a JSON-serializable result map that is fed back to the model.
+	Run(ctx context.Context, args map[string]any) (map[string]any, error)
+}
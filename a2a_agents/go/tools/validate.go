@@ -0,0 +1,117 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import "fmt"
+
+// ValidateResult checks a tool's result map against its declared response
+// schema (see Declaration.Response). A nil schema accepts everything.
+// Undeclared keys pass: the schema pins down the fields consumers rely
+// on, not the whole payload.
+func ValidateResult(decl *Declaration, result map[string]any) error {
+	if decl.Response == nil {
+		return nil
+	}
+	if err := validateValue(decl.Response, result, "result"); err != nil {
+		return fmt.Errorf("tools: result of %q: %w", decl.Name, err)
+	}
+	return nil
+}
+
+// validateValue checks one value against its schema node, reporting the
+// first mismatch with its path.
+func validateValue(schema *Schema, value any, path string) error {
+	switch schema.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an object, got %T", path, value)
+		}
+		for _, name := range schema.Required {
+			if v, ok := obj[name]; !ok || v == nil {
+				return fmt.Errorf("%s: missing required field %q", path, name)
+			}
+		}
+		for name, prop := range schema.Properties {
+			v, ok := obj[name]
+			if !ok || v == nil {
+				continue
+			}
+			if err := validateValue(prop, v, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		list, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an array, got %T", path, value)
+		}
+		if schema.Items == nil {
+			return nil
+		}
+		for i, elem := range list {
+			if err := validateValue(schema.Items, elem, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string, got %T", path, value)
+		}
+		if len(schema.Enum) > 0 && !containsString(schema.Enum, s) {
+			return fmt.Errorf("%s: %q is not one of %v", path, s, schema.Enum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected a boolean, got %T", path, value)
+		}
+	case "integer":
+		f, ok := asNumber(value)
+		if !ok || f != float64(int64(f)) {
+			return fmt.Errorf("%s: expected an integer, got %v", path, value)
+		}
+	case "number":
+		if _, ok := asNumber(value); !ok {
+			return fmt.Errorf("%s: expected a number, got %T", path, value)
+		}
+	}
+	return nil
+}
+
+func containsString(list []string, want string) bool {
+	for _, s := range list {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}
+
+// asNumber widens the numeric types Run results carry in practice.
+func asNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
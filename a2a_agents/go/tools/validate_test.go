@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func storeDecl() *Declaration {
+	return &Declaration{
+		Name: "get_store_sales",
+		Response: &Schema{
+			Type:     "object",
+			Required: []string{"locations"},
+			Properties: map[string]*Schema{
+				"zoom": {Type: "integer"},
+				"locations": {
+					Type: "array",
+					Items: &Schema{
+						Type:     "object",
+						Required: []string{"lat", "lng", "name"},
+						Properties: map[string]*Schema{
+							"lat":  {Type: "number"},
+							"lng":  {Type: "number"},
+							"name": {Type: "string"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  map[string]any
+		wantErr string
+	}{
+		{
+			name: "valid",
+			result: map[string]any{
+				"zoom": 10,
+				"locations": []any{
+					map[string]any{"lat": 34.0, "lng": -118, "name": "Downtown"},
+				},
+				"extra": "ignored",
+			},
+		},
+		{
+			name:    "missing required",
+			result:  map[string]any{"zoom": 10},
+			wantErr: `missing required field "locations"`,
+		},
+		{
+			name: "row missing lat",
+			result: map[string]any{
+				"locations": []any{
+					map[string]any{"lng": -118.2, "name": "Downtown"},
+				},
+			},
+			wantErr: `result.locations[0]: missing required field "lat"`,
+		},
+		{
+			name: "wrong element type",
+			result: map[string]any{
+				"locations": []any{
+					map[string]any{"lat": "34", "lng": -118.2, "name": "Downtown"},
+				},
+			},
+			wantErr: "result.locations[0].lat: expected a number",
+		},
+		{
+			name: "fractional integer",
+			result: map[string]any{
+				"zoom":      10.5,
+				"locations": []any{},
+			},
+			wantErr: "result.zoom: expected an integer",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateResult(storeDecl(), test.result)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateResult: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("err = %v, want it to contain %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateResultNoSchema(t *testing.T) {
+	decl := &Declaration{Name: "anything"}
+	if err := ValidateResult(decl, map[string]any{"free": "form"}); err != nil {
+		t.Errorf("nil response schema rejected result: %v", err)
+	}
+}
+
+func TestValidateResultEnum(t *testing.T) {
+	decl := &Declaration{
+		Name: "get_status",
+		Response: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"state": {Type: "string", Enum: []string{"ok", "degraded"}},
+			},
+		},
+	}
+	if err := ValidateResult(decl, map[string]any{"state": "ok"}); err != nil {
+		t.Errorf("valid enum rejected: %v", err)
+	}
+	err := ValidateResult(decl, map[string]any{"state": "down"})
+	if err == nil || !strings.Contains(err.Error(), "not one of") {
+		t.Errorf("err = %v, want an enum violation", err)
+	}
+}